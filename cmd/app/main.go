@@ -3,6 +3,8 @@ package main
 import (
 	"log/slog"
 	"os"
+	// база часовых поясов внутри бинарника: в alpine-образе нет tzdata
+	_ "time/tzdata"
 
 	"github.com/silentmol/avito-backend-trainee/internal/app"
 )
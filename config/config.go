@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-faster/errors"
 	"github.com/spf13/viper"
@@ -25,6 +26,87 @@ type Config struct {
 		Name string
 		Port string
 	}
+	Idempotency struct {
+		TTL           time.Duration `mapstructure:"ttl"`
+		PurgeInterval time.Duration `mapstructure:"purge_interval"`
+		Lease         time.Duration `mapstructure:"lease"`
+	}
+	Outbox struct {
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+		Lease        time.Duration `mapstructure:"lease"`
+		MaxAttempts  int           `mapstructure:"max_attempts"`
+		BackoffBase  time.Duration `mapstructure:"backoff_base"`
+		BackoffMax   time.Duration `mapstructure:"backoff_max"`
+	}
+	Webhook struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+	}
+	Notification struct {
+		Chat struct {
+			WebhookURL     string        `mapstructure:"webhook_url"`
+			Username       string        `mapstructure:"username"`
+			DirectMessages bool          `mapstructure:"direct_messages"`
+			Timeout        time.Duration `mapstructure:"timeout"`
+			Templates      struct {
+				ReviewerAssigned string `mapstructure:"reviewer_assigned"`
+				Merged           string `mapstructure:"merged"`
+				ReviewReminder   string `mapstructure:"review_reminder"`
+				Stale            string `mapstructure:"stale"`
+				Closed           string `mapstructure:"closed"`
+			}
+		}
+		Email struct {
+			Host           string        `mapstructure:"host"`
+			Port           int           `mapstructure:"port"`
+			Username       string        `mapstructure:"username"`
+			Password       string        `mapstructure:"password"`
+			From           string        `mapstructure:"from"`
+			TLS            string        `mapstructure:"tls"`
+			Timeout        time.Duration `mapstructure:"timeout"`
+			DigestInterval time.Duration `mapstructure:"digest_interval"`
+			TemplatesDir   string        `mapstructure:"templates_dir"`
+		}
+	}
+	ReviewSLA struct {
+		Enabled       bool          `mapstructure:"enabled"`
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+		BatchSize     int           `mapstructure:"batch_size"`
+		RemindAfter   time.Duration `mapstructure:"remind_after"`
+		EscalateAfter time.Duration `mapstructure:"escalate_after"`
+		// EscalationRetry - пауза перед повтором эскалации, которую не удалось выполнить.
+		EscalationRetry time.Duration `mapstructure:"escalation_retry"`
+	} `mapstructure:"review_sla"`
+	Assignment struct {
+		DefaultMode string `mapstructure:"default_mode"`
+		Seed        int64  `mapstructure:"seed"`
+	} `mapstructure:"assignment"`
+	StalePR struct {
+		Enabled        bool          `mapstructure:"enabled"`
+		CheckInterval  time.Duration `mapstructure:"check_interval"`
+		BatchSize      int           `mapstructure:"batch_size"`
+		StaleAfterDays int           `mapstructure:"stale_after_days"`
+		CloseAfterDays int           `mapstructure:"close_after_days"`
+	} `mapstructure:"stale_pr"`
+	OOO struct {
+		Enabled       bool          `mapstructure:"enabled"`
+		CheckInterval time.Duration `mapstructure:"check_interval"`
+		BatchSize     int           `mapstructure:"batch_size"`
+	} `mapstructure:"ooo"`
+	Tracing struct {
+		Exporter    string  `mapstructure:"exporter"`
+		Endpoint    string  `mapstructure:"endpoint"`
+		Insecure    bool    `mapstructure:"insecure"`
+		SampleRatio float64 `mapstructure:"sample_ratio"`
+	} `mapstructure:"tracing"`
+	Integrations struct {
+		GitHub struct {
+			Secret string
+		} `mapstructure:"github"`
+		GitLab struct {
+			Token string
+		} `mapstructure:"gitlab"`
+	}
 }
 
 func Load() (*Config, error) {
@@ -1,25 +1,64 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/go-faster/errors"
 	"github.com/silentmol/avito-backend-trainee/config"
+	balanceusecase "github.com/silentmol/avito-backend-trainee/internal/balance/usecase"
 	"github.com/silentmol/avito-backend-trainee/internal/controller/http"
+	idempotencyrepo "github.com/silentmol/avito-backend-trainee/internal/idempotency/adapter/postgres"
+	idempotencyusecase "github.com/silentmol/avito-backend-trainee/internal/idempotency/usecase"
+	integrationrepo "github.com/silentmol/avito-backend-trainee/internal/integration/adapter/postgres"
+	integrationusecase "github.com/silentmol/avito-backend-trainee/internal/integration/usecase"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+	notificationchat "github.com/silentmol/avito-backend-trainee/internal/notification/adapter/chat"
+	notificationrepo "github.com/silentmol/avito-backend-trainee/internal/notification/adapter/postgres"
+	notificationsmtp "github.com/silentmol/avito-backend-trainee/internal/notification/adapter/smtp"
+	notificationdomain "github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	notificationusecase "github.com/silentmol/avito-backend-trainee/internal/notification/usecase"
+	ooorepo "github.com/silentmol/avito-backend-trainee/internal/ooo/adapter/postgres"
+	ooousecase "github.com/silentmol/avito-backend-trainee/internal/ooo/usecase"
+	outboxlogger "github.com/silentmol/avito-backend-trainee/internal/outbox/adapter/logger"
+	outboxrepo "github.com/silentmol/avito-backend-trainee/internal/outbox/adapter/postgres"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	outboxusecase "github.com/silentmol/avito-backend-trainee/internal/outbox/usecase"
+	pairingrepo "github.com/silentmol/avito-backend-trainee/internal/pairing/adapter/postgres"
+	pairingusecase "github.com/silentmol/avito-backend-trainee/internal/pairing/usecase"
 	prrepo "github.com/silentmol/avito-backend-trainee/internal/pr/adapter/postgres"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	prusecase "github.com/silentmol/avito-backend-trainee/internal/pr/usecase"
+	slarepo "github.com/silentmol/avito-backend-trainee/internal/sla/adapter/postgres"
+	slausecase "github.com/silentmol/avito-backend-trainee/internal/sla/usecase"
+	stalerepo "github.com/silentmol/avito-backend-trainee/internal/stale/adapter/postgres"
+	staledomain "github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+	staleusecase "github.com/silentmol/avito-backend-trainee/internal/stale/usecase"
 	"github.com/silentmol/avito-backend-trainee/internal/storage"
 	teamrepo "github.com/silentmol/avito-backend-trainee/internal/team/adapter/postgres"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
 	teamusecase "github.com/silentmol/avito-backend-trainee/internal/team/usecase"
+	topupusecase "github.com/silentmol/avito-backend-trainee/internal/topup/usecase"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 	userrepo "github.com/silentmol/avito-backend-trainee/internal/user/adapter/postgres"
 	userusecase "github.com/silentmol/avito-backend-trainee/internal/user/usecase"
+	webhookrepo "github.com/silentmol/avito-backend-trainee/internal/webhook/adapter/postgres"
+	webhooksender "github.com/silentmol/avito-backend-trainee/internal/webhook/adapter/sender"
+	webhookusecase "github.com/silentmol/avito-backend-trainee/internal/webhook/usecase"
 	"github.com/silentmol/avito-backend-trainee/migrator"
 )
 
 func Run() error {
 	slog.Info("starting application")
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cfg, err := config.Load()
 	if err != nil {
 		slog.Error("failed to load config", slog.Any("error", err))
@@ -33,6 +72,27 @@ func Run() error {
 		slog.String("db_name", cfg.DB.Name),
 	)
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: cfg.App.Name,
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		slog.Error("failed to set up tracing", slog.Any("error", err))
+		return errors.Wrap(err, "tracing")
+	}
+	defer func() {
+		// ctx уже отменён сигналом, буфер спанов дописывается с отдельным таймаутом
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("failed to flush traces", slog.Any("error", err))
+		}
+	}()
+	slog.Info("tracing configured", slog.String("exporter", cfg.Tracing.Exporter))
+
 	conn, err := storage.GetConnect(cfg.GetDSN())
 	if err != nil {
 		slog.Error("failed to connect to database", slog.Any("error", err))
@@ -41,6 +101,11 @@ func Run() error {
 	defer conn.Close()
 	slog.Info("database connection established")
 
+	if err := metrics.RegisterPool(conn); err != nil {
+		slog.Error("failed to register pool metrics", slog.Any("error", err))
+		return errors.Wrap(err, "pool metrics")
+	}
+
 	if err := migrator.Migrate(cfg.GetDSN()); err != nil {
 		slog.Error("failed to apply migrations", slog.Any("error", err))
 		return errors.Wrap(err, "db migrate")
@@ -50,14 +115,198 @@ func Run() error {
 	userRepo := userrepo.NewUserRepository(conn)
 	teamRepo := teamrepo.NewTeamRepository(conn)
 	prRepo := prrepo.NewPRRepository(conn)
+	idempotencyRepo := idempotencyrepo.NewIdempotencyRepository(conn)
+	outboxRepo := outboxrepo.NewOutboxRepository(conn)
+	webhookRepo := webhookrepo.NewWebhookRepository(conn)
+	identityRepo := integrationrepo.NewIdentityRepository(conn)
+	pairingRepo := pairingrepo.NewRuleRepository(conn)
+	txManager := storage.NewTxManager(conn)
+
+	userUsecase := userusecase.NewUserUsecase(userRepo, txManager, outboxRepo)
+	teamUsecase := teamusecase.NewTeamUsecase(teamRepo, txManager, outboxRepo)
+	assignmentMode := prdomain.AssignmentMode(cfg.Assignment.DefaultMode)
+	if !assignmentMode.Valid() {
+		slog.Error("invalid default assignment mode", slog.String("mode", cfg.Assignment.DefaultMode))
+		return errors.Errorf("assignment mode: unknown mode %q", cfg.Assignment.DefaultMode)
+	}
+
+	prUsecase := prusecase.NewPRUsecase(prRepo, userRepo, teamRepo, pairingRepo, prRepo, txManager, outboxRepo, prusecase.Config{
+		DefaultMode: assignmentMode,
+		Seed:        cfg.Assignment.Seed,
+	})
+	pairingUsecase := pairingusecase.NewPairingUsecase(pairingRepo, userRepo)
+	idempotencyUsecase := idempotencyusecase.NewIdempotencyUsecase(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.Lease)
+	webhookUsecase := webhookusecase.NewWebhookUsecase(webhookRepo, webhooksender.NewSender(cfg.Webhook.Timeout))
+	integrationUsecase := integrationusecase.NewIntegrationUsecase(identityRepo, prUsecase, integrationusecase.Config{
+		GitHubSecret: cfg.Integrations.GitHub.Secret,
+		GitLabToken:  cfg.Integrations.GitLab.Token,
+	})
+
+	topupUsecase := topupusecase.NewTopUpUsecase(prRepo, teamRepo, prUsecase)
+	balanceUsecase := balanceusecase.NewBalanceUsecase(prRepo, teamRepo, pairingRepo, outboxRepo, prRepo, txManager)
+
+	sinks := []outboxusecase.Sink{outboxlogger.NewSink(), webhookUsecase, topupUsecase}
+
+	if chatCfg := cfg.Notification.Chat; chatCfg.WebhookURL != "" {
+		templates, err := notificationdomain.ParseTemplates(map[notificationdomain.Kind]string{
+			notificationdomain.KindReviewerAssigned: chatCfg.Templates.ReviewerAssigned,
+			notificationdomain.KindMerged:           chatCfg.Templates.Merged,
+			notificationdomain.KindReviewReminder:   chatCfg.Templates.ReviewReminder,
+			notificationdomain.KindStale:            chatCfg.Templates.Stale,
+			notificationdomain.KindClosed:           chatCfg.Templates.Closed,
+		})
+		if err != nil {
+			slog.Error("invalid chat notification templates", slog.Any("error", err))
+			return errors.Wrap(err, "chat templates")
+		}
+
+		sinks = append(sinks, notificationusecase.NewChatNotifier(
+			userRepo,
+			notificationrepo.NewDeliveryRepository(conn),
+			notificationchat.NewSender(chatCfg.WebhookURL, chatCfg.Username, chatCfg.Timeout),
+			templates,
+			notificationusecase.ChatConfig{DirectMessages: chatCfg.DirectMessages},
+		))
+		slog.Info("chat notifications enabled")
+	}
+
+	if emailCfg := cfg.Notification.Email; emailCfg.Host != "" {
+		templatesFS := notificationdomain.DefaultEmailTemplates()
+		if emailCfg.TemplatesDir != "" {
+			templatesFS = os.DirFS(emailCfg.TemplatesDir)
+		}
+
+		templates, err := notificationdomain.ParseEmailTemplates(templatesFS,
+			notificationdomain.KindReviewerAssigned,
+			notificationdomain.KindReviewerUnassigned,
+			notificationdomain.KindReviewReminder,
+			notificationdomain.KindStale,
+			notificationdomain.KindClosed,
+			notificationdomain.KindDigest,
+		)
+		if err != nil {
+			slog.Error("invalid email notification templates", slog.Any("error", err))
+			return errors.Wrap(err, "email templates")
+		}
+
+		smtpSender, err := notificationsmtp.NewSender(notificationsmtp.Config{
+			Host:     emailCfg.Host,
+			Port:     emailCfg.Port,
+			Username: emailCfg.Username,
+			Password: emailCfg.Password,
+			From:     emailCfg.From,
+			TLS:      emailCfg.TLS,
+			Timeout:  emailCfg.Timeout,
+		})
+		if err != nil {
+			slog.Error("invalid smtp config", slog.Any("error", err))
+			return errors.Wrap(err, "smtp config")
+		}
+
+		emailNotifier := notificationusecase.NewEmailNotifier(
+			userRepo,
+			notificationrepo.NewDigestRepository(conn),
+			notificationrepo.NewDeliveryRepository(conn),
+			smtpSender,
+			templates,
+			txManager,
+		)
+		sinks = append(sinks, emailNotifier)
+		go emailNotifier.RunDigest(ctx, emailCfg.DigestInterval)
+		slog.Info("email notifications enabled", slog.String("smtp_host", emailCfg.Host))
+	}
+
+	dispatcher := outboxusecase.NewDispatcher(outboxRepo, outboxusecase.DispatcherConfig{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		Lease:        cfg.Outbox.Lease,
+		Retry: outboxdomain.RetryPolicy{
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+			BaseDelay:   cfg.Outbox.BackoffBase,
+			MaxDelay:    cfg.Outbox.BackoffMax,
+		},
+	}, sinks...)
+
+	go idempotencyUsecase.RunPurge(ctx, cfg.Idempotency.PurgeInterval)
+	go dispatcher.Run(ctx)
+
+	if slaCfg := cfg.ReviewSLA; slaCfg.Enabled {
+		slaUsecase := slausecase.NewSLAUsecase(
+			slarepo.NewAssignmentRepository(conn),
+			prRepo,
+			prUsecase,
+			outboxRepo,
+			txManager,
+			storage.NewAdvisoryLock(conn, storage.LockReviewSLA),
+			slausecase.Config{
+				CheckInterval: slaCfg.CheckInterval,
+				BatchSize:     slaCfg.BatchSize,
+				Defaults: teamdomain.ReviewSLA{
+					RemindAfter:   slaCfg.RemindAfter,
+					EscalateAfter: slaCfg.EscalateAfter,
+				},
+				EscalationRetry: slaCfg.EscalationRetry,
+			},
+		)
+		go slaUsecase.Run(ctx)
+	}
+
+	stalePolicy := staledomain.Policy{
+		StaleAfter: time.Duration(cfg.StalePR.StaleAfterDays) * 24 * time.Hour,
+		CloseAfter: time.Duration(cfg.StalePR.CloseAfterDays) * 24 * time.Hour,
+	}
+	if err := stalePolicy.Validate(); err != nil {
+		slog.Error("invalid stale pull request policy", slog.Any("error", err))
+		return errors.Wrap(err, "stale policy")
+	}
+
+	staleUsecase := staleusecase.NewStaleUsecase(
+		prRepo,
+		stalerepo.NewActionRepository(conn),
+		outboxRepo,
+		txManager,
+		storage.NewAdvisoryLock(conn, storage.LockStalePRs),
+		staleusecase.Config{
+			CheckInterval: cfg.StalePR.CheckInterval,
+			BatchSize:     cfg.StalePR.BatchSize,
+			Policy:        stalePolicy,
+		},
+	)
+	if cfg.StalePR.Enabled && stalePolicy.Enabled() {
+		go staleUsecase.Run(ctx)
+	}
+
+	oooUsecase := ooousecase.NewOOOUsecase(
+		ooorepo.NewPeriodRepository(conn),
+		userRepo,
+		prRepo,
+		prUsecase,
+		outboxRepo,
+		txManager,
+		storage.NewAdvisoryLock(conn, storage.LockOOO),
+		ooousecase.Config{
+			CheckInterval: cfg.OOO.CheckInterval,
+			BatchSize:     cfg.OOO.BatchSize,
+		},
+	)
+	if cfg.OOO.Enabled {
+		go oooUsecase.Run(ctx)
+	}
+
+	handle := http.NewHandler(userUsecase, teamUsecase, prUsecase, webhookUsecase, integrationUsecase, staleUsecase,
+		oooUsecase, pairingUsecase, topupUsecase, balanceUsecase)
+	idempotency := http.NewIdempotency(idempotencyUsecase)
 
-	userUsecase := userusecase.NewUserUsecase(userRepo)
-	teamUsecase := teamusecase.NewTeamUsecase(teamRepo)
-	prUsecase := prusecase.NewPRUsecase(prRepo, userRepo, teamRepo)
+	app := getRouter(handle, idempotency, cfg.App.Name)
 
-	handle := http.NewHandler(userUsecase, teamUsecase, prUsecase)
+	go func() {
+		<-ctx.Done()
+		slog.Info("shutting down http server")
+		if err := app.Shutdown(); err != nil {
+			slog.Error("http server shutdown error", slog.Any("error", err))
+		}
+	}()
 
-	app := getRouter(handle, cfg.App.Name)
 	slog.Info("starting http server", slog.String("port", cfg.App.Port))
 	if err := app.Listen(":" + cfg.App.Port); err != nil {
 		slog.Error("http server listen error", slog.Any("error", err))
@@ -2,23 +2,67 @@ package app
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/silentmol/avito-backend-trainee/internal/controller/http"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
 )
 
-func getRouter(handle *http.Handle, appName string) *fiber.App {
+func getRouter(handle *http.Handle, idempotency *http.Idempotency, appName string) *fiber.App {
 	app := fiber.New(fiber.Config{
 		AppName: appName,
 	})
 
-	app.Post("/team/add", handle.AddTeam)
+	app.Use(http.Tracing)
+	app.Use(http.Metrics)
+	app.Get("/metrics", adaptor.HTTPHandler(metrics.Handler()))
+
+	app.Post("/team/add", idempotency.Handle, handle.AddTeam)
 	app.Get("/team/get", handle.GetTeam)
+	app.Post("/team/setReviewSLA", idempotency.Handle, handle.SetReviewSLA)
+	app.Post("/team/setCodeOwners", idempotency.Handle, handle.SetCodeOwners)
+	app.Get("/team/codeOwners", handle.GetCodeOwners)
+	app.Post("/team/setReviewerPolicy", idempotency.Handle, handle.SetReviewerPolicy)
+	app.Post("/team/topUp", idempotency.Handle, handle.TopUpTeam)
+	app.Post("/team/balance", idempotency.Handle, handle.BalanceTeam)
+
+	app.Post("/pool/set", idempotency.Handle, handle.SetPool)
+	app.Get("/pool/get", handle.GetPool)
 
-	app.Post("/users/setIsActive", handle.SetIsActive)
+	app.Post("/users/setIsActive", idempotency.Handle, handle.SetIsActive)
+	app.Post("/users/setChatHandle", idempotency.Handle, handle.SetChatHandle)
+	app.Post("/users/setEmailSettings", idempotency.Handle, handle.SetEmailSettings)
+	app.Post("/users/setWorkingHours", idempotency.Handle, handle.SetWorkingHours)
+	app.Post("/users/setTags", idempotency.Handle, handle.SetTags)
+	app.Post("/users/setLevel", idempotency.Handle, handle.SetLevel)
 	app.Get("/users/getReview", handle.GetReview)
+	app.Post("/users/ooo", idempotency.Handle, handle.AddOOO)
+	app.Get("/users/ooo", handle.ListOOO)
+	app.Post("/users/ooo/delete", idempotency.Handle, handle.DeleteOOO)
+
+	app.Post("/pairingRules/add", idempotency.Handle, handle.AddPairingRule)
+	app.Get("/pairingRules/list", handle.ListPairingRules)
+	app.Post("/pairingRules/delete", idempotency.Handle, handle.DeletePairingRule)
+
+	app.Post("/pullRequest/create", idempotency.Handle, handle.CreatePR)
+	app.Post("/pullRequest/merge", idempotency.Handle, handle.MergePR)
+	app.Post("/pullRequest/reassign", idempotency.Handle, handle.ReassignPR)
+	app.Post("/pullRequest/setReviewers", idempotency.Handle, handle.SetReviewers)
+	app.Post("/pullRequest/addReviewer", idempotency.Handle, handle.AddReviewer)
+	app.Post("/pullRequest/removeReviewer", idempotency.Handle, handle.RemoveReviewer)
+	app.Post("/pullRequest/topUp", idempotency.Handle, handle.TopUpPR)
+	app.Post("/pullRequest/rebalance", idempotency.Handle, handle.RebalancePR)
+	app.Get("/pullRequest/stale", handle.StalePRs)
+	app.Get("/pullRequest/assignments", handle.GetAssignments)
+
+	app.Post("/webhooks/add", idempotency.Handle, handle.AddWebhook)
+	app.Get("/webhooks/list", handle.ListWebhooks)
+	app.Post("/webhooks/delete", idempotency.Handle, handle.DeleteWebhook)
+	app.Get("/webhooks/deliveries", handle.GetWebhookDeliveries)
 
-	app.Post("/pullRequest/create", handle.CreatePR)
-	app.Post("/pullRequest/merge", handle.MergePR)
-	app.Post("/pullRequest/reassign", handle.ReassignPR)
+	app.Post("/integrations/github/webhook", idempotency.Handle, handle.GitHubWebhook)
+	app.Post("/integrations/gitlab/webhook", idempotency.Handle, handle.GitLabWebhook)
+	app.Post("/integrations/identities/add", idempotency.Handle, handle.AddIdentity)
+	app.Get("/integrations/identities/list", handle.ListIdentities)
 
 	return app
 }
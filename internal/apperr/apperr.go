@@ -7,6 +7,37 @@ var (
 	ErrTeamExists  = errors.New("team exists")
 	ErrPRExists    = errors.New("pr exists")
 	ErrPRMerged    = errors.New("pr merged")
+	ErrPRClosed    = errors.New("pr closed")
 	ErrNotAssigned = errors.New("not assigned to pr")
+	ErrAssigned    = errors.New("already assigned to pr")
 	ErrNoCandidate = errors.New("no candidate in team")
+
+	ErrIdempotencyKeyReused  = errors.New("idempotency key reused with different request")
+	ErrIdempotencyInProgress = errors.New("request with idempotency key in progress")
+
+	ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	ErrUnknownIdentity  = errors.New("unknown external identity")
+	ErrIdentityExists   = errors.New("external id already mapped")
+
+	ErrInvalidReviewSLA = errors.New("invalid review sla")
+
+	ErrInvalidOOO = errors.New("invalid out-of-office period")
+
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+
+	ErrInvalidCodeOwners = errors.New("invalid code owners")
+
+	ErrInvalidTags = errors.New("invalid tags")
+
+	ErrInvalidPool           = errors.New("invalid reviewer pool")
+	ErrInvalidReviewerPolicy = errors.New("invalid reviewer policy")
+	ErrLevelPolicy           = errors.New("reviewer level policy cannot be satisfied")
+	ErrInvalidPairingRule    = errors.New("invalid pairing rule")
+	ErrPairingRule           = errors.New("pairing rule violated")
+	ErrInvalidReviewers      = errors.New("invalid requested reviewers")
+
+	ErrPlanOutdated = errors.New("balance plan outdated")
 )
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+// BalanceTeamRequest - DryRun только возвращает предлагаемые переносы, не меняя PR.
+type BalanceTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	DryRun   bool   `json:"dry_run"`
+}
+
+type BalanceTeamResponse struct {
+	TeamName string                 `json:"team_name"`
+	DryRun   bool                   `json:"dry_run"`
+	Moves    []prdomain.BalanceMove `json:"moves"`
+	Loads    []prdomain.MemberLoad  `json:"loads"`
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/balance/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// BalanceTeam рассчитывает переносы назначений открытых PR авторов команды от загруженных
+// участников к менее загруженным с учётом правил выбора и, если это не DryRun, применяет их
+// в одной транзакции. Если за время расчёта PR изменились и перенос стал недопустим,
+// транзакция откатывается с ErrPlanOutdated.
+func (u *BalanceUsecase) BalanceTeam(ctx context.Context,
+	request *dto.BalanceTeamRequest) (*dto.BalanceTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "BalanceUsecase.BalanceTeam")
+	defer span.End()
+
+	team, err := u.teamReader.GetTeam(ctx, request.TeamName)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("BalanceUsecase.BalanceTeam: team not found",
+				slog.String("team_name", request.TeamName),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	prs, err := u.prProvider.ListOpenByAuthorTeam(ctx, team.Name)
+	if err != nil {
+		return nil, fmt.Errorf("list open pull requests in provider: %w", err)
+	}
+
+	memberIDs := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		memberIDs = append(memberIDs, m.ID)
+	}
+	openReviews, err := u.prProvider.CountOpenReviews(ctx, memberIDs)
+	if err != nil {
+		return nil, fmt.Errorf("count open reviews in provider: %w", err)
+	}
+
+	optsByAuthor := make(map[string]prdomain.SelectOptions)
+	for _, pr := range prs {
+		if _, ok := optsByAuthor[pr.AuthorId]; ok {
+			continue
+		}
+		opts, err := u.authorOptions(ctx, team, pr.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+		optsByAuthor[pr.AuthorId] = opts
+	}
+	optsOf := func(authorID string) prdomain.SelectOptions {
+		return optsByAuthor[authorID]
+	}
+
+	moves, loads := prdomain.PlanBalance(team, prs, openReviews, optsOf)
+	response := &dto.BalanceTeamResponse{
+		TeamName: team.Name,
+		DryRun:   request.DryRun,
+		Moves:    append(make([]prdomain.BalanceMove, 0, len(moves)), moves...),
+		Loads:    loads,
+	}
+
+	if request.DryRun || len(moves) == 0 {
+		slog.Info("BalanceUsecase.BalanceTeam: balance planned",
+			slog.String("team_name", team.Name),
+			slog.Bool("dry_run", request.DryRun),
+			slog.Int("moves", len(moves)),
+		)
+		return response, nil
+	}
+
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return u.apply(ctx, team, moves, optsOf)
+	})
+	if err != nil {
+		slog.Error("BalanceUsecase.BalanceTeam: failed to apply moves",
+			slog.String("team_name", team.Name),
+			slog.Int("moves", len(moves)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	metrics.Reassignments.Add(float64(len(moves)))
+	slog.Info("BalanceUsecase.BalanceTeam: team reviews balanced",
+		slog.String("team_name", team.Name),
+		slog.Int("moves", len(moves)),
+	)
+
+	return response, nil
+}
+
+// apply переносит назначения на заблокированных PR; каждый перенос перепроверяется по
+// актуальному состоянию PR, записывается в историю решений, а новый ревьювер получает
+// событие о назначении.
+func (u *BalanceUsecase) apply(ctx context.Context, team *teamdomain.Team, moves []prdomain.BalanceMove,
+	optsOf func(authorID string) prdomain.SelectOptions) error {
+
+	order := make([]string, 0)
+	byPR := make(map[string][]prdomain.BalanceMove)
+	for _, move := range moves {
+		if _, ok := byPR[move.PullRequestID]; !ok {
+			order = append(order, move.PullRequestID)
+		}
+		byPR[move.PullRequestID] = append(byPR[move.PullRequestID], move)
+	}
+
+	for _, prID := range order {
+		pr, err := u.prProvider.LockPR(ctx, prID)
+		if err != nil {
+			if err == apperr.ErrNotFound {
+				return fmt.Errorf("%w: pull request %s not found", apperr.ErrPlanOutdated, prID)
+			}
+			return fmt.Errorf("lock pull request in provider: %w", err)
+		}
+
+		decisions := make([]*prdomain.Decision, 0, len(byPR[prID]))
+		for _, move := range byPR[prID] {
+			opts := optsOf(pr.AuthorId)
+			if !prdomain.CanMove(team, pr, move.From, move.To, opts) {
+				return fmt.Errorf("%w: cannot move %s to %s on %s", apperr.ErrPlanOutdated,
+					move.From, move.To, prID)
+			}
+			decisions = append(decisions, prdomain.NewBalanceDecision(prdomain.ClonePR(pr), team, move, opts))
+			if err := pr.ReplaceReviewer(move.From, move.To); err != nil {
+				return err
+			}
+		}
+
+		updated, err := u.prProvider.UpdatePR(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("update pull request in provider: %w", err)
+		}
+
+		for _, decision := range decisions {
+			if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+				return fmt.Errorf("save assignment decision in provider: %w", err)
+			}
+		}
+
+		events := make([]outboxdomain.Event, 0, len(byPR[prID]))
+		for _, move := range byPR[prID] {
+			event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, updated.ID,
+				outboxdomain.ReviewerAssignedPayload{
+					PullRequest:        *updated,
+					ReviewerID:         move.To,
+					ReplacedReviewerID: move.From,
+				})
+			if err != nil {
+				return fmt.Errorf("build reviewer assigned event: %w", err)
+			}
+			events = append(events, event)
+		}
+
+		if err := u.eventWriter.Append(ctx, events...); err != nil {
+			return fmt.Errorf("append reviewer assigned events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// authorOptions - ограничения выбора для PR автора: требование политики команды к грейду
+// и действующие правила пар. Наставник не из команды считается доступным, чтобы его не сняли.
+func (u *BalanceUsecase) authorOptions(ctx context.Context, team *teamdomain.Team,
+	authorID string) (prdomain.SelectOptions, error) {
+
+	var opts prdomain.SelectOptions
+	if policy := team.ReviewerPolicy; policy != nil {
+		opts.MinLevel = policy.MinLevel
+		opts.MinLevelReviewers = policy.MinLevelReviewers
+	}
+
+	rules, err := u.pairingReader.ListAuthorRules(ctx, authorID, time.Now())
+	if err != nil {
+		return opts, fmt.Errorf("get pairing rules from provider: %w", err)
+	}
+
+	for _, rule := range rules {
+		switch rule.Kind {
+		case pairingdomain.KindExclude:
+			opts.Excluded = append(opts.Excluded, rule.ReviewerID)
+		case pairingdomain.KindRequire:
+			mentor := teamdomain.TeamMember{ID: rule.ReviewerID, IsActive: true}
+			for _, m := range team.Members {
+				if m.ID == rule.ReviewerID {
+					mentor = m
+				}
+			}
+			opts.Required = append(opts.Required, mentor)
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+type PRProvider interface {
+	// ListOpenByAuthorTeam возвращает открытые PR, авторы которых состоят в команде teamName.
+	ListOpenByAuthorTeam(ctx context.Context, teamName string) ([]prdomain.PullRequest, error)
+	// CountOpenReviews возвращает число открытых ревью каждого из reviewerIDs по всем PR.
+	CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error)
+	// LockPR читает PR с блокировкой строки до конца транзакции.
+	LockPR(ctx context.Context, id string) (*prdomain.PullRequest, error)
+	UpdatePR(ctx context.Context, pr *prdomain.PullRequest) (*prdomain.PullRequest, error)
+}
+
+type TeamReader interface {
+	GetTeam(ctx context.Context, teamName string) (*teamdomain.Team, error)
+}
+
+type PairingReader interface {
+	ListAuthorRules(ctx context.Context, authorID string, now time.Time) ([]pairingdomain.Rule, error)
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
+}
+
+// DecisionStore записывает переносы в историю решений о выборе ревьюверов.
+type DecisionStore interface {
+	SaveDecision(ctx context.Context, decision *prdomain.Decision) error
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// BalanceUsecase выравнивает число открытых ревью между участниками команды.
+type BalanceUsecase struct {
+	prProvider    PRProvider
+	teamReader    TeamReader
+	pairingReader PairingReader
+	eventWriter   EventWriter
+	decisions     DecisionStore
+	txManager     Transactor
+}
+
+func NewBalanceUsecase(
+	prProvider PRProvider,
+	teamReader TeamReader,
+	pairingReader PairingReader,
+	eventWriter EventWriter,
+	decisions DecisionStore,
+	txManager Transactor,
+) *BalanceUsecase {
+	return &BalanceUsecase{
+		prProvider:    prProvider,
+		teamReader:    teamReader,
+		pairingReader: pairingReader,
+		eventWriter:   eventWriter,
+		decisions:     decisions,
+		txManager:     txManager,
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/balance/dto"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceUsecase_BalanceTeam(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "a", IsActive: true},
+			{ID: "r1", IsActive: true},
+			{ID: "r2", IsActive: true},
+			{ID: "r3", IsActive: true},
+		},
+	}
+	openPRs := func() []prdomain.PullRequest {
+		return []prdomain.PullRequest{
+			{ID: "p1", AuthorId: "a", Status: prdomain.StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+			{ID: "p2", AuthorId: "a", Status: prdomain.StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+		}
+	}
+	wantMoves := []prdomain.BalanceMove{{PullRequestID: "p1", From: "r1", To: "r3"}}
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		teamErr   error
+		rules     []pairingdomain.Rule
+		elsewhere int
+		locked    *prdomain.PullRequest
+		wantMoves []prdomain.BalanceMove
+		wantErr   error
+	}{
+		{
+			name:      "dry run",
+			dryRun:    true,
+			wantMoves: wantMoves,
+		},
+		{
+			name:      "apply",
+			locked:    &openPRs()[0],
+			wantMoves: wantMoves,
+		},
+		{
+			name: "excluded by pairing rule",
+			rules: []pairingdomain.Rule{
+				{Kind: pairingdomain.KindExclude, AuthorID: "a", ReviewerID: "r3"},
+			},
+			wantMoves: []prdomain.BalanceMove{},
+		},
+		{
+			name:      "least loaded member busy on other teams",
+			elsewhere: 2,
+			wantMoves: []prdomain.BalanceMove{},
+		},
+		{
+			name: "plan outdated",
+			locked: &prdomain.PullRequest{
+				ID: "p1", AuthorId: "a", Status: prdomain.StatusMerged, AssignedReviewers: []string{"r1", "r2"},
+			},
+			wantErr: apperr.ErrPlanOutdated,
+		},
+		{
+			name:    "team not found",
+			teamErr: apperr.ErrNotFound,
+			wantErr: apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockBalancePRProvider(ctrl)
+			teamReader := mocks.NewMockBalanceTeamReader(ctrl)
+			pairingReader := mocks.NewMockBalancePairingReader(ctrl)
+			eventWriter := mocks.NewMockBalanceEventWriter(ctrl)
+			decisions := mocks.NewMockBalanceDecisionStore(ctrl)
+
+			if tt.teamErr != nil {
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(nil, tt.teamErr)
+			} else {
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+				prProvider.EXPECT().ListOpenByAuthorTeam(gomock.Any(), "backend").Return(openPRs(), nil)
+				prProvider.EXPECT().CountOpenReviews(gomock.Any(), []string{"a", "r1", "r2", "r3"}).
+					Return(map[string]int{"r1": 2, "r2": 2, "r3": tt.elsewhere}, nil)
+				pairingReader.EXPECT().ListAuthorRules(gomock.Any(), "a", gomock.Any()).Return(tt.rules, nil)
+			}
+
+			if tt.locked != nil {
+				prProvider.EXPECT().LockPR(gomock.Any(), "p1").Return(tt.locked, nil)
+			}
+			if tt.locked != nil && tt.wantErr == nil {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *prdomain.PullRequest) (*prdomain.PullRequest, error) {
+						assert.Equal(t, []string{"r3", "r2"}, pr.AssignedReviewers)
+						return pr, nil
+					})
+				decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, decision *prdomain.Decision) error {
+						assert.Equal(t, prdomain.DecisionBalance, decision.Kind)
+						assert.Equal(t, "r1", decision.Input.OldReviewerID)
+						assert.Equal(t, []string{"r3"}, decision.Reviewers)
+						// снимок PR до переноса
+						assert.Equal(t, []string{"r1", "r2"}, decision.Input.PullRequest.AssignedReviewers)
+						return nil
+					})
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventPRReviewerAssigned, events[0].Type)
+						return nil
+					})
+			}
+
+			uc := NewBalanceUsecase(prProvider, teamReader, pairingReader, eventWriter, decisions,
+				testutils.InlineTx{})
+
+			resp, err := uc.BalanceTeam(context.Background(), &dto.BalanceTeamRequest{
+				TeamName: "backend",
+				DryRun:   tt.dryRun,
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMoves, resp.Moves)
+			assert.Equal(t, tt.dryRun, resp.DryRun)
+		})
+	}
+}
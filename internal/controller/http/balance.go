@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	balancedto "github.com/silentmol/avito-backend-trainee/internal/balance/dto"
+)
+
+func (h *Handle) BalanceTeam(c *fiber.Ctx) error {
+	req := &balancedto.BalanceTeamRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("BalanceTeam: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.balance.BalanceTeam(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+		case errors.Is(err, apperr.ErrPlanOutdated):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PLAN_OUTDATED",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		slog.Error("BalanceTeam: failed to balance team reviews",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to balance team reviews")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -1,25 +1,53 @@
 package http
 
 import (
+	balanceusecase "github.com/silentmol/avito-backend-trainee/internal/balance/usecase"
+	integrationusecase "github.com/silentmol/avito-backend-trainee/internal/integration/usecase"
+	ooousecase "github.com/silentmol/avito-backend-trainee/internal/ooo/usecase"
+	pairingusecase "github.com/silentmol/avito-backend-trainee/internal/pairing/usecase"
 	prusecase "github.com/silentmol/avito-backend-trainee/internal/pr/usecase"
+	staleusecase "github.com/silentmol/avito-backend-trainee/internal/stale/usecase"
 	teamusecase "github.com/silentmol/avito-backend-trainee/internal/team/usecase"
+	topupusecase "github.com/silentmol/avito-backend-trainee/internal/topup/usecase"
 	userusecase "github.com/silentmol/avito-backend-trainee/internal/user/usecase"
+	webhookusecase "github.com/silentmol/avito-backend-trainee/internal/webhook/usecase"
 )
 
 type Handle struct {
-	user *userusecase.UserUsecase
-	team *teamusecase.TeamUsecase
-	pr   *prusecase.PRUsecase
+	user        *userusecase.UserUsecase
+	team        *teamusecase.TeamUsecase
+	pr          *prusecase.PRUsecase
+	webhook     *webhookusecase.WebhookUsecase
+	integration *integrationusecase.IntegrationUsecase
+	stale       *staleusecase.StaleUsecase
+	ooo         *ooousecase.OOOUsecase
+	pairing     *pairingusecase.PairingUsecase
+	topup       *topupusecase.TopUpUsecase
+	balance     *balanceusecase.BalanceUsecase
 }
 
 func NewHandler(
 	userUC *userusecase.UserUsecase,
 	teamUC *teamusecase.TeamUsecase,
 	prUC *prusecase.PRUsecase,
+	webhookUC *webhookusecase.WebhookUsecase,
+	integrationUC *integrationusecase.IntegrationUsecase,
+	staleUC *staleusecase.StaleUsecase,
+	oooUC *ooousecase.OOOUsecase,
+	pairingUC *pairingusecase.PairingUsecase,
+	topupUC *topupusecase.TopUpUsecase,
+	balanceUC *balanceusecase.BalanceUsecase,
 ) *Handle {
 	return &Handle{
-		user: userUC,
-		team: teamUC,
-		pr:   prUC,
+		user:        userUC,
+		team:        teamUC,
+		pr:          prUC,
+		webhook:     webhookUC,
+		integration: integrationUC,
+		stale:       staleUC,
+		ooo:         oooUC,
+		pairing:     pairingUC,
+		topup:       topupUC,
+		balance:     balanceUC,
 	}
 }
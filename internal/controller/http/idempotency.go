@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	idempotencydomain "github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+	idempotencyusecase "github.com/silentmol/avito-backend-trainee/internal/idempotency/usecase"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+)
+
+type Idempotency struct {
+	idempotency *idempotencyusecase.IdempotencyUsecase
+}
+
+func NewIdempotency(idempotencyUC *idempotencyusecase.IdempotencyUsecase) *Idempotency {
+	return &Idempotency{
+		idempotency: idempotencyUC,
+	}
+}
+
+// Handle - middleware для мутирующих ручек: повторяет сохранённый ответ
+// на запрос с тем же Idempotency-Key и отклоняет ключ, использованный с другим телом.
+func (i *Idempotency) Handle(c *fiber.Ctx) error {
+	key := c.Get(idempotencyKeyHeader)
+	if key == "" {
+		return c.Next()
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		slog.Warn("Idempotency: key too long", slog.Int("length", len(key)))
+		return fiber.NewError(fiber.StatusBadRequest, "Idempotency-Key is too long")
+	}
+
+	fingerprint := idempotencydomain.Fingerprint(c.Method(), c.Path(), c.Body())
+
+	record, err := i.idempotency.Begin(c.UserContext(), key, fingerprint)
+	if err != nil {
+		if errors.Is(err, apperr.ErrIdempotencyKeyReused) {
+			slog.Info("Idempotency: key reused with different request",
+				slog.String("idempotency_key", key),
+				slog.String("path", c.Path()),
+			)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "IDEMPOTENCY_KEY_REUSED",
+					"message": "Idempotency-Key was already used with a different request",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrIdempotencyInProgress) {
+			slog.Info("Idempotency: request with key in progress",
+				slog.String("idempotency_key", key),
+				slog.String("path", c.Path()),
+			)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "IDEMPOTENCY_IN_PROGRESS",
+					"message": "request with this Idempotency-Key is still in progress",
+				},
+			})
+		}
+
+		slog.Error("Idempotency: failed to check key",
+			slog.String("idempotency_key", key),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to check idempotency key")
+	}
+
+	if record != nil {
+		c.Set(idempotencyReplayedHeader, "true")
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Status(record.StatusCode).Send(record.Body)
+	}
+
+	// ошибки через fiber.Error (валидация, 5xx) не сохраняем - такой запрос можно повторить
+	if err := c.Next(); err != nil {
+		if releaseErr := i.idempotency.Release(c.UserContext(), key); releaseErr != nil {
+			slog.Error("Idempotency: failed to release key", slog.Any("error", releaseErr))
+		}
+		return err
+	}
+
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		if err := i.idempotency.Release(c.UserContext(), key); err != nil {
+			slog.Error("Idempotency: failed to release key", slog.Any("error", err))
+		}
+		return nil
+	}
+
+	body := append([]byte(nil), c.Response().Body()...)
+	if err := i.idempotency.Complete(c.UserContext(), key, status, body); err != nil {
+		slog.Error("Idempotency: failed to store response",
+			slog.String("idempotency_key", key),
+			slog.Any("error", err),
+		)
+		// не оставляем ключ зарезервированным до истечения TTL
+		if releaseErr := i.idempotency.Release(c.UserContext(), key); releaseErr != nil {
+			slog.Error("Idempotency: failed to release key", slog.Any("error", releaseErr))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	idempotencydomain "github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+	idempotencyusecase "github.com/silentmol/avito-backend-trainee/internal/idempotency/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRecords - хранилище ключей в памяти с той же семантикой резерва, что и у postgres-репозитория.
+type memoryRecords struct {
+	mu      sync.Mutex
+	records map[string]*idempotencydomain.Record
+}
+
+func newMemoryRecords() *memoryRecords {
+	return &memoryRecords{records: make(map[string]*idempotencydomain.Record)}
+}
+
+func (m *memoryRecords) Reserve(_ context.Context, record *idempotencydomain.Record) (*idempotencydomain.Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.records[record.Key]
+	if ok && (existing.Expired(record.CreatedAt) || existing.Abandoned(record.CreatedAt)) {
+		ok = false
+	}
+	if ok {
+		stored := *existing
+		return &stored, false, nil
+	}
+
+	stored := *record
+	m.records[record.Key] = &stored
+	return nil, true, nil
+}
+
+func (m *memoryRecords) Complete(_ context.Context, key string, statusCode int, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[key]; ok {
+		record.StatusCode = statusCode
+		record.Body = body
+	}
+	return nil
+}
+
+func (m *memoryRecords) Release(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.records[key]; ok && !record.Completed() {
+		delete(m.records, key)
+	}
+	return nil
+}
+
+func (m *memoryRecords) DeleteExpired(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newIdempotencyApp(records *memoryRecords, lease time.Duration, handler fiber.Handler) *fiber.App {
+	idempotency := NewIdempotency(idempotencyusecase.NewIdempotencyUsecase(records, time.Hour, lease))
+
+	app := fiber.New()
+	app.Post("/pullRequest/topUp", idempotency.Handle, handler)
+	return app
+}
+
+func doIdempotent(t *testing.T, app *fiber.App, key, body string) (int, string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/pullRequest/topUp", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody), resp.Header.Get(idempotencyReplayedHeader)
+}
+
+func TestIdempotency_Handle_Replay(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	app := newIdempotencyApp(newMemoryRecords(), time.Minute, func(c *fiber.Ctx) error {
+		calls.Add(1)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"added": []string{"u2"}})
+	})
+
+	status, body, replayed := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-1"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, replayed)
+
+	status, replayBody, replayed := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-1"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "true", replayed)
+	assert.JSONEq(t, body, replayBody)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_Handle_KeyReusedWithDifferentBody(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	app := newIdempotencyApp(newMemoryRecords(), time.Minute, func(c *fiber.Ctx) error {
+		calls.Add(1)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	status, _, _ := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-1"}`)
+	require.Equal(t, fiber.StatusOK, status)
+
+	status, body, _ := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-2"}`)
+	require.Equal(t, fiber.StatusUnprocessableEntity, status)
+	assert.Contains(t, body, "IDEMPOTENCY_KEY_REUSED")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_Handle_ConcurrentRequestInProgress(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	app := newIdempotencyApp(newMemoryRecords(), time.Minute, func(c *fiber.Ctx) error {
+		close(entered)
+		<-release
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		req := httptest.NewRequest(fiber.MethodPost, "/pullRequest/topUp", strings.NewReader(`{"pull_request_id":"pr-1"}`))
+		req.Header.Set(idempotencyKeyHeader, "key-1")
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			done <- 0
+			return
+		}
+		resp.Body.Close()
+		done <- resp.StatusCode
+	}()
+
+	<-entered
+	status, body, _ := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-1"}`)
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Contains(t, body, "IDEMPOTENCY_IN_PROGRESS")
+
+	close(release)
+	assert.Equal(t, fiber.StatusOK, <-done)
+}
+
+func TestIdempotency_Handle_AbandonedReservationTakenOver(t *testing.T) {
+	t.Parallel()
+
+	records := newMemoryRecords()
+	now := time.Now()
+	// резерв от процесса, который упал, не успев сохранить ответ
+	records.records["key-1"] = &idempotencydomain.Record{
+		Key:         "key-1",
+		Fingerprint: idempotencydomain.Fingerprint(fiber.MethodPost, "/pullRequest/topUp", []byte(`{"pull_request_id":"pr-1"}`)),
+		CreatedAt:   now.Add(-2 * time.Minute),
+		ExpiresAt:   now.Add(time.Hour),
+		LockedUntil: now.Add(-time.Minute),
+	}
+
+	var calls atomic.Int32
+	app := newIdempotencyApp(records, time.Minute, func(c *fiber.Ctx) error {
+		calls.Add(1)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	status, _, replayed := doIdempotent(t, app, "key-1", `{"pull_request_id":"pr-1"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Empty(t, replayed)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	integrationdto "github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+)
+
+func (h *Handle) GitHubWebhook(c *fiber.Ctx) error {
+	req := &integrationdto.GitHubWebhookRequest{
+		Event:     c.Get(domain.GitHubEventHeader),
+		Signature: c.Get(domain.GitHubSignatureHeader),
+		// тело копируется: fiber переиспользует буфер после ответа
+		Body: append([]byte(nil), c.Body()...),
+	}
+
+	resp, err := h.integration.HandleGitHubWebhook(c.UserContext(), req)
+	if err != nil {
+		return integrationError(c, "GitHubWebhook", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) GitLabWebhook(c *fiber.Ctx) error {
+	req := &integrationdto.GitLabWebhookRequest{
+		Event: c.Get(domain.GitLabEventHeader),
+		Token: c.Get(domain.GitLabTokenHeader),
+		Body:  append([]byte(nil), c.Body()...),
+	}
+
+	resp, err := h.integration.HandleGitLabWebhook(c.UserContext(), req)
+	if err != nil {
+		return integrationError(c, "GitLabWebhook", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) AddIdentity(c *fiber.Ctx) error {
+	req := &integrationdto.AddIdentityRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("AddIdentity: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("AddIdentity: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.integration.AddIdentity(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+		return integrationError(c, "AddIdentity", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"identity": resp.Identity,
+	})
+}
+
+func (h *Handle) ListIdentities(c *fiber.Ctx) error {
+	req := &integrationdto.ListIdentitiesRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("ListIdentities: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.integration.ListIdentities(c.UserContext(), req)
+	if err != nil {
+		return integrationError(c, "ListIdentities", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// integrationError переводит ошибки интеграций в ответ API.
+func integrationError(c *fiber.Ctx, op string, err error) error {
+	switch {
+	case errors.Is(err, apperr.ErrInvalidSignature):
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_SIGNATURE",
+				"message": "webhook signature verification failed",
+			},
+		})
+	case errors.Is(err, apperr.ErrInvalidPayload):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_PAYLOAD",
+				"message": err.Error(),
+			},
+		})
+	case errors.Is(err, apperr.ErrIdentityExists):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "IDENTITY_EXISTS",
+				"message": "external id is already mapped to another login",
+			},
+		})
+	case errors.Is(err, apperr.ErrUnknownIdentity):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "UNKNOWN_IDENTITY",
+				"message": err.Error(),
+			},
+		})
+	case errors.Is(err, apperr.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "NOT_FOUND",
+				"message": "resource not found",
+			},
+		})
+	}
+
+	slog.Error(op+": integration failure", slog.Any("error", err))
+	return fiber.NewError(fiber.StatusInternalServerError, "integration request failed")
+}
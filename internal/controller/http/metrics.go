@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+)
+
+// unmatchedRoute - метка для запросов, не попавших ни в одну ручку, чтобы произвольные
+// пути не раздували число рядов метрик.
+const unmatchedRoute = "unmatched"
+
+// Metrics - middleware, которое пишет длительность каждого запроса по шаблону маршрута
+// и статусу, а для ответов с ошибкой - код ошибки из тела.
+func Metrics(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	status, code := responseStatus(c, err)
+
+	// строки fiber живут только до конца запроса, а метки хранятся в реестре
+	metrics.HTTPRequestDuration.
+		WithLabelValues(utils.CopyString(c.Method()), routePattern(c, err), strconv.Itoa(status)).
+		Observe(time.Since(start).Seconds())
+	if code != "" {
+		metrics.HTTPErrors.WithLabelValues(code).Inc()
+	}
+
+	return err
+}
+
+// responseStatus - итоговый статус ответа с учётом ошибки, которую вернёт обработчик
+// ошибок fiber, и код ошибки; для успешных ответов код пустой.
+func responseStatus(c *fiber.Ctx, err error) (int, string) {
+	status := c.Response().StatusCode()
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		return fiberErr.Code, statusCode(fiberErr.Code)
+	case err != nil:
+		return fiber.StatusInternalServerError, statusCode(fiber.StatusInternalServerError)
+	case status >= fiber.StatusBadRequest:
+		return status, bodyErrorCode(c.Response().Body(), status)
+	}
+	return status, ""
+}
+
+// routePattern - шаблон маршрута запроса.
+func routePattern(c *fiber.Ctx, err error) string {
+	// ручки отвечают 404 телом с кодом, ошибку 404 возвращает только роутер
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusNotFound {
+		return unmatchedRoute
+	}
+	return c.Route().Path
+}
+
+// bodyErrorCode достаёт код из тела {"error": {"code": ...}}; без кода используется статус.
+func bodyErrorCode(body []byte, status int) string {
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &resp) == nil && resp.Error.Code != "" {
+		return resp.Error.Code
+	}
+	return statusCode(status)
+}
+
+// statusCode - код для ошибок без тела в формате API, например BAD_REQUEST.
+func statusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(status), " ", "_"))
+}
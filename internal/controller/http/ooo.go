@@ -0,0 +1,121 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	ooodto "github.com/silentmol/avito-backend-trainee/internal/ooo/dto"
+)
+
+func (h *Handle) AddOOO(c *fiber.Ctx) error {
+	req := &ooodto.AddPeriodRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("AddOOO: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("AddOOO: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.ooo.AddPeriod(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidOOO) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_OOO",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("AddOOO: failed to add out-of-office period",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to add out-of-office period")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"period": resp.Period,
+	})
+}
+
+func (h *Handle) ListOOO(c *fiber.Ctx) error {
+	req := &ooodto.ListPeriodsRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("ListOOO: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.ooo.ListPeriods(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("ListOOO: failed to list out-of-office periods",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to list out-of-office periods")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) DeleteOOO(c *fiber.Ctx) error {
+	req := &ooodto.DeletePeriodRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("DeleteOOO: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.ooo.DeletePeriod(c.UserContext(), req); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "out-of-office period not found",
+				},
+			})
+		}
+
+		slog.Error("DeleteOOO: failed to delete out-of-office period",
+			slog.Int64("period_id", req.PeriodID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to delete out-of-office period")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
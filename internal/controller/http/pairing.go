@@ -0,0 +1,122 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	pairingdto "github.com/silentmol/avito-backend-trainee/internal/pairing/dto"
+)
+
+func (h *Handle) AddPairingRule(c *fiber.Ctx) error {
+	req := &pairingdto.AddRuleRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("AddPairingRule: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("AddPairingRule: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pairing.AddRule(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidPairingRule) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_PAIRING_RULE",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("AddPairingRule: failed to add pairing rule",
+			slog.String("author_id", req.AuthorID),
+			slog.String("reviewer_id", req.ReviewerID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to add pairing rule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"rule": resp.Rule,
+	})
+}
+
+func (h *Handle) ListPairingRules(c *fiber.Ctx) error {
+	req := &pairingdto.ListRulesRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("ListPairingRules: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pairing.ListRules(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("ListPairingRules: failed to list pairing rules",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to list pairing rules")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) DeletePairingRule(c *fiber.Ctx) error {
+	req := &pairingdto.DeleteRuleRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("DeletePairingRule: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.pairing.DeleteRule(c.UserContext(), req); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pairing rule not found",
+				},
+			})
+		}
+
+		slog.Error("DeletePairingRule: failed to delete pairing rule",
+			slog.Int64("rule_id", req.RuleID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to delete pairing rule")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdto "github.com/silentmol/avito-backend-trainee/internal/team/dto"
+)
+
+func (h *Handle) SetPool(c *fiber.Ctx) error {
+	req := &teamdto.SetPoolRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetPool: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetPool: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.SetPool(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidPool) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_POOL",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		slog.Error("SetPool: failed to set reviewer pool",
+			slog.String("pool_name", req.PoolName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to set reviewer pool")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) GetPool(c *fiber.Ctx) error {
+	req := &teamdto.GetPoolRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("GetPool: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.GetPool(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pool not found",
+				},
+			})
+		}
+
+		slog.Error("GetPool: failed to get reviewer pool",
+			slog.String("pool_name", req.PoolName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to get reviewer pool")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -23,8 +23,26 @@ func (h *Handle) CreatePR(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	resp, err := h.pr.CreatePR(c.Context(), req)
+	resp, err := h.pr.CreatePR(c.UserContext(), req)
 	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidTags) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_TAGS",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrInvalidReviewers) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_REVIEWERS",
+					"message": err.Error(),
+				},
+			})
+		}
+
 		if errors.Is(err, apperr.ErrNotFound) {
 			slog.Info("CreatePR: author or team not found",
 				slog.String("pr_id", req.PrID),
@@ -64,9 +82,7 @@ func (h *Handle) CreatePR(c *fiber.Ctx) error {
 		slog.String("status", string(resp.PullRequest.Status)),
 	)
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"pr": resp.PullRequest,
-	})
+	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
 func (h *Handle) MergePR(c *fiber.Ctx) error {
@@ -81,7 +97,7 @@ func (h *Handle) MergePR(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	resp, err := h.pr.MergePR(c.Context(), req)
+	resp, err := h.pr.MergePR(c.UserContext(), req)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			slog.Info("MergePR: pull request not found",
@@ -124,7 +140,7 @@ func (h *Handle) ReassignPR(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	resp, err := h.pr.ReassignPR(c.Context(), req)
+	resp, err := h.pr.ReassignPR(c.UserContext(), req)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			slog.Info("ReassignPR: pull request or user not found",
@@ -151,6 +167,18 @@ func (h *Handle) ReassignPR(c *fiber.Ctx) error {
 			})
 		}
 
+		if errors.Is(err, apperr.ErrPRClosed) {
+			slog.Info("ReassignPR: PR closed as stale",
+				slog.String("pr_id", req.PrID),
+			)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot reassign on closed PR",
+				},
+			})
+		}
+
 		if errors.Is(err, apperr.ErrNotAssigned) {
 			slog.Info("ReassignPR: reviewer not assigned to PR",
 				slog.String("pr_id", req.PrID),
@@ -164,15 +192,46 @@ func (h *Handle) ReassignPR(c *fiber.Ctx) error {
 			})
 		}
 
+		if errors.Is(err, apperr.ErrLevelPolicy) {
+			slog.Info("ReassignPR: level policy unsatisfied",
+				slog.String("pr_id", req.PrID),
+				slog.String("old_reviewer_id", req.OldReviewerId),
+			)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "LEVEL_POLICY_UNSATISFIED",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPairingRule) {
+			slog.Info("ReassignPR: reviewer required by pairing rule",
+				slog.String("pr_id", req.PrID),
+				slog.String("old_reviewer_id", req.OldReviewerId),
+			)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PAIRING_RULE",
+					"message": err.Error(),
+				},
+			})
+		}
+
 		if errors.Is(err, apperr.ErrNoCandidate) {
 			slog.Info("ReassignPR: no candidate found in team",
 				slog.String("pr_id", req.PrID),
 				slog.String("old_reviewer_id", req.OldReviewerId),
 			)
+			// если кандидатов не осталось из-за правил пар, объяснение есть в тексте ошибки
+			message := "no active replacement candidate in team"
+			if err != apperr.ErrNoCandidate {
+				message = err.Error()
+			}
 			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 				"error": fiber.Map{
 					"code":    "NO_CANDIDATE",
-					"message": "no active replacement candidate in team",
+					"message": message,
 				},
 			})
 		}
@@ -190,8 +249,348 @@ func (h *Handle) ReassignPR(c *fiber.Ctx) error {
 		slog.String("replaced_by", resp.ReplacedBy),
 	)
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"pr":          resp.PullRequest,
-		"replaced_by": resp.ReplacedBy,
-	})
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) GetAssignments(c *fiber.Ctx) error {
+	req := &prdto.GetAssignmentsRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("GetAssignments: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.GetAssignments(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request not found",
+				},
+			})
+		}
+
+		slog.Error("GetAssignments: failed to get assignment decisions",
+			slog.String("pr_id", req.PrID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to get assignment decisions")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) SetReviewers(c *fiber.Ctx) error {
+	req := &prdto.SetReviewersRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetReviewers: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.SetReviewers(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request or author not found",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRMerged) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_MERGED",
+					"message": "cannot change reviewers on merged PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRClosed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot change reviewers on closed PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrInvalidReviewers) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_REVIEWERS",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrLevelPolicy) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "LEVEL_POLICY_UNSATISFIED",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPairingRule) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PAIRING_RULE",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		slog.Error("SetReviewers: failed to set reviewers",
+			slog.String("pr_id", req.PrID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to set reviewers")
+	}
+
+	slog.Info("SetReviewers: reviewers replaced",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.Any("reviewers", resp.PullRequest.AssignedReviewers),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) AddReviewer(c *fiber.Ctx) error {
+	req := &prdto.AddReviewerRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("AddReviewer: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.AddReviewer(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request or author not found",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRMerged) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_MERGED",
+					"message": "cannot add reviewer on merged PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRClosed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot add reviewer on closed PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrAssigned) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "ALREADY_ASSIGNED",
+					"message": "reviewer is already assigned to this PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrInvalidReviewers) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_REVIEWERS",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNoCandidate) {
+			message := "no available reviewer in team"
+			if err != apperr.ErrNoCandidate {
+				message = err.Error()
+			}
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NO_CANDIDATE",
+					"message": message,
+				},
+			})
+		}
+
+		slog.Error("AddReviewer: failed to add reviewer",
+			slog.String("pr_id", req.PrID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to add reviewer")
+	}
+
+	slog.Info("AddReviewer: reviewer added",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.String("reviewer_id", resp.Added),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) RemoveReviewer(c *fiber.Ctx) error {
+	req := &prdto.RemoveReviewerRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("RemoveReviewer: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.RemoveReviewer(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request not found",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRMerged) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_MERGED",
+					"message": "cannot remove reviewer on merged PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRClosed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot remove reviewer on closed PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNotAssigned) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_ASSIGNED",
+					"message": "reviewer is not assigned to this PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrLevelPolicy) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "LEVEL_POLICY_UNSATISFIED",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPairingRule) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PAIRING_RULE",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		slog.Error("RemoveReviewer: failed to remove reviewer",
+			slog.String("pr_id", req.PrID),
+			slog.String("reviewer_id", req.ReviewerID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to remove reviewer")
+	}
+
+	slog.Info("RemoveReviewer: reviewer removed",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.String("reviewer_id", resp.Removed),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) RebalancePR(c *fiber.Ctx) error {
+	req := &prdto.RebalancePRRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("RebalancePR: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.RebalancePR(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request or author not found",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRMerged) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_MERGED",
+					"message": "cannot rebalance merged PR",
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrPRClosed) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot rebalance closed PR",
+				},
+			})
+		}
+
+		slog.Error("RebalancePR: failed to rebalance PR",
+			slog.String("pr_id", req.PrID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to rebalance pull request")
+	}
+
+	slog.Info("RebalancePR: pull request rebalanced",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.Int("changes", len(resp.Changes)),
+	)
+
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
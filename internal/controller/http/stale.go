@@ -0,0 +1,18 @@
+package http
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *Handle) StalePRs(c *fiber.Ctx) error {
+	resp, err := h.stale.Report(c.UserContext(), time.Now())
+	if err != nil {
+		slog.Error("StalePRs: failed to build stale report", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to build stale report")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -40,7 +40,7 @@ func (h *Handle) AddTeam(c *fiber.Ctx) error {
 		seen[m.ID] = struct{}{}
 	}
 
-	resp, err := h.team.CreateTeam(c.Context(), req)
+	resp, err := h.team.CreateTeam(c.UserContext(), req)
 	if err != nil {
 		if errors.Is(err, apperr.ErrTeamExists) {
 			slog.Info("AddTeam: team already exists",
@@ -81,7 +81,7 @@ func (h *Handle) GetTeam(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "team_name is required")
 	}
 
-	resp, err := h.team.GetTeam(c.Context(), req)
+	resp, err := h.team.GetTeam(c.UserContext(), req)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			slog.Info("GetTeam: team not found",
@@ -109,3 +109,164 @@ func (h *Handle) GetTeam(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(resp.Team)
 }
+
+func (h *Handle) SetReviewSLA(c *fiber.Ctx) error {
+	req := &teamdto.SetReviewSLARequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetReviewSLA: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetReviewSLA: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.SetReviewSLA(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrInvalidReviewSLA):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_REVIEW_SLA",
+					"message": err.Error(),
+				},
+			})
+		case errors.Is(err, apperr.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+		}
+
+		slog.Error("SetReviewSLA: failed to set review sla",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to set review sla")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"review_sla": resp,
+	})
+}
+
+func (h *Handle) SetCodeOwners(c *fiber.Ctx) error {
+	req := &teamdto.SetCodeOwnersRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetCodeOwners: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetCodeOwners: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.SetCodeOwners(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrInvalidCodeOwners):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_CODE_OWNERS",
+					"message": err.Error(),
+				},
+			})
+		case errors.Is(err, apperr.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+		}
+
+		slog.Error("SetCodeOwners: failed to set code owners",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to set code owners")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) GetCodeOwners(c *fiber.Ctx) error {
+	req := &teamdto.GetCodeOwnersRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("GetCodeOwners: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.GetCodeOwners(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+		}
+
+		slog.Error("GetCodeOwners: failed to get code owners",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to get code owners")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) SetReviewerPolicy(c *fiber.Ctx) error {
+	req := &teamdto.SetReviewerPolicyRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetReviewerPolicy: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetReviewerPolicy: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.team.SetReviewerPolicy(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrInvalidReviewerPolicy):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_REVIEWER_POLICY",
+					"message": err.Error(),
+				},
+			})
+		case errors.Is(err, apperr.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "team not found",
+				},
+			})
+		}
+
+		slog.Error("SetReviewerPolicy: failed to set reviewer policy",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to set reviewer policy")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
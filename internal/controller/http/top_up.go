@@ -0,0 +1,95 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	topupdto "github.com/silentmol/avito-backend-trainee/internal/topup/dto"
+)
+
+func (h *Handle) TopUpPR(c *fiber.Ctx) error {
+	req := &prdto.TopUpPRRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("TopUpPR: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.pr.TopUpPR(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "pull request or author not found",
+				},
+			})
+		case errors.Is(err, apperr.ErrPRMerged):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_MERGED",
+					"message": "cannot top up merged PR",
+				},
+			})
+		case errors.Is(err, apperr.ErrPRClosed):
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "PR_CLOSED",
+					"message": "cannot top up closed PR",
+				},
+			})
+		case errors.Is(err, apperr.ErrNoCandidate):
+			message := "no available reviewer in team"
+			if err != apperr.ErrNoCandidate {
+				message = err.Error()
+			}
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NO_CANDIDATE",
+					"message": message,
+				},
+			})
+		}
+
+		slog.Error("TopUpPR: failed to top up PR",
+			slog.String("pr_id", req.PrID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to top up pull request")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *Handle) TopUpTeam(c *fiber.Ctx) error {
+	req := &topupdto.TopUpTeamRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("TopUpTeam: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.topup.TopUpTeam(c.UserContext(), req)
+	if err != nil {
+		slog.Error("TopUpTeam: failed to top up team pull requests",
+			slog.String("team_name", req.TeamName),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to top up team pull requests")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	app.Use(Tracing)
+	app.Get("/team/get", func(c *fiber.Ctx) error {
+		_, span := tracing.Start(c.UserContext(), "TeamUsecase.GetTeam")
+		span.End()
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/users/getReview", func(c *fiber.Ctx) error {
+		return errors.New("db is down")
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		wantName   string
+		wantStatus int
+		wantCode   codes.Code
+		wantSpans  []string
+	}{
+		{
+			name:       "continues_incoming_trace",
+			path:       "/team/get?team_name=backend",
+			wantName:   "GET /team/get",
+			wantStatus: fiber.StatusOK,
+			wantSpans:  []string{"TeamUsecase.GetTeam", "GET /team/get"},
+		},
+		{
+			name:       "handler_error",
+			path:       "/users/getReview",
+			wantName:   "GET /users/getReview",
+			wantStatus: fiber.StatusInternalServerError,
+			wantCode:   codes.Error,
+			wantSpans:  []string{"GET /users/getReview"},
+		},
+		{
+			name:       "unmatched_route",
+			path:       "/no/such/route",
+			wantName:   "GET unmatched",
+			wantStatus: fiber.StatusNotFound,
+			wantSpans:  []string{"GET unmatched"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := testutils.StartTrace(t)
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			require.Equal(t, tt.wantSpans, spans.Names())
+			ended := spans.Ended()
+			server := ended[len(ended)-1]
+			require.Equal(t, tt.wantName, server.Name)
+			require.Equal(t, trace.SpanKindServer, server.SpanKind)
+			require.Equal(t, trace.SpanFromContext(ctx).SpanContext().SpanID(), server.Parent.SpanID())
+			require.Equal(t, tt.wantCode, server.Status.Code)
+			require.Contains(t, server.Attributes, semconv.HTTPResponseStatusCode(tt.wantStatus))
+			if len(ended) > 1 {
+				require.Equal(t, server.SpanContext.SpanID(), ended[0].Parent.SpanID())
+			}
+		})
+	}
+}
@@ -24,7 +24,7 @@ func (h *Handle) SetIsActive(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	resp, err := h.user.SetIsActive(c.Context(), req)
+	resp, err := h.user.SetIsActive(c.UserContext(), req)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			slog.Info("SetIsActive: user not found",
@@ -55,6 +55,204 @@ func (h *Handle) SetIsActive(c *fiber.Ctx) error {
 	})
 }
 
+func (h *Handle) SetChatHandle(c *fiber.Ctx) error {
+	req := &userdto.SetChatHandleRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetChatHandle: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetChatHandle: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.user.SetChatHandle(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("SetChatHandle: failed to update chat_handle",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to update chat_handle")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": resp.User,
+	})
+}
+
+func (h *Handle) SetEmailSettings(c *fiber.Ctx) error {
+	req := &userdto.SetEmailSettingsRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetEmailSettings: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetEmailSettings: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.user.SetEmailSettings(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("SetEmailSettings: failed to update email settings",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to update email settings")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": resp.User,
+	})
+}
+
+func (h *Handle) SetWorkingHours(c *fiber.Ctx) error {
+	req := &userdto.SetWorkingHoursRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetWorkingHours: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetWorkingHours: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.user.SetWorkingHours(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidWorkingHours) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_WORKING_HOURS",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("SetWorkingHours: failed to update working hours",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to update working hours")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": resp.User,
+	})
+}
+
+func (h *Handle) SetTags(c *fiber.Ctx) error {
+	req := &userdto.SetTagsRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetTags: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetTags: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.user.SetTags(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidTags) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_TAGS",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("SetTags: failed to update tags",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to update tags")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": resp.User,
+	})
+}
+
+func (h *Handle) SetLevel(c *fiber.Ctx) error {
+	req := &userdto.SetLevelRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("SetLevel: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("SetLevel: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.user.SetLevel(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "user not found",
+				},
+			})
+		}
+
+		slog.Error("SetLevel: failed to update level",
+			slog.String("user_id", req.UserID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to update level")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": resp.User,
+	})
+}
+
 func (h *Handle) GetReview(c *fiber.Ctx) error {
 	userID := c.Query("user_id")
 	if userID == "" {
@@ -66,7 +264,7 @@ func (h *Handle) GetReview(c *fiber.Ctx) error {
 		UserId: userID,
 	}
 
-	resp, err := h.pr.GetReview(c.Context(), req)
+	resp, err := h.pr.GetReview(c.UserContext(), req)
 	if err != nil {
 		slog.Error("GetReview: failed to get user reviews",
 			slog.String("user_id", userID),
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	webhookdto "github.com/silentmol/avito-backend-trainee/internal/webhook/dto"
+)
+
+func (h *Handle) AddWebhook(c *fiber.Ctx) error {
+	req := &webhookdto.AddSubscriptionRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("AddWebhook: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		slog.Warn("AddWebhook: validation failed", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.webhook.AddSubscription(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidWebhook) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INVALID_WEBHOOK",
+					"message": err.Error(),
+				},
+			})
+		}
+
+		slog.Error("AddWebhook: failed to add subscription",
+			slog.String("url", req.URL),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to add webhook subscription")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"subscription": resp.Subscription,
+	})
+}
+
+func (h *Handle) ListWebhooks(c *fiber.Ctx) error {
+	resp, err := h.webhook.ListSubscriptions(c.UserContext())
+	if err != nil {
+		slog.Error("ListWebhooks: failed to list subscriptions", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to list webhook subscriptions")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"subscriptions": resp.Subscriptions,
+	})
+}
+
+func (h *Handle) DeleteWebhook(c *fiber.Ctx) error {
+	req := &webhookdto.DeleteSubscriptionRequest{}
+
+	if err := c.BodyParser(req); err != nil {
+		slog.Warn("DeleteWebhook: invalid request body", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.webhook.DeleteSubscription(c.UserContext(), req); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "webhook subscription not found",
+				},
+			})
+		}
+
+		slog.Error("DeleteWebhook: failed to delete subscription",
+			slog.Int64("subscription_id", req.SubscriptionID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to delete webhook subscription")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *Handle) GetWebhookDeliveries(c *fiber.Ctx) error {
+	req := &webhookdto.GetDeliveriesRequest{}
+
+	if err := c.QueryParser(req); err != nil {
+		slog.Warn("GetWebhookDeliveries: invalid query", slog.Any("error", err))
+		return fiber.NewError(fiber.StatusBadRequest, "invalid query parameters")
+	}
+
+	if err := validator.New().Struct(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.webhook.GetDeliveries(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "NOT_FOUND",
+					"message": "webhook subscription not found",
+				},
+			})
+		}
+
+		slog.Error("GetWebhookDeliveries: failed to list deliveries",
+			slog.Int64("subscription_id", req.SubscriptionID),
+			slog.Any("error", err),
+		)
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to get webhook deliveries")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
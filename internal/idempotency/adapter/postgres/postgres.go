@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+)
+
+type IdempotencyRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(conn *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{conn: conn}
+}
+
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *domain.Record) (*domain.Record, bool, error) {
+	// просроченный ключ и брошенный резерв (процесс упал, не дождавшись ответа) можно занять заново
+	deleteExpiredQuery := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1
+		  AND (expires_at <= $2 OR (status_code IS NULL AND locked_until <= $2))
+	`
+
+	if _, err := r.conn.Exec(ctx, deleteExpiredQuery, record.Key, record.CreatedAt); err != nil {
+		return nil, false, fmt.Errorf("db: failed to delete expired idempotency key: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO idempotency_keys (key, fingerprint, created_at, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key) DO NOTHING
+	`
+
+	tag, err := r.conn.Exec(ctx, insertQuery,
+		record.Key,
+		record.Fingerprint,
+		record.CreatedAt,
+		record.ExpiresAt,
+		record.LockedUntil,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("db: failed to reserve idempotency key: %w", err)
+	}
+
+	if tag.RowsAffected() == 1 {
+		return nil, true, nil
+	}
+
+	existing, err := r.get(ctx, record.Key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, false, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $2,
+		    response_body = $3
+		WHERE key = $1
+	`
+
+	tag, err := r.conn.Exec(ctx, query, key, statusCode, body)
+	if err != nil {
+		return fmt.Errorf("db: failed to store idempotent response: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1 AND status_code IS NULL
+	`
+
+	if _, err := r.conn.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("db: failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= $1
+	`
+
+	tag, err := r.conn.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("db: failed to delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *IdempotencyRepository) get(ctx context.Context, key string) (*domain.Record, error) {
+	query := `
+		SELECT key, fingerprint, status_code, response_body, created_at, expires_at, locked_until
+		FROM idempotency_keys
+		WHERE key = $1
+	`
+
+	var record domain.Record
+	var statusCode *int
+	var lockedUntil *time.Time
+
+	if err := r.conn.QueryRow(ctx, query, key).Scan(
+		&record.Key,
+		&record.Fingerprint,
+		&statusCode,
+		&record.Body,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+		&lockedUntil,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to get idempotency key: %w", err)
+	}
+
+	if statusCode != nil {
+		record.StatusCode = *statusCode
+	}
+	if lockedUntil != nil {
+		record.LockedUntil = *lockedUntil
+	}
+
+	return &record, nil
+}
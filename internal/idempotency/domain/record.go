@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Record - сохранённый результат запроса с заголовком Idempotency-Key.
+type Record struct {
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// LockedUntil - до какого момента незавершённый запрос держит ключ;
+	// после него резерв считается брошенным (например, процесс упал) и ключ можно занять заново.
+	LockedUntil time.Time
+}
+
+// Completed сообщает, что ответ на исходный запрос уже сохранён и его можно отдать повторно.
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+func (r *Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Abandoned сообщает, что резерв незавершённого запроса истёк и ключ можно занять заново.
+func (r *Record) Abandoned(now time.Time) bool {
+	return !r.Completed() && !r.LockedUntil.IsZero() && !now.Before(r.LockedUntil)
+}
+
+// Fingerprint считает отпечаток запроса: метод, путь и тело.
+// Один и тот же ключ с другим отпечатком считается повторным использованием ключа.
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := Fingerprint("POST", "/pullRequest/create", []byte(`{"pull_request_id":"pr-1"}`))
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     []byte
+		wantSame bool
+	}{
+		{
+			name:     "same_request_same_fingerprint",
+			method:   "POST",
+			path:     "/pullRequest/create",
+			body:     []byte(`{"pull_request_id":"pr-1"}`),
+			wantSame: true,
+		},
+		{
+			name:   "different_body",
+			method: "POST",
+			path:   "/pullRequest/create",
+			body:   []byte(`{"pull_request_id":"pr-2"}`),
+		},
+		{
+			name:   "different_path",
+			method: "POST",
+			path:   "/pullRequest/reassign",
+			body:   []byte(`{"pull_request_id":"pr-1"}`),
+		},
+		{
+			name:   "parts_are_not_concatenated_ambiguously",
+			method: "POST/",
+			path:   "pullRequest/create",
+			body:   []byte(`{"pull_request_id":"pr-1"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Fingerprint(tt.method, tt.path, tt.body)
+			if tt.wantSame {
+				assert.Equal(t, base, got)
+				return
+			}
+			assert.NotEqual(t, base, got)
+		})
+	}
+}
+
+func TestRecord_CompletedAndExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	pending := &Record{Key: "k", ExpiresAt: now.Add(time.Hour)}
+	assert.False(t, pending.Completed())
+	assert.False(t, pending.Expired(now))
+
+	done := &Record{Key: "k", StatusCode: 201, ExpiresAt: now.Add(-time.Second)}
+	assert.True(t, done.Completed())
+	assert.True(t, done.Expired(now))
+}
+
+func TestRecord_Abandoned(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	running := &Record{Key: "k", LockedUntil: now.Add(time.Minute)}
+	assert.False(t, running.Abandoned(now))
+
+	crashed := &Record{Key: "k", LockedUntil: now.Add(-time.Second)}
+	assert.True(t, crashed.Abandoned(now))
+
+	done := &Record{Key: "k", StatusCode: 201, LockedUntil: now.Add(-time.Second)}
+	assert.False(t, done.Abandoned(now))
+
+	legacy := &Record{Key: "k"}
+	assert.False(t, legacy.Abandoned(now))
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+)
+
+// Begin резервирует ключ под запрос.
+// Возвращает nil, если запрос нужно выполнить, и сохранённую запись, если ответ нужно повторить.
+func (u *IdempotencyUsecase) Begin(ctx context.Context, key, fingerprint string) (*domain.Record, error) {
+	now := time.Now()
+
+	existing, reserved, err := u.recordProvider.Reserve(ctx, &domain.Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.ttl),
+		LockedUntil: now.Add(u.lease),
+	})
+	if err != nil {
+		// запись удалили между вставкой и чтением - параллельный запрос ещё не завершён
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrIdempotencyInProgress
+		}
+		slog.Error("IdempotencyUsecase.Begin: failed to reserve key",
+			slog.String("idempotency_key", key),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("reserve idempotency key in provider: %w", err)
+	}
+
+	if reserved {
+		return nil, nil
+	}
+
+	// ключ уже занят: с другим телом - ошибка клиента, без ответа - запрос ещё выполняется
+	if existing.Fingerprint != fingerprint {
+		slog.Info("IdempotencyUsecase.Begin: key reused with different request",
+			slog.String("idempotency_key", key),
+		)
+		return nil, apperr.ErrIdempotencyKeyReused
+	}
+
+	if !existing.Completed() {
+		slog.Info("IdempotencyUsecase.Begin: request with key is in progress",
+			slog.String("idempotency_key", key),
+		)
+		return nil, apperr.ErrIdempotencyInProgress
+	}
+
+	slog.Info("IdempotencyUsecase.Begin: replaying stored response",
+		slog.String("idempotency_key", key),
+		slog.Int("status_code", existing.StatusCode),
+	)
+
+	return existing, nil
+}
+
+// Complete сохраняет ответ, который будет отдаваться на повторы.
+func (u *IdempotencyUsecase) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	if err := u.recordProvider.Complete(ctx, key, statusCode, body); err != nil {
+		slog.Error("IdempotencyUsecase.Complete: failed to store response",
+			slog.String("idempotency_key", key),
+			slog.Any("error", err),
+		)
+		return fmt.Errorf("store idempotent response in provider: %w", err)
+	}
+	return nil
+}
+
+// Release снимает резерв, если запрос не дал ответа, который стоит повторять.
+func (u *IdempotencyUsecase) Release(ctx context.Context, key string) error {
+	if err := u.recordProvider.Release(ctx, key); err != nil {
+		slog.Error("IdempotencyUsecase.Release: failed to release key",
+			slog.String("idempotency_key", key),
+			slog.Any("error", err),
+		)
+		return fmt.Errorf("release idempotency key in provider: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunPurge периодически удаляет просроченные ключи, пока не отменён контекст.
+func (u *IdempotencyUsecase) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := u.recordProvider.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				slog.Error("IdempotencyUsecase.RunPurge: failed to delete expired keys",
+					slog.Any("error", err),
+				)
+				continue
+			}
+			if deleted > 0 {
+				slog.Info("IdempotencyUsecase.RunPurge: expired keys deleted",
+					slog.Int64("deleted", deleted),
+				)
+			}
+		}
+	}
+}
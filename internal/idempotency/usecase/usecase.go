@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+)
+
+type RecordProvider interface {
+	Reserve(ctx context.Context, record *domain.Record) (*domain.Record, bool, error)
+	Complete(ctx context.Context, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type IdempotencyUsecase struct {
+	recordProvider RecordProvider
+	ttl            time.Duration
+	lease          time.Duration
+}
+
+func NewIdempotencyUsecase(repo RecordProvider, ttl, lease time.Duration) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		recordProvider: repo,
+		ttl:            ttl,
+		lease:          lease,
+	}
+}
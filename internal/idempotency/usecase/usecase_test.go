@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyUsecase_Begin(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name         string
+		key          string
+		fingerprint  string
+		stubExisting *domain.Record
+		stubReserved bool
+		stubErr      error
+		wantRecord   bool
+		wantErr      error
+		wantAnyErr   bool
+	}
+
+	tests := []tc{
+		{
+			name:         "new_key_reserved",
+			key:          "k1",
+			fingerprint:  "fp",
+			stubReserved: true,
+		},
+		{
+			name:        "completed_same_request_replayed",
+			key:         "k2",
+			fingerprint: "fp",
+			stubExisting: &domain.Record{
+				Key:         "k2",
+				Fingerprint: "fp",
+				StatusCode:  201,
+				Body:        []byte(`{"pr":{}}`),
+			},
+			wantRecord: true,
+		},
+		{
+			name:        "key_reused_with_different_request",
+			key:         "k3",
+			fingerprint: "fp-new",
+			stubExisting: &domain.Record{
+				Key:         "k3",
+				Fingerprint: "fp-old",
+				StatusCode:  201,
+			},
+			wantErr: apperr.ErrIdempotencyKeyReused,
+		},
+		{
+			name:        "request_in_progress",
+			key:         "k4",
+			fingerprint: "fp",
+			stubExisting: &domain.Record{
+				Key:         "k4",
+				Fingerprint: "fp",
+			},
+			wantErr: apperr.ErrIdempotencyInProgress,
+		},
+		{
+			name:        "record_released_concurrently",
+			key:         "k5",
+			fingerprint: "fp",
+			stubErr:     apperr.ErrNotFound,
+			wantErr:     apperr.ErrIdempotencyInProgress,
+		},
+		{
+			name:        "provider_error",
+			key:         "k6",
+			fingerprint: "fp",
+			stubErr:     errors.New("db error"),
+			wantAnyErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			recordProvider := mocks.NewMockRecordProvider(ctrl)
+
+			recordProvider.EXPECT().
+				Reserve(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, record *domain.Record) (*domain.Record, bool, error) {
+					assert.Equal(t, tt.key, record.Key)
+					assert.Equal(t, tt.fingerprint, record.Fingerprint)
+					assert.Equal(t, time.Hour, record.ExpiresAt.Sub(record.CreatedAt))
+					assert.Equal(t, time.Minute, record.LockedUntil.Sub(record.CreatedAt))
+					return tt.stubExisting, tt.stubReserved, tt.stubErr
+				})
+
+			uc := NewIdempotencyUsecase(recordProvider, time.Hour, time.Minute)
+
+			got, err := uc.Begin(context.Background(), tt.key, tt.fingerprint)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				require.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			if !tt.wantRecord {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.stubExisting.StatusCode, got.StatusCode)
+			assert.Equal(t, tt.stubExisting.Body, got.Body)
+		})
+	}
+}
+
+func TestIdempotencyUsecase_CompleteAndRelease(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recordProvider := mocks.NewMockRecordProvider(ctrl)
+
+	recordProvider.EXPECT().
+		Complete(gomock.Any(), "k1", 201, []byte("body")).
+		Return(nil)
+	recordProvider.EXPECT().
+		Release(gomock.Any(), "k2").
+		Return(errors.New("db error"))
+
+	uc := NewIdempotencyUsecase(recordProvider, time.Hour, time.Minute)
+
+	require.NoError(t, uc.Complete(context.Background(), "k1", 201, []byte("body")))
+	require.Error(t, uc.Release(context.Background(), "k2"))
+}
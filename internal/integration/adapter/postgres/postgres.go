@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+type IdentityRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewIdentityRepository(conn *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{conn: conn}
+}
+
+func (i *IdentityRepository) AddIdentity(ctx context.Context,
+	identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error) {
+
+	// повторное добавление логина переназначает его на другого пользователя
+	query := `
+		INSERT INTO external_identities (provider, login, user_id, external_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (provider, login) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+		    external_id = EXCLUDED.external_id
+		RETURNING provider, login, user_id, COALESCE(external_id, ''), created_at
+	`
+
+	var saved domain.ExternalIdentity
+	if err := storage.QuerierFrom(ctx, i.conn).QueryRow(ctx, query,
+		identity.Provider,
+		identity.Login,
+		identity.UserID,
+		identity.ExternalID,
+	).Scan(
+		&saved.Provider,
+		&saved.Login,
+		&saved.UserID,
+		&saved.ExternalID,
+		&saved.CreatedAt,
+	); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return nil, apperr.ErrNotFound
+			case "23505":
+				return nil, apperr.ErrIdentityExists
+			}
+		}
+		return nil, fmt.Errorf("db: failed to add external identity: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (i *IdentityRepository) ListIdentities(ctx context.Context,
+	provider domain.Provider) ([]domain.ExternalIdentity, error) {
+
+	query := `
+		SELECT provider, login, user_id, COALESCE(external_id, ''), created_at
+		FROM external_identities
+		WHERE provider = $1
+		ORDER BY login
+	`
+
+	rows, err := storage.QuerierFrom(ctx, i.conn).Query(ctx, query, provider)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list external identities: %w", err)
+	}
+	defer rows.Close()
+
+	identities := make([]domain.ExternalIdentity, 0)
+
+	for rows.Next() {
+		var identity domain.ExternalIdentity
+		if err := rows.Scan(
+			&identity.Provider,
+			&identity.Login,
+			&identity.UserID,
+			&identity.ExternalID,
+			&identity.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan external identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: failed to iterate external identities: %w", err)
+	}
+
+	return identities, nil
+}
+
+// ResolveUserID ищет пользователя по логину или внешнему id; совпадение по id приоритетнее,
+// так как логин во внешней системе может смениться.
+func (i *IdentityRepository) ResolveUserID(ctx context.Context,
+	provider domain.Provider, login, externalID string) (string, error) {
+
+	query := `
+		SELECT user_id
+		FROM external_identities
+		WHERE provider = $1
+		  AND (login = $2 OR external_id = NULLIF($3, ''))
+		ORDER BY (external_id = NULLIF($3, '')) IS TRUE DESC
+		LIMIT 1
+	`
+
+	var userID string
+	if err := storage.QuerierFrom(ctx, i.conn).QueryRow(ctx, query, provider, login, externalID).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperr.ErrNotFound
+		}
+		return "", fmt.Errorf("db: failed to resolve external identity: %w", err)
+	}
+
+	return userID, nil
+}
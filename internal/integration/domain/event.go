@@ -0,0 +1,24 @@
+package domain
+
+type Action string
+
+const (
+	ActionOpen   Action = "open"
+	ActionReopen Action = "reopen"
+	ActionMerge  Action = "merge"
+	ActionClose  Action = "close"
+	ActionIgnore Action = "ignore"
+)
+
+// PullRequestEvent - событие внешней системы, приведённое к операциям сервиса.
+type PullRequestEvent struct {
+	Provider    Provider
+	Action      Action
+	PrID        string
+	Name        string
+	AuthorLogin string
+	// AuthorExternalID - числовой id автора во внешней системе, если известен.
+	AuthorExternalID string
+	// Reason поясняет, почему событие пропущено.
+	Reason string
+}
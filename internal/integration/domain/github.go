@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	GitHubDeliveryHeader  = "X-GitHub-Delivery"
+
+	GitHubEventPullRequest = "pull_request"
+	GitHubEventPing        = "ping"
+)
+
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			ID    int64  `json:"id"`
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// VerifyGitHubSignature проверяет заголовок X-Hub-Signature-256 ("sha256=<hex>") за постоянное время.
+func VerifyGitHubSignature(secret string, body []byte, header string) bool {
+	hexSig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+
+	got, err := hex.DecodeString(hexSig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// GitHubPRID - стабильный pull_request_id для PR из GitHub: "github:<owner>/<repo>#<number>".
+func GitHubPRID(repoFullName string, number int) string {
+	return fmt.Sprintf("github:%s#%d", repoFullName, number)
+}
+
+// ParseGitHubPullRequest разбирает событие pull_request и решает, какую операцию выполнить.
+func ParseGitHubPullRequest(body []byte) (*PullRequestEvent, error) {
+	var payload gitHubPullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode github pull_request payload: %w", err)
+	}
+
+	number := payload.PullRequest.Number
+	if number == 0 {
+		number = payload.Number
+	}
+	if payload.Repository.FullName == "" || number == 0 {
+		return nil, fmt.Errorf("github pull_request payload: missing repository or number")
+	}
+
+	event := &PullRequestEvent{
+		Provider:    ProviderGitHub,
+		Action:      ActionIgnore,
+		PrID:        GitHubPRID(payload.Repository.FullName, number),
+		Name:        payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+	}
+	if payload.PullRequest.User.ID != 0 {
+		event.AuthorExternalID = strconv.FormatInt(payload.PullRequest.User.ID, 10)
+	}
+
+	switch payload.Action {
+	case "opened", "reopened":
+		// черновики получают ревьюверов только после ready_for_review
+		if payload.PullRequest.Draft {
+			event.Reason = "draft pull request"
+			return event, nil
+		}
+		event.Action = ActionOpen
+		if payload.Action == "reopened" {
+			event.Action = ActionReopen
+		}
+	case "ready_for_review":
+		event.Action = ActionOpen
+	case "closed":
+		if !payload.PullRequest.Merged {
+			event.Action = ActionClose
+			return event, nil
+		}
+		event.Action = ActionMerge
+	default:
+		event.Reason = fmt.Sprintf("unsupported action %q", payload.Action)
+	}
+
+	return event, nil
+}
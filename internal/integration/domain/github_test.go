@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	valid := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name   string
+		secret string
+		body   []byte
+		header string
+		want   bool
+	}{
+		{name: "valid", secret: "secret", body: body, header: valid, want: true},
+		{name: "wrong_secret", secret: "other", body: body, header: valid},
+		{name: "tampered_body", secret: "secret", body: []byte(`{"action":"closed"}`), header: valid},
+		{name: "missing_prefix", secret: "secret", body: body, header: valid[len("sha256="):]},
+		{name: "sha1_header", secret: "secret", body: body, header: "sha1=0123"},
+		{name: "not_hex", secret: "secret", body: body, header: "sha256=zz"},
+		{name: "empty", secret: "secret", body: body, header: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, VerifyGitHubSignature(tt.secret, tt.body, tt.header))
+		})
+	}
+}
+
+func TestParseGitHubPullRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantAction Action
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "opened",
+			body:       `{"action":"opened","pull_request":{"number":7,"title":"Fix","user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionOpen,
+		},
+		{
+			name:       "opened_draft",
+			body:       `{"action":"opened","pull_request":{"number":7,"title":"Fix","draft":true,"user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionIgnore,
+			wantReason: "draft pull request",
+		},
+		{
+			name:       "reopened",
+			body:       `{"action":"reopened","pull_request":{"number":7,"title":"Fix","user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionReopen,
+		},
+		{
+			name:       "reopened_draft",
+			body:       `{"action":"reopened","pull_request":{"number":7,"title":"Fix","draft":true,"user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionIgnore,
+			wantReason: "draft pull request",
+		},
+		{
+			name:       "ready_for_review",
+			body:       `{"action":"ready_for_review","pull_request":{"number":7,"title":"Fix","user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionOpen,
+		},
+		{
+			name:       "closed_merged",
+			body:       `{"action":"closed","pull_request":{"number":7,"title":"Fix","merged":true,"user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionMerge,
+		},
+		{
+			name:       "closed_not_merged",
+			body:       `{"action":"closed","pull_request":{"number":7,"title":"Fix","user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionClose,
+		},
+		{
+			name:       "labeled",
+			body:       `{"action":"labeled","pull_request":{"number":7,"title":"Fix","user":{"login":"octocat"}},"repository":{"full_name":"acme/api"}}`,
+			wantAction: ActionIgnore,
+			wantReason: `unsupported action "labeled"`,
+		},
+		{
+			name:    "missing_repository",
+			body:    `{"action":"opened","pull_request":{"number":7}}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			body:    `{"action":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := ParseGitHubPullRequest([]byte(tt.body))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, event.Action)
+			assert.Equal(t, tt.wantReason, event.Reason)
+			assert.Equal(t, "github:acme/api#7", event.PrID)
+			assert.Equal(t, "octocat", event.AuthorLogin)
+			assert.Equal(t, ProviderGitHub, event.Provider)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	GitLabEventHeader = "X-Gitlab-Event"
+	GitLabTokenHeader = "X-Gitlab-Token"
+
+	GitLabEventMergeRequest = "Merge Request Hook"
+	GitLabEventSystem       = "System Hook"
+
+	gitLabObjectMergeRequest = "merge_request"
+)
+
+type gitLabMergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		ID int64 `json:"id"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int64  `json:"iid"`
+		Title          string `json:"title"`
+		AuthorID       int64  `json:"author_id"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// VerifyGitLabToken сравнивает заголовок X-Gitlab-Token с секретом за постоянное время.
+func VerifyGitLabToken(secret, header string) bool {
+	if secret == "" || header == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(header)) == 1
+}
+
+// GitLabPRID - стабильный pull_request_id для merge request: "gitlab:<project_id>!<iid>".
+// id проекта не меняется при переименовании или переносе проекта, в отличие от пути.
+func GitLabPRID(projectID, iid int64) string {
+	return fmt.Sprintf("gitlab:%d!%d", projectID, iid)
+}
+
+// IsGitLabMergeRequest сообщает, что тело - событие merge request (проектный или системный хук).
+func IsGitLabMergeRequest(body []byte) bool {
+	var head struct {
+		ObjectKind string `json:"object_kind"`
+	}
+	if err := json.Unmarshal(body, &head); err != nil {
+		return false
+	}
+	return head.ObjectKind == gitLabObjectMergeRequest
+}
+
+// ParseGitLabMergeRequest разбирает событие merge request и решает, какую операцию выполнить.
+func ParseGitLabMergeRequest(body []byte) (*PullRequestEvent, error) {
+	var payload gitLabMergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode gitlab merge request payload: %w", err)
+	}
+
+	attrs := payload.ObjectAttributes
+	if payload.ObjectKind != gitLabObjectMergeRequest {
+		return nil, fmt.Errorf("gitlab payload: unexpected object_kind %q", payload.ObjectKind)
+	}
+	if payload.Project.ID == 0 || attrs.IID == 0 {
+		return nil, fmt.Errorf("gitlab merge request payload: missing project or iid")
+	}
+
+	event := &PullRequestEvent{
+		Provider: ProviderGitLab,
+		Action:   ActionIgnore,
+		PrID:     GitLabPRID(payload.Project.ID, attrs.IID),
+		Name:     attrs.Title,
+	}
+	if attrs.AuthorID != 0 {
+		event.AuthorExternalID = strconv.FormatInt(attrs.AuthorID, 10)
+	}
+	// в событии есть только логин инициатора; он совпадает с автором не всегда
+	if payload.User.ID == attrs.AuthorID {
+		event.AuthorLogin = payload.User.Username
+	}
+
+	draft := attrs.Draft || attrs.WorkInProgress
+
+	switch attrs.Action {
+	case "open", "reopen":
+		if draft {
+			event.Reason = "draft merge request"
+			return event, nil
+		}
+		event.Action = ActionOpen
+		if attrs.Action == "reopen" {
+			event.Action = ActionReopen
+		}
+	case "update":
+		// снятие отметки draft - аналог ready_for_review в GitHub
+		if change := payload.Changes.Draft; change != nil && change.Previous && !change.Current {
+			event.Action = ActionOpen
+			return event, nil
+		}
+		event.Reason = "merge request updated"
+	case "merge":
+		event.Action = ActionMerge
+	case "close":
+		event.Action = ActionClose
+	default:
+		event.Reason = fmt.Sprintf("unsupported action %q", attrs.Action)
+	}
+
+	return event, nil
+}
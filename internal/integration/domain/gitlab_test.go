@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyGitLabToken(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, VerifyGitLabToken("token", "token"))
+	assert.False(t, VerifyGitLabToken("token", "other"))
+	assert.False(t, VerifyGitLabToken("token", ""))
+	assert.False(t, VerifyGitLabToken("", ""))
+}
+
+func TestParseGitLabMergeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantAction Action
+		wantLogin  string
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "open_by_author",
+			body:       `{"object_kind":"merge_request","user":{"id":51,"username":"alice"},"project":{"id":118},"object_attributes":{"iid":17,"title":"Fix","author_id":51,"action":"open"}}`,
+			wantAction: ActionOpen,
+			wantLogin:  "alice",
+		},
+		{
+			name:       "reopen_by_other_user",
+			body:       `{"object_kind":"merge_request","user":{"id":7,"username":"maintainer"},"project":{"id":118},"object_attributes":{"iid":17,"title":"Fix","author_id":51,"action":"reopen"}}`,
+			wantAction: ActionReopen,
+		},
+		{
+			name:       "open_draft",
+			body:       `{"object_kind":"merge_request","user":{"id":51,"username":"alice"},"project":{"id":118},"object_attributes":{"iid":17,"author_id":51,"action":"open","work_in_progress":true}}`,
+			wantAction: ActionIgnore,
+			wantLogin:  "alice",
+			wantReason: "draft merge request",
+		},
+		{
+			name:       "draft_removed",
+			body:       `{"object_kind":"merge_request","user":{"id":51,"username":"alice"},"project":{"id":118},"object_attributes":{"iid":17,"author_id":51,"action":"update"},"changes":{"draft":{"previous":true,"current":false}}}`,
+			wantAction: ActionOpen,
+			wantLogin:  "alice",
+		},
+		{
+			name:       "merge",
+			body:       `{"object_kind":"merge_request","user":{"id":7,"username":"maintainer"},"project":{"id":118},"object_attributes":{"iid":17,"author_id":51,"action":"merge"}}`,
+			wantAction: ActionMerge,
+		},
+		{
+			name:       "close",
+			body:       `{"object_kind":"merge_request","user":{"id":51,"username":"alice"},"project":{"id":118},"object_attributes":{"iid":17,"author_id":51,"action":"close"}}`,
+			wantAction: ActionClose,
+			wantLogin:  "alice",
+		},
+		{
+			name:       "approved",
+			body:       `{"object_kind":"merge_request","user":{"id":7,"username":"maintainer"},"project":{"id":118},"object_attributes":{"iid":17,"author_id":51,"action":"approved"}}`,
+			wantAction: ActionIgnore,
+			wantReason: `unsupported action "approved"`,
+		},
+		{
+			name:    "not_merge_request",
+			body:    `{"object_kind":"push","project":{"id":118}}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing_iid",
+			body:    `{"object_kind":"merge_request","project":{"id":118},"object_attributes":{"action":"open"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := ParseGitLabMergeRequest([]byte(tt.body))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, event.Action)
+			assert.Equal(t, tt.wantReason, event.Reason)
+			assert.Equal(t, tt.wantLogin, event.AuthorLogin)
+			assert.Equal(t, "51", event.AuthorExternalID)
+			assert.Equal(t, "gitlab:118!17", event.PrID)
+			assert.Equal(t, ProviderGitLab, event.Provider)
+		})
+	}
+}
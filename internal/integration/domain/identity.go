@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type Provider string
+
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+func (p Provider) Valid() bool {
+	switch p {
+	case ProviderGitHub, ProviderGitLab:
+		return true
+	}
+	return false
+}
+
+// ExternalIdentity связывает логин во внешней системе с users.id.
+type ExternalIdentity struct {
+	Provider Provider `json:"provider" validate:"required"`
+	Login    string   `json:"login" validate:"required"`
+	UserID   string   `json:"user_id" validate:"required"`
+	// ExternalID - числовой id пользователя во внешней системе. GitLab передаёт
+	// в событиях merge request только id автора, без логина.
+	ExternalID string    `json:"external_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+
+type AddIdentityRequest struct {
+	domain.ExternalIdentity
+}
+
+type AddIdentityResponse struct {
+	Identity domain.ExternalIdentity `json:"identity"`
+}
+
+type ListIdentitiesRequest struct {
+	Provider string `query:"provider" validate:"required"`
+}
+
+type ListIdentitiesResponse struct {
+	Provider   string                    `json:"provider"`
+	Identities []domain.ExternalIdentity `json:"identities"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type GitHubWebhookRequest struct {
+	Event     string
+	Signature string
+	Body      []byte
+}
+
+type WebhookResponse struct {
+	Action string `json:"action"`
+	PrID   string `json:"pull_request_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type GitLabWebhookRequest struct {
+	Event string
+	Token string
+	Body  []byte
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+// apply выполняет над PR операцию, соответствующую событию внешней системы.
+func (u *IntegrationUsecase) apply(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	switch event.Action {
+	case domain.ActionOpen:
+		return u.open(ctx, event)
+	case domain.ActionReopen:
+		return u.reopen(ctx, event)
+	case domain.ActionMerge:
+		return u.merge(ctx, event)
+	case domain.ActionClose:
+		return u.close(ctx, event)
+	default:
+		slog.Info("IntegrationUsecase.apply: event ignored",
+			slog.String("provider", string(event.Provider)),
+			slog.String("pr_id", event.PrID),
+			slog.String("reason", event.Reason),
+		)
+		return ignored(event.PrID, event.Reason), nil
+	}
+}
+
+func (u *IntegrationUsecase) open(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	authorID, err := u.identityProvider.ResolveUserID(ctx, event.Provider, event.AuthorLogin, event.AuthorExternalID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Info("IntegrationUsecase.open: author is not mapped",
+				slog.String("provider", string(event.Provider)),
+				slog.String("login", event.AuthorLogin),
+				slog.String("external_id", event.AuthorExternalID),
+				slog.String("pr_id", event.PrID),
+			)
+			return nil, fmt.Errorf("%w: %s login %q (id %q)",
+				apperr.ErrUnknownIdentity, event.Provider, event.AuthorLogin, event.AuthorExternalID)
+		}
+		return nil, fmt.Errorf("resolve author identity in provider: %w", err)
+	}
+
+	resp, err := u.prService.CreatePR(ctx, &prdto.CreatePRRequest{
+		PrID:     event.PrID,
+		Name:     event.Name,
+		AuthorId: authorID,
+	})
+	if err != nil {
+		// повторная доставка или ready_for_review черновика, переоткрытого после закрытия
+		if errors.Is(err, apperr.ErrPRExists) {
+			return u.reopenExisting(ctx, event)
+		}
+		return nil, fmt.Errorf("create pull request: %w", err)
+	}
+
+	return &dto.WebhookResponse{
+		Action: "created",
+		PrID:   resp.PullRequest.ID,
+	}, nil
+}
+
+// reopen переоткрывает известный PR; PR, которого сервис ещё не видел, создаётся как при open.
+func (u *IntegrationUsecase) reopen(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	resp, err := u.reopenExisting(ctx, event)
+	if errors.Is(err, apperr.ErrNotFound) {
+		return u.open(ctx, event)
+	}
+	return resp, err
+}
+
+// reopenExisting переоткрывает закрытый PR; уже открытый PR оставляется как есть.
+func (u *IntegrationUsecase) reopenExisting(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	resp, err := u.prService.ReopenPR(ctx, &prdto.ReopenPRRequest{PrID: event.PrID})
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrNotFound
+		}
+		if errors.Is(err, apperr.ErrPRMerged) {
+			return ignored(event.PrID, "pull request already merged"), nil
+		}
+		return nil, fmt.Errorf("reopen pull request: %w", err)
+	}
+
+	if !resp.Reopened {
+		return ignored(event.PrID, "pull request already open"), nil
+	}
+
+	return &dto.WebhookResponse{
+		Action: "reopened",
+		PrID:   resp.PullRequest.ID,
+	}, nil
+}
+
+func (u *IntegrationUsecase) merge(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	resp, err := u.prService.MergePR(ctx, &prdto.MergePRRequest{PrID: event.PrID})
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return ignored(event.PrID, "pull request is not tracked"), nil
+		}
+		return nil, fmt.Errorf("merge pull request: %w", err)
+	}
+
+	return &dto.WebhookResponse{
+		Action: "merged",
+		PrID:   resp.PullRequest.ID,
+	}, nil
+}
+
+func (u *IntegrationUsecase) close(ctx context.Context, event *domain.PullRequestEvent) (*dto.WebhookResponse, error) {
+	resp, err := u.prService.ClosePR(ctx, &prdto.ClosePRRequest{PrID: event.PrID})
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return ignored(event.PrID, "pull request is not tracked"), nil
+		}
+		return nil, fmt.Errorf("close pull request: %w", err)
+	}
+
+	return &dto.WebhookResponse{
+		Action: "closed",
+		PrID:   resp.PullRequest.ID,
+	}, nil
+}
+
+func ignored(prID, reason string) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		Action: string(domain.ActionIgnore),
+		PrID:   prID,
+		Reason: reason,
+	}
+}
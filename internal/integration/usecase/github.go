@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+)
+
+func (u *IntegrationUsecase) HandleGitHubWebhook(ctx context.Context,
+	request *dto.GitHubWebhookRequest) (*dto.WebhookResponse, error) {
+
+	if u.cfg.GitHubSecret == "" {
+		slog.Warn("IntegrationUsecase.HandleGitHubWebhook: github secret is not configured")
+		return nil, apperr.ErrInvalidSignature
+	}
+
+	if !domain.VerifyGitHubSignature(u.cfg.GitHubSecret, request.Body, request.Signature) {
+		slog.Warn("IntegrationUsecase.HandleGitHubWebhook: signature mismatch",
+			slog.String("event", request.Event),
+		)
+		return nil, apperr.ErrInvalidSignature
+	}
+
+	switch request.Event {
+	case domain.GitHubEventPing:
+		return &dto.WebhookResponse{Action: "pong"}, nil
+	case domain.GitHubEventPullRequest:
+	default:
+		return ignored("", fmt.Sprintf("unsupported event %q", request.Event)), nil
+	}
+
+	event, err := domain.ParseGitHubPullRequest(request.Body)
+	if err != nil {
+		slog.Warn("IntegrationUsecase.HandleGitHubWebhook: invalid payload", slog.Any("error", err))
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidPayload, err)
+	}
+
+	return u.apply(ctx, event)
+}
@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "github-secret"
+
+const fixturePRID = "github:acme/backend#42"
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return body
+}
+
+func sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestIntegrationUsecase_HandleGitHubWebhook(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name       string
+		event      string
+		fixture    string
+		signature  string
+		resolveErr error
+		createErr  error
+		mergeErr   error
+		reopenErr  error
+		closeErr   error
+		// reopened - PR был закрыт и переоткрыт
+		reopened    bool
+		wantResolve bool
+		wantCreate  bool
+		wantMerge   bool
+		wantReopen  bool
+		wantClose   bool
+		wantAction  string
+		wantErr     error
+		wantAnyErr  bool
+	}
+
+	tests := []tc{
+		{
+			name:        "opened_creates_pr",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_opened.json",
+			wantResolve: true,
+			wantCreate:  true,
+			wantAction:  "created",
+		},
+		{
+			name:        "opened_closed_pr_is_reopened",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_opened.json",
+			wantResolve: true,
+			wantCreate:  true,
+			createErr:   apperr.ErrPRExists,
+			wantReopen:  true,
+			reopened:    true,
+			wantAction:  "reopened",
+		},
+		{
+			name:        "opened_existing_pr_is_ignored",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_opened.json",
+			wantResolve: true,
+			wantCreate:  true,
+			createErr:   apperr.ErrPRExists,
+			wantReopen:  true,
+			wantAction:  string(domain.ActionIgnore),
+		},
+		{
+			name:       "reopened_closed_pr_is_reopened",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_reopened.json",
+			wantReopen: true,
+			reopened:   true,
+			wantAction: "reopened",
+		},
+		{
+			name:       "reopened_open_pr_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_reopened.json",
+			wantReopen: true,
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:        "reopened_untracked_pr_creates_pr",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_reopened.json",
+			wantReopen:  true,
+			reopenErr:   apperr.ErrNotFound,
+			wantResolve: true,
+			wantCreate:  true,
+			wantAction:  "created",
+		},
+		{
+			name:       "reopened_merged_pr_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_reopened.json",
+			wantReopen: true,
+			reopenErr:  apperr.ErrPRMerged,
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:        "ready_for_review_creates_pr",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_ready_for_review.json",
+			wantResolve: true,
+			wantCreate:  true,
+			wantAction:  "created",
+		},
+		{
+			name:       "draft_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_opened_draft.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "closed_merged_merges_pr",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_closed_merged.json",
+			wantMerge:  true,
+			wantAction: "merged",
+		},
+		{
+			name:       "merge_of_untracked_pr_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_closed_merged.json",
+			wantMerge:  true,
+			mergeErr:   apperr.ErrNotFound,
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "closed_without_merge_closes_pr",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_closed.json",
+			wantClose:  true,
+			wantAction: "closed",
+		},
+		{
+			name:       "close_of_untracked_pr_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_closed.json",
+			wantClose:  true,
+			closeErr:   apperr.ErrNotFound,
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "synchronize_is_ignored",
+			event:      domain.GitHubEventPullRequest,
+			fixture:    "github_pull_request_synchronize.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "ping",
+			event:      domain.GitHubEventPing,
+			fixture:    "github_ping.json",
+			wantAction: "pong",
+		},
+		{
+			name:       "other_event_is_ignored",
+			event:      "push",
+			fixture:    "github_ping.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:      "bad_signature",
+			event:     domain.GitHubEventPullRequest,
+			fixture:   "github_pull_request_opened.json",
+			signature: "sha256=00",
+			wantErr:   apperr.ErrInvalidSignature,
+		},
+		{
+			name:        "unknown_author",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_opened.json",
+			wantResolve: true,
+			resolveErr:  apperr.ErrNotFound,
+			wantErr:     apperr.ErrUnknownIdentity,
+		},
+		{
+			name:        "create_error",
+			event:       domain.GitHubEventPullRequest,
+			fixture:     "github_pull_request_opened.json",
+			wantResolve: true,
+			wantCreate:  true,
+			createErr:   errors.New("db error"),
+			wantAnyErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			identityProvider := mocks.NewMockIdentityProvider(ctrl)
+			prService := mocks.NewMockPRService(ctrl)
+
+			if tt.wantResolve {
+				userID := "u1"
+				if tt.resolveErr != nil {
+					userID = ""
+				}
+				identityProvider.EXPECT().
+					ResolveUserID(gomock.Any(), domain.ProviderGitHub, "octocat", "583231").
+					Return(userID, tt.resolveErr)
+			}
+
+			if tt.wantCreate {
+				prService.EXPECT().
+					CreatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *prdto.CreatePRRequest) (*prdto.CreatePRResponse, error) {
+						assert.Equal(t, fixturePRID, req.PrID)
+						assert.Equal(t, "Add reviewer rotation", req.Name)
+						assert.Equal(t, "u1", req.AuthorId)
+						if tt.createErr != nil {
+							return nil, tt.createErr
+						}
+						return &prdto.CreatePRResponse{PullRequest: prdomain.PullRequest{ID: req.PrID}}, nil
+					})
+			}
+
+			if tt.wantReopen {
+				prService.EXPECT().
+					ReopenPR(gomock.Any(), &prdto.ReopenPRRequest{PrID: fixturePRID}).
+					DoAndReturn(func(_ context.Context, req *prdto.ReopenPRRequest) (*prdto.ReopenPRResponse, error) {
+						if tt.reopenErr != nil {
+							return nil, tt.reopenErr
+						}
+						return &prdto.ReopenPRResponse{
+							PullRequest: prdomain.PullRequest{ID: req.PrID},
+							Reopened:    tt.reopened,
+						}, nil
+					})
+			}
+
+			if tt.wantClose {
+				prService.EXPECT().
+					ClosePR(gomock.Any(), &prdto.ClosePRRequest{PrID: fixturePRID}).
+					DoAndReturn(func(_ context.Context, req *prdto.ClosePRRequest) (*prdto.ClosePRResponse, error) {
+						if tt.closeErr != nil {
+							return nil, tt.closeErr
+						}
+						return &prdto.ClosePRResponse{PullRequest: prdomain.PullRequest{ID: req.PrID}}, nil
+					})
+			}
+
+			if tt.wantMerge {
+				prService.EXPECT().
+					MergePR(gomock.Any(), &prdto.MergePRRequest{PrID: fixturePRID}).
+					DoAndReturn(func(_ context.Context, req *prdto.MergePRRequest) (*prdto.MergePRResponse, error) {
+						if tt.mergeErr != nil {
+							return nil, tt.mergeErr
+						}
+						return &prdto.MergePRResponse{PullRequest: prdomain.PullRequest{ID: req.PrID}}, nil
+					})
+			}
+
+			uc := &IntegrationUsecase{
+				identityProvider: identityProvider,
+				prService:        prService,
+				cfg:              Config{GitHubSecret: testSecret},
+			}
+
+			body := loadFixture(t, tt.fixture)
+			signature := tt.signature
+			if signature == "" {
+				signature = sign(body)
+			}
+
+			resp, err := uc.HandleGitHubWebhook(context.Background(), &dto.GitHubWebhookRequest{
+				Event:     tt.event,
+				Signature: signature,
+				Body:      body,
+			})
+			if tt.wantErr != nil || tt.wantAnyErr {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, resp.Action)
+		})
+	}
+}
+
+func TestIntegrationUsecase_HandleGitHubWebhook_SecretNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	uc := &IntegrationUsecase{}
+
+	body := loadFixture(t, "github_pull_request_opened.json")
+	resp, err := uc.HandleGitHubWebhook(context.Background(), &dto.GitHubWebhookRequest{
+		Event:     domain.GitHubEventPullRequest,
+		Signature: sign(body),
+		Body:      body,
+	})
+
+	require.ErrorIs(t, err, apperr.ErrInvalidSignature)
+	require.Nil(t, resp)
+}
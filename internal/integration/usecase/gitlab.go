@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+)
+
+func (u *IntegrationUsecase) HandleGitLabWebhook(ctx context.Context,
+	request *dto.GitLabWebhookRequest) (*dto.WebhookResponse, error) {
+
+	if u.cfg.GitLabToken == "" {
+		slog.Warn("IntegrationUsecase.HandleGitLabWebhook: gitlab token is not configured")
+		return nil, apperr.ErrInvalidSignature
+	}
+
+	if !domain.VerifyGitLabToken(u.cfg.GitLabToken, request.Token) {
+		slog.Warn("IntegrationUsecase.HandleGitLabWebhook: token mismatch",
+			slog.String("event", request.Event),
+		)
+		return nil, apperr.ErrInvalidSignature
+	}
+
+	// системные хуки приходят с общим заголовком, тип события - в object_kind
+	switch request.Event {
+	case domain.GitLabEventMergeRequest:
+	case domain.GitLabEventSystem:
+		if !domain.IsGitLabMergeRequest(request.Body) {
+			return ignored("", "unsupported system hook"), nil
+		}
+	default:
+		return ignored("", fmt.Sprintf("unsupported event %q", request.Event)), nil
+	}
+
+	event, err := domain.ParseGitLabMergeRequest(request.Body)
+	if err != nil {
+		slog.Warn("IntegrationUsecase.HandleGitLabWebhook: invalid payload", slog.Any("error", err))
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidPayload, err)
+	}
+
+	return u.apply(ctx, event)
+}
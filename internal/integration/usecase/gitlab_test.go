@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "gitlab-token"
+
+const gitLabFixturePRID = "gitlab:118!17"
+
+func TestIntegrationUsecase_HandleGitLabWebhook(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name         string
+		event        string
+		fixture      string
+		token        string
+		resolveLogin string
+		resolveErr   error
+		wantResolve  bool
+		createErr    error
+		wantCreate   bool
+		wantMerge    bool
+		reopenErr    error
+		wantReopen   bool
+		wantClose    bool
+		wantAction   string
+		wantErr      error
+	}
+
+	tests := []tc{
+		{
+			name:         "open_creates_pr",
+			event:        domain.GitLabEventMergeRequest,
+			fixture:      "gitlab_merge_request_open.json",
+			wantResolve:  true,
+			resolveLogin: "alice",
+			wantCreate:   true,
+			wantAction:   "created",
+		},
+		{
+			name:       "reopen_reopens_closed_pr",
+			event:      domain.GitLabEventMergeRequest,
+			fixture:    "gitlab_merge_request_reopen_by_maintainer.json",
+			wantReopen: true,
+			wantAction: "reopened",
+		},
+		{
+			name:        "reopen_of_untracked_pr_resolves_author_by_id",
+			event:       domain.GitLabEventMergeRequest,
+			fixture:     "gitlab_merge_request_reopen_by_maintainer.json",
+			wantReopen:  true,
+			reopenErr:   apperr.ErrNotFound,
+			wantResolve: true,
+			wantCreate:  true,
+			wantAction:  "created",
+		},
+		{
+			name:       "draft_is_ignored",
+			event:      domain.GitLabEventMergeRequest,
+			fixture:    "gitlab_merge_request_open_draft.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:         "marked_ready_creates_pr",
+			event:        domain.GitLabEventMergeRequest,
+			fixture:      "gitlab_merge_request_update_ready.json",
+			wantResolve:  true,
+			resolveLogin: "alice",
+			wantCreate:   true,
+			wantAction:   "created",
+		},
+		{
+			name:       "other_update_is_ignored",
+			event:      domain.GitLabEventMergeRequest,
+			fixture:    "gitlab_merge_request_update.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "merge_merges_pr",
+			event:      domain.GitLabEventMergeRequest,
+			fixture:    "gitlab_merge_request_merge.json",
+			wantMerge:  true,
+			wantAction: "merged",
+		},
+		{
+			name:       "close_closes_pr",
+			event:      domain.GitLabEventMergeRequest,
+			fixture:    "gitlab_merge_request_close.json",
+			wantClose:  true,
+			wantAction: "closed",
+		},
+		{
+			name:       "system_hook_with_merge_request",
+			event:      domain.GitLabEventSystem,
+			fixture:    "gitlab_merge_request_merge.json",
+			wantMerge:  true,
+			wantAction: "merged",
+		},
+		{
+			name:       "system_hook_push_is_ignored",
+			event:      domain.GitLabEventSystem,
+			fixture:    "gitlab_push.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:       "push_hook_is_ignored",
+			event:      "Push Hook",
+			fixture:    "gitlab_push.json",
+			wantAction: string(domain.ActionIgnore),
+		},
+		{
+			name:    "bad_token",
+			event:   domain.GitLabEventMergeRequest,
+			fixture: "gitlab_merge_request_open.json",
+			token:   "wrong",
+			wantErr: apperr.ErrInvalidSignature,
+		},
+		{
+			name:         "unknown_author",
+			event:        domain.GitLabEventMergeRequest,
+			fixture:      "gitlab_merge_request_open.json",
+			wantResolve:  true,
+			resolveLogin: "alice",
+			resolveErr:   apperr.ErrNotFound,
+			wantErr:      apperr.ErrUnknownIdentity,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			identityProvider := mocks.NewMockIdentityProvider(ctrl)
+			prService := mocks.NewMockPRService(ctrl)
+
+			if tt.wantResolve {
+				userID := "u1"
+				if tt.resolveErr != nil {
+					userID = ""
+				}
+				// логин передаётся, только если событие инициировал сам автор
+				identityProvider.EXPECT().
+					ResolveUserID(gomock.Any(), domain.ProviderGitLab, tt.resolveLogin, "51").
+					Return(userID, tt.resolveErr)
+			}
+
+			if tt.wantCreate {
+				prService.EXPECT().
+					CreatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *prdto.CreatePRRequest) (*prdto.CreatePRResponse, error) {
+						assert.Equal(t, gitLabFixturePRID, req.PrID)
+						assert.Equal(t, "Out-of-office schedules", req.Name)
+						assert.Equal(t, "u1", req.AuthorId)
+						if tt.createErr != nil {
+							return nil, tt.createErr
+						}
+						return &prdto.CreatePRResponse{PullRequest: prdomain.PullRequest{ID: req.PrID}}, nil
+					})
+			}
+
+			if tt.wantReopen {
+				prService.EXPECT().
+					ReopenPR(gomock.Any(), &prdto.ReopenPRRequest{PrID: gitLabFixturePRID}).
+					DoAndReturn(func(_ context.Context, req *prdto.ReopenPRRequest) (*prdto.ReopenPRResponse, error) {
+						if tt.reopenErr != nil {
+							return nil, tt.reopenErr
+						}
+						return &prdto.ReopenPRResponse{PullRequest: prdomain.PullRequest{ID: req.PrID}, Reopened: true}, nil
+					})
+			}
+
+			if tt.wantClose {
+				prService.EXPECT().
+					ClosePR(gomock.Any(), &prdto.ClosePRRequest{PrID: gitLabFixturePRID}).
+					Return(&prdto.ClosePRResponse{PullRequest: prdomain.PullRequest{ID: gitLabFixturePRID}}, nil)
+			}
+
+			if tt.wantMerge {
+				prService.EXPECT().
+					MergePR(gomock.Any(), &prdto.MergePRRequest{PrID: gitLabFixturePRID}).
+					Return(&prdto.MergePRResponse{PullRequest: prdomain.PullRequest{ID: gitLabFixturePRID}}, nil)
+			}
+
+			uc := &IntegrationUsecase{
+				identityProvider: identityProvider,
+				prService:        prService,
+				cfg:              Config{GitLabToken: testToken},
+			}
+
+			token := tt.token
+			if token == "" {
+				token = testToken
+			}
+
+			resp, err := uc.HandleGitLabWebhook(context.Background(), &dto.GitLabWebhookRequest{
+				Event: tt.event,
+				Token: token,
+				Body:  loadFixture(t, tt.fixture),
+			})
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, resp.Action)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/integration/dto"
+)
+
+func (u *IntegrationUsecase) AddIdentity(ctx context.Context,
+	request *dto.AddIdentityRequest) (*dto.AddIdentityResponse, error) {
+
+	if !request.Provider.Valid() {
+		return nil, fmt.Errorf("%w: unknown provider %q", apperr.ErrInvalidPayload, request.Provider)
+	}
+
+	identity, err := u.identityProvider.AddIdentity(ctx, &request.ExternalIdentity)
+	if err != nil {
+		slog.Error("IntegrationUsecase.AddIdentity: provider error",
+			slog.String("provider", string(request.Provider)),
+			slog.String("login", request.Login),
+			slog.String("user_id", request.UserID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("add external identity in provider: %w", err)
+	}
+
+	slog.Info("IntegrationUsecase.AddIdentity: identity mapped",
+		slog.String("provider", string(identity.Provider)),
+		slog.String("login", identity.Login),
+		slog.String("user_id", identity.UserID),
+	)
+
+	return &dto.AddIdentityResponse{
+		Identity: *identity,
+	}, nil
+}
+
+func (u *IntegrationUsecase) ListIdentities(ctx context.Context,
+	request *dto.ListIdentitiesRequest) (*dto.ListIdentitiesResponse, error) {
+
+	provider := domain.Provider(request.Provider)
+	if !provider.Valid() {
+		return nil, fmt.Errorf("%w: unknown provider %q", apperr.ErrInvalidPayload, request.Provider)
+	}
+
+	identities, err := u.identityProvider.ListIdentities(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("list external identities in provider: %w", err)
+	}
+
+	return &dto.ListIdentitiesResponse{
+		Provider:   request.Provider,
+		Identities: identities,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+type IdentityProvider interface {
+	AddIdentity(ctx context.Context, identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error)
+	ListIdentities(ctx context.Context, provider domain.Provider) ([]domain.ExternalIdentity, error)
+	ResolveUserID(ctx context.Context, provider domain.Provider, login, externalID string) (string, error)
+}
+
+// PRService - операции над PR, которые выполняет интеграция (реализуется PRUsecase).
+type PRService interface {
+	CreatePR(ctx context.Context, request *prdto.CreatePRRequest) (*prdto.CreatePRResponse, error)
+	MergePR(ctx context.Context, request *prdto.MergePRRequest) (*prdto.MergePRResponse, error)
+	ClosePR(ctx context.Context, request *prdto.ClosePRRequest) (*prdto.ClosePRResponse, error)
+	ReopenPR(ctx context.Context, request *prdto.ReopenPRRequest) (*prdto.ReopenPRResponse, error)
+}
+
+type Config struct {
+	GitHubSecret string
+	GitLabToken  string
+}
+
+type IntegrationUsecase struct {
+	identityProvider IdentityProvider
+	prService        PRService
+	cfg              Config
+}
+
+func NewIntegrationUsecase(repo IdentityProvider, prService PRService, cfg Config) *IntegrationUsecase {
+	return &IntegrationUsecase{
+		identityProvider: repo,
+		prService:        prService,
+		cfg:              cfg,
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reviewer_assigner"
+
+// Registry - реестр метрик сервиса; отдаётся на /metrics вместе с метриками рантайма Go.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration - длительность обработки запросов. Граница 0.3 с совпадает
+	// с SLI времени ответа, поэтому долю быстрых ответов можно считать без интерполяции.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request duration by route and status.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.3, 0.5, 1, 2.5, 5},
+	}, []string{"method", "route", "status"})
+
+	// HTTPErrors - ответы с ошибкой по коду из тела ({"error": {"code": ...}}).
+	HTTPErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "errors_total",
+		Help:      "HTTP error responses by error code.",
+	}, []string{"code"})
+
+	PRsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Pull requests created.",
+	})
+
+	Reassignments = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewer_reassignments_total",
+		Help:      "Reviewers replaced on open pull requests.",
+	})
+
+	// NoCandidate - выбор ревьювера не нашёл ни одного кандидата (ответ NO_CANDIDATE
+	// или пропуск в фоновых задачах).
+	NoCandidate = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_total",
+		Help:      "Reviewer selections that found no candidate.",
+	})
+
+	// EmptySlots - места ревьюверов, оставшиеся свободными при создании PR.
+	EmptySlots = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "empty_reviewer_slots_total",
+		Help:      "Reviewer slots left empty when a pull request was created.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPErrors,
+		PRsCreated,
+		Reassignments,
+		NoCandidate,
+		EmptySlots,
+	)
+}
+
+// Handler отдаёт метрики реестра в текстовом формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector снимает статистику пула соединений pgx в момент сбора метрик.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired     *prometheus.Desc
+	idle         *prometheus.Desc
+	total        *prometheus.Desc
+	max          *prometheus.Desc
+	acquires     *prometheus.Desc
+	waits        *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:         pool,
+		acquired:     desc("acquired_connections", "Connections currently acquired from the pool."),
+		idle:         desc("idle_connections", "Idle connections in the pool."),
+		total:        desc("total_connections", "Connections currently open in the pool."),
+		max:          desc("max_connections", "Maximum size of the pool."),
+		acquires:     desc("acquires_total", "Successful acquires from the pool."),
+		waits:        desc("empty_acquires_total", "Acquires that had to wait for a connection."),
+		waitDuration: desc("acquire_wait_seconds_total", "Total time spent waiting for a connection."),
+	}
+}
+
+// RegisterPool добавляет статистику пула в Registry.
+func RegisterPool(pool *pgxpool.Pool) error {
+	return Registry.Register(NewPoolCollector(pool))
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.total
+	ch <- c.max
+	ch <- c.acquires
+	ch <- c.waits
+	ch <- c.waitDuration
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.waits, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	// только ожидание свободного соединения, без Acquire, которым соединение нашлось сразу
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+}
@@ -0,0 +1,67 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+)
+
+// Sender отправляет сообщения в incoming webhook Slack или Mattermost:
+// оба принимают JSON с полями text, username и channel.
+type Sender struct {
+	url      string
+	username string
+	client   *http.Client
+}
+
+type incomingWebhookPayload struct {
+	Text     string `json:"text"`
+	Username string `json:"username,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+}
+
+func NewSender(url, username string, timeout time.Duration) *Sender {
+	return &Sender{
+		url:      url,
+		username: username,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, message domain.ChatMessage) error {
+	body, err := json.Marshal(incomingWebhookPayload{
+		Text:     message.Text,
+		Username: s.username,
+		Channel:  message.Channel,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal chat message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send chat request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// тело ответа содержит причину ошибки ("channel_not_found", "invalid_payload")
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("chat webhook responded %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+type DeliveryRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewDeliveryRepository(conn *pgxpool.Pool) *DeliveryRepository {
+	return &DeliveryRepository{conn: conn}
+}
+
+func (d *DeliveryRepository) IsDelivered(ctx context.Context, delivery domain.Delivery) (bool, error) {
+	query := `
+		SELECT 1
+		FROM notification_deliveries
+		WHERE event_id = $1 AND sink = $2 AND recipient_id = $3 AND kind = $4
+	`
+
+	var found int
+	if err := storage.QuerierFrom(ctx, d.conn).QueryRow(ctx, query,
+		delivery.EventID, delivery.Sink, delivery.RecipientID, delivery.Kind,
+	).Scan(&found); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("db: failed to check notification delivery: %w", err)
+	}
+
+	return true, nil
+}
+
+func (d *DeliveryRepository) MarkDelivered(ctx context.Context, delivery domain.Delivery) error {
+	query := `
+		INSERT INTO notification_deliveries (event_id, sink, recipient_id, kind)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := storage.QuerierFrom(ctx, d.conn).Exec(ctx, query,
+		delivery.EventID, delivery.Sink, delivery.RecipientID, delivery.Kind,
+	); err != nil {
+		return fmt.Errorf("db: failed to mark notification delivered: %w", err)
+	}
+
+	return nil
+}
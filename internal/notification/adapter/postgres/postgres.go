@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+// digestBatchLimit ограничивает размер одного дайджеста; остаток уйдёт следующим письмом.
+const digestBatchLimit = 200
+
+type DigestRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewDigestRepository(conn *pgxpool.Pool) *DigestRepository {
+	return &DigestRepository{conn: conn}
+}
+
+func (d *DigestRepository) AppendDigestItem(ctx context.Context, userID string, item domain.DigestItem) error {
+	data, err := json.Marshal(item.Data)
+	if err != nil {
+		return fmt.Errorf("marshal digest item: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_digest_items (user_id, kind, data)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := storage.QuerierFrom(ctx, d.conn).Exec(ctx, query, userID, item.Kind, data); err != nil {
+		return fmt.Errorf("db: failed to append digest item: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DigestRepository) ListDigestRecipients(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT user_id
+		FROM email_digest_items
+		WHERE sent_at IS NULL
+		ORDER BY user_id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, d.conn).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list digest recipients: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("db: failed to scan digest recipient: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: failed to iterate digest recipients: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// ClaimDigestItems блокирует неотправленные записи пользователя до конца транзакции;
+// записи, занятые другой репликой, пропускаются.
+func (d *DigestRepository) ClaimDigestItems(ctx context.Context, userID string) ([]domain.DigestItem, error) {
+	query := `
+		SELECT id, kind, data, created_at
+		FROM email_digest_items
+		WHERE user_id = $1 AND sent_at IS NULL
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := storage.QuerierFrom(ctx, d.conn).Query(ctx, query, userID, digestBatchLimit)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to claim digest items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]domain.DigestItem, 0)
+	for rows.Next() {
+		var item domain.DigestItem
+		var data []byte
+		if err := rows.Scan(&item.ID, &item.Kind, &data, &item.CreatedAt); err != nil {
+			return nil, fmt.Errorf("db: failed to scan digest item: %w", err)
+		}
+		if err := json.Unmarshal(data, &item.Data); err != nil {
+			return nil, fmt.Errorf("db: failed to decode digest item %d: %w", item.ID, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: failed to iterate digest items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (d *DigestRepository) MarkDigestSent(ctx context.Context, ids []int64) error {
+	query := `
+		UPDATE email_digest_items
+		SET sent_at = NOW()
+		WHERE id = ANY($1)
+	`
+
+	if _, err := storage.QuerierFrom(ctx, d.conn).Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("db: failed to mark digest items sent: %w", err)
+	}
+
+	return nil
+}
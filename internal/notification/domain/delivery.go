@@ -0,0 +1,10 @@
+package domain
+
+// Delivery - уведомление одного вида одному получателю по событию outbox через синк Sink.
+// Отправленные доставки запоминаются, чтобы повтор события не дублировал сообщения.
+type Delivery struct {
+	EventID     int64
+	Sink        string
+	RecipientID string
+	Kind        Kind
+}
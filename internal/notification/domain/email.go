@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/email/*.tmpl
+var defaultEmailTemplates embed.FS
+
+// DefaultEmailTemplates - шаблоны писем, встроенные в бинарник.
+func DefaultEmailTemplates() fs.FS {
+	sub, err := fs.Sub(defaultEmailTemplates, "templates/email")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Email - письмо с текстовой и HTML-версией.
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// DigestItem - уведомление, отложенное до отправки дайджеста.
+type DigestItem struct {
+	ID        int64        `json:"id"`
+	Kind      Kind         `json:"kind"`
+	Data      TemplateData `json:"data"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// DigestData - данные шаблона дайджеста.
+type DigestData struct {
+	Recipient Recipient
+	Items     []DigestItem
+}
+
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// EmailTemplates - шаблоны писем: для каждого вида <kind>.subject.tmpl,
+// <kind>.txt.tmpl (text/template) и <kind>.html.tmpl (html/template).
+type EmailTemplates struct {
+	byKind map[Kind]emailTemplate
+}
+
+func ParseEmailTemplates(fsys fs.FS, kinds ...Kind) (*EmailTemplates, error) {
+	t := &EmailTemplates{byKind: make(map[Kind]emailTemplate, len(kinds))}
+
+	for _, kind := range kinds {
+		var tmpl emailTemplate
+		var err error
+
+		if tmpl.subject, err = parseText(fsys, kind, "subject"); err != nil {
+			return nil, err
+		}
+		if tmpl.text, err = parseText(fsys, kind, "txt"); err != nil {
+			return nil, err
+		}
+
+		name := string(kind) + ".html.tmpl"
+		source, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read email template %q: %w", name, err)
+		}
+		if tmpl.html, err = htmltemplate.New(name).Option("missingkey=error").Parse(string(source)); err != nil {
+			return nil, fmt.Errorf("parse email template %q: %w", name, err)
+		}
+
+		t.byKind[kind] = tmpl
+	}
+
+	return t, nil
+}
+
+func parseText(fsys fs.FS, kind Kind, part string) (*texttemplate.Template, error) {
+	name := string(kind) + "." + part + ".tmpl"
+
+	source, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("read email template %q: %w", name, err)
+	}
+
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("parse email template %q: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+func (t *EmailTemplates) Has(kind Kind) bool {
+	_, ok := t.byKind[kind]
+	return ok
+}
+
+// Render собирает письмо; data - TemplateData или DigestData.
+func (t *EmailTemplates) Render(kind Kind, to string, data any) (*Email, error) {
+	tmpl, ok := t.byKind[kind]
+	if !ok {
+		return nil, fmt.Errorf("email template %q is not configured", kind)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := tmpl.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", kind, err)
+	}
+	if err := tmpl.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", kind, err)
+	}
+
+	return &Email{
+		To: to,
+		// перевод строки в теме сломал бы заголовки письма
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEmailTemplates_Defaults(t *testing.T) {
+	t.Parallel()
+
+	templates, err := ParseEmailTemplates(DefaultEmailTemplates(),
+		KindReviewerAssigned, KindReviewerUnassigned, KindReviewReminder, KindStale, KindClosed, KindDigest)
+	require.NoError(t, err)
+
+	pr := prdomain.PullRequest{
+		ID:                "pr-1",
+		Name:              "Add <rotation>",
+		AuthorId:          "u1",
+		AssignedReviewers: []string{"u2", "u4"},
+	}
+	recipient := Recipient{UserID: "u2", Name: "Bob", Email: "bob@example.com"}
+
+	email, err := templates.Render(KindReviewerAssigned, recipient.Email, TemplateData{
+		Recipient:          recipient,
+		PullRequest:        pr,
+		ReplacedReviewerID: "u3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", email.To)
+	assert.Equal(t, "Review requested: Add <rotation>", email.Subject)
+	assert.Contains(t, email.Text, `You were assigned to review "Add <rotation>" (pr-1) by u1.`)
+	assert.Contains(t, email.Text, "You replace u3")
+	assert.Contains(t, email.Text, "Current reviewers: u2, u4")
+	// в HTML-версии данные экранируются
+	assert.Contains(t, email.HTML, "<b>Add &lt;rotation&gt;</b>")
+
+	email, err = templates.Render(KindReviewerUnassigned, recipient.Email, TemplateData{
+		Recipient:     recipient,
+		PullRequest:   pr,
+		NewReviewerID: "u5",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, email.Text, "handed over to u5")
+
+	email, err = templates.Render(KindReviewerUnassigned, recipient.Email, TemplateData{
+		Recipient:   recipient,
+		PullRequest: pr,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, email.Text, `You are no longer a reviewer of "Add <rotation>" (pr-1).`)
+
+	assignedAt := time.Date(2025, 12, 1, 9, 30, 0, 0, time.UTC)
+	email, err = templates.Render(KindReviewReminder, recipient.Email, TemplateData{
+		Recipient:   recipient,
+		PullRequest: pr,
+		AssignedAt:  &assignedAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Review reminder: Add <rotation>", email.Subject)
+	assert.Contains(t, email.Text, "waiting for your review since 2025-12-01 09:30 UTC")
+
+	closeAt := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	email, err = templates.Render(KindStale, recipient.Email, TemplateData{
+		Recipient:   recipient,
+		PullRequest: pr,
+		CloseAt:     &closeAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Pull request marked as stale: Add <rotation>", email.Subject)
+	assert.Contains(t, email.Text, "will be closed and its reviewers released on 2025-12-31")
+
+	email, err = templates.Render(KindDigest, recipient.Email, DigestData{
+		Recipient: recipient,
+		Items: []DigestItem{
+			{Kind: KindReviewerAssigned, Data: TemplateData{PullRequest: pr}},
+			{Kind: KindReviewerUnassigned, Data: TemplateData{PullRequest: pr, NewReviewerID: "u5"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Review digest: 2 update(s)", email.Subject)
+	assert.Contains(t, email.Text, `- assigned to "Add <rotation>" (pr-1) by u1`)
+	assert.Contains(t, email.Text, `- unassigned from "Add <rotation>" (pr-1), now reviewed by u5`)
+	assert.Contains(t, email.HTML, "<li>assigned to <b>Add &lt;rotation&gt;</b>")
+}
+
+func TestParseEmailTemplates_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing_html",
+			fsys: fstest.MapFS{
+				"merged.subject.tmpl": {Data: []byte("merged")},
+				"merged.txt.tmpl":     {Data: []byte("merged")},
+			},
+		},
+		{
+			name: "syntax_error",
+			fsys: fstest.MapFS{
+				"merged.subject.tmpl": {Data: []byte("{{.PullRequest.ID")},
+				"merged.txt.tmpl":     {Data: []byte("merged")},
+				"merged.html.tmpl":    {Data: []byte("merged")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseEmailTemplates(tt.fsys, KindMerged)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEmailTemplates_RenderFlattensSubject(t *testing.T) {
+	t.Parallel()
+
+	templates, err := ParseEmailTemplates(fstest.MapFS{
+		"merged.subject.tmpl": {Data: []byte("Merged:\n{{.PullRequest.Name}}\n")},
+		"merged.txt.tmpl":     {Data: []byte("text")},
+		"merged.html.tmpl":    {Data: []byte("<p>html</p>")},
+	}, KindMerged)
+	require.NoError(t, err)
+
+	email, err := templates.Render(KindMerged, "a@example.com", TemplateData{
+		PullRequest: prdomain.PullRequest{Name: "Fix\r\nBcc: evil@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Merged: Fix Bcc: evil@example.com", email.Subject)
+}
@@ -0,0 +1,8 @@
+package domain
+
+// ChatMessage - сообщение в incoming webhook Slack/Mattermost.
+type ChatMessage struct {
+	// Channel - канал или "@login" для личного сообщения; пустой - канал вебхука по умолчанию.
+	Channel string
+	Text    string
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// Notification - кому и о чём сообщить по событию outbox.
+type Notification struct {
+	Kind        Kind
+	RecipientID string
+	Data        TemplateData
+}
+
+// FromEvent определяет уведомления по событию. Каждый канал доставки
+// отправляет только те виды, для которых у него есть шаблоны.
+func FromEvent(event outboxdomain.Event) ([]Notification, error) {
+	switch event.Type {
+	case outboxdomain.EventPRReviewerAssigned:
+		var payload outboxdomain.ReviewerAssignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+
+		data := TemplateData{
+			PullRequest:        payload.PullRequest,
+			ReplacedReviewerID: payload.ReplacedReviewerID,
+		}
+		notifications := []Notification{{
+			Kind:        KindReviewerAssigned,
+			RecipientID: payload.ReviewerID,
+			Data:        data,
+		}}
+		if payload.ReplacedReviewerID != "" {
+			data.NewReviewerID = payload.ReviewerID
+			notifications = append(notifications, Notification{
+				Kind:        KindReviewerUnassigned,
+				RecipientID: payload.ReplacedReviewerID,
+				Data:        data,
+			})
+		}
+		return notifications, nil
+	case outboxdomain.EventPRReviewerUnassigned:
+		var payload outboxdomain.ReviewerUnassignedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return []Notification{{
+			Kind:        KindReviewerUnassigned,
+			RecipientID: payload.ReviewerID,
+			Data: TemplateData{
+				PullRequest: payload.PullRequest,
+			},
+		}}, nil
+	case outboxdomain.EventPRReviewReminder:
+		var payload outboxdomain.ReviewReminderPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return []Notification{{
+			Kind:        KindReviewReminder,
+			RecipientID: payload.ReviewerID,
+			Data: TemplateData{
+				PullRequest: payload.PullRequest,
+				AssignedAt:  &payload.AssignedAt,
+			},
+		}}, nil
+	case outboxdomain.EventPRStale:
+		var payload outboxdomain.PRStalePayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return []Notification{{
+			Kind:        KindStale,
+			RecipientID: payload.PullRequest.AuthorId,
+			Data: TemplateData{
+				PullRequest: payload.PullRequest,
+				CloseAt:     payload.CloseAt,
+			},
+		}}, nil
+	case outboxdomain.EventPRClosed:
+		var payload outboxdomain.PRClosedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return []Notification{{
+			Kind:        KindClosed,
+			RecipientID: payload.PullRequest.AuthorId,
+			Data: TemplateData{
+				PullRequest: payload.PullRequest,
+			},
+		}}, nil
+	case outboxdomain.EventPRMerged:
+		var payload outboxdomain.PRPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return []Notification{{
+			Kind:        KindMerged,
+			RecipientID: payload.PullRequest.AuthorId,
+			Data: TemplateData{
+				PullRequest: payload.PullRequest,
+			},
+		}}, nil
+	}
+
+	return nil, nil
+}
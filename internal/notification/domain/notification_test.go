@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEvent(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", AssignedReviewers: []string{"u2"}}
+
+	assigned, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2", ReplacedReviewerID: "u3"})
+	require.NoError(t, err)
+
+	merged, err := outboxdomain.NewEvent(outboxdomain.EventPRMerged, pr.ID, outboxdomain.PRPayload{PullRequest: pr})
+	require.NoError(t, err)
+
+	created, err := outboxdomain.NewEvent(outboxdomain.EventPRCreated, pr.ID, outboxdomain.PRPayload{PullRequest: pr})
+	require.NoError(t, err)
+
+	initial, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2"})
+	require.NoError(t, err)
+
+	unassigned, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerUnassigned, pr.ID,
+		outboxdomain.ReviewerUnassignedPayload{PullRequest: pr, ReviewerID: "u3"})
+	require.NoError(t, err)
+
+	reminder, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewReminder, pr.ID,
+		outboxdomain.ReviewReminderPayload{PullRequest: pr, ReviewerID: "u2"})
+	require.NoError(t, err)
+
+	stale, err := outboxdomain.NewEvent(outboxdomain.EventPRStale, pr.ID, outboxdomain.PRStalePayload{PullRequest: pr})
+	require.NoError(t, err)
+
+	closed, err := outboxdomain.NewEvent(outboxdomain.EventPRClosed, pr.ID,
+		outboxdomain.PRClosedPayload{PullRequest: pr, FreedReviewers: []string{"u2"}})
+	require.NoError(t, err)
+
+	type want struct {
+		kind      Kind
+		recipient string
+	}
+
+	tests := []struct {
+		name    string
+		event   outboxdomain.Event
+		want    []want
+		wantErr bool
+	}{
+		{
+			name:  "reviewer_assigned_on_create",
+			event: initial,
+			want:  []want{{KindReviewerAssigned, "u2"}},
+		},
+		{
+			name:  "reassign_notifies_new_and_replaced_reviewer",
+			event: assigned,
+			want:  []want{{KindReviewerAssigned, "u2"}, {KindReviewerUnassigned, "u3"}},
+		},
+		{
+			name:  "removal_notifies_unassigned_reviewer",
+			event: unassigned,
+			want:  []want{{KindReviewerUnassigned, "u3"}},
+		},
+		{
+			name:  "merged_goes_to_author",
+			event: merged,
+			want:  []want{{KindMerged, "u1"}},
+		},
+		{
+			name:  "review_reminder_goes_to_reviewer",
+			event: reminder,
+			want:  []want{{KindReviewReminder, "u2"}},
+		},
+		{
+			name:  "stale_goes_to_author",
+			event: stale,
+			want:  []want{{KindStale, "u1"}},
+		},
+		{
+			name:  "closed_goes_to_author",
+			event: closed,
+			want:  []want{{KindClosed, "u1"}},
+		},
+		{
+			name:  "created_is_not_notified",
+			event: created,
+		},
+		{
+			name: "broken_payload",
+			event: outboxdomain.Event{
+				Type:    outboxdomain.EventPRMerged,
+				Payload: json.RawMessage(`{`),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			notifications, err := FromEvent(tt.event)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, notifications, len(tt.want))
+			for i, w := range tt.want {
+				assert.Equal(t, w.kind, notifications[i].Kind)
+				assert.Equal(t, w.recipient, notifications[i].RecipientID)
+				assert.Equal(t, "pr-1", notifications[i].Data.PullRequest.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "strings"
+
+// Recipient - получатель уведомления.
+type Recipient struct {
+	UserID     string
+	Name       string
+	ChatHandle string
+	Email      string
+}
+
+// Mention возвращает упоминание пользователя в чате; без chat_handle - просто имя.
+func (r Recipient) Mention() string {
+	if r.ChatHandle == "" {
+		return r.Name
+	}
+	// Slack-упоминания вида <@U024BE7LH> передаются как есть
+	if strings.HasPrefix(r.ChatHandle, "<") {
+		return r.ChatHandle
+	}
+	return "@" + r.ChatHandle
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type Kind string
+
+const (
+	// KindReviewerAssigned - ревьюверу: назначен при создании PR или переназначении.
+	KindReviewerAssigned Kind = "reviewer_assigned"
+	// KindReviewerUnassigned - ревьюверу, которого заменили при переназначении или сняли с PR.
+	KindReviewerUnassigned Kind = "reviewer_unassigned"
+	// KindReviewReminder - ревьюверу: PR ждёт ревью дольше, чем позволяет SLA команды.
+	KindReviewReminder Kind = "review_reminder"
+	// KindStale - автору: PR помечен заброшенным и будет закрыт.
+	KindStale Kind = "stale"
+	// KindClosed - автору: заброшенный PR закрыт.
+	KindClosed Kind = "closed"
+	// KindMerged - автору: PR слит.
+	KindMerged Kind = "merged"
+	// KindDigest - сводка накопленных уведомлений для пользователей в режиме дайджеста.
+	KindDigest Kind = "digest"
+)
+
+// TemplateData - данные, доступные в шаблонах сообщений.
+type TemplateData struct {
+	Recipient   Recipient            `json:"-"`
+	PullRequest prdomain.PullRequest `json:"pull_request"`
+	// ReplacedReviewerID заполнен, если ревьювер назначен вместо другого.
+	ReplacedReviewerID string `json:"replaced_reviewer_id,omitempty"`
+	// NewReviewerID - кто назначен вместо получателя (для reviewer_unassigned), пустой - замены нет.
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+	// AssignedAt - когда получатель был назначен ревьювером (для review_reminder).
+	AssignedAt *time.Time `json:"assigned_at,omitempty"`
+	// CloseAt - когда заброшенный PR будет закрыт (для stale).
+	CloseAt *time.Time `json:"close_at,omitempty"`
+}
+
+// Templates - набор шаблонов сообщений по видам уведомлений.
+type Templates struct {
+	byKind map[Kind]*template.Template
+}
+
+// ParseTemplates разбирает шаблоны text/template. Ошибка синтаксиса обнаруживается
+// при старте сервиса, а не при первой отправке.
+func ParseTemplates(sources map[Kind]string) (*Templates, error) {
+	t := &Templates{byKind: make(map[Kind]*template.Template, len(sources))}
+
+	for kind, source := range sources {
+		if source == "" {
+			return nil, fmt.Errorf("template %q is empty", kind)
+		}
+
+		tmpl, err := template.New(string(kind)).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("parse template %q: %w", kind, err)
+		}
+		t.byKind[kind] = tmpl
+	}
+
+	return t, nil
+}
+
+func (t *Templates) Has(kind Kind) bool {
+	_, ok := t.byKind[kind]
+	return ok
+}
+
+func (t *Templates) Render(kind Kind, data TemplateData) (string, error) {
+	tmpl, ok := t.byKind[kind]
+	if !ok {
+		return "", fmt.Errorf("template %q is not configured", kind)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template %q: %w", kind, err)
+	}
+
+	return buf.String(), nil
+}
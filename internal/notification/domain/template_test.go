@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipient_Mention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		recipient Recipient
+		want      string
+	}{
+		{name: "handle", recipient: Recipient{Name: "Alice", ChatHandle: "alice"}, want: "@alice"},
+		{name: "slack_user_id", recipient: Recipient{Name: "Alice", ChatHandle: "<@U024BE7LH>"}, want: "<@U024BE7LH>"},
+		{name: "no_handle", recipient: Recipient{Name: "Alice"}, want: "Alice"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.recipient.Mention())
+		})
+	}
+}
+
+func TestParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sources map[Kind]string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			sources: map[Kind]string{KindMerged: "{{.Recipient.Mention}} merged"},
+		},
+		{
+			name:    "syntax_error",
+			sources: map[Kind]string{KindMerged: "{{.Recipient.Mention"},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			sources: map[Kind]string{KindMerged: ""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			templates, err := ParseTemplates(tt.sources)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, templates.Has(KindMerged))
+		})
+	}
+}
+
+func TestTemplates_Render(t *testing.T) {
+	t.Parallel()
+
+	templates, err := ParseTemplates(map[Kind]string{
+		KindReviewerAssigned: `{{.Recipient.Mention}}: review {{.PullRequest.ID}}{{if .ReplacedReviewerID}} instead of {{.ReplacedReviewerID}}{{end}}`,
+		KindMerged:           `{{.Recipient.Mention}}: {{.Unknown}}`,
+	})
+	require.NoError(t, err)
+
+	data := TemplateData{
+		Recipient:   Recipient{Name: "Bob", ChatHandle: "bob"},
+		PullRequest: prdomain.PullRequest{ID: "pr-1"},
+	}
+
+	text, err := templates.Render(KindReviewerAssigned, data)
+	require.NoError(t, err)
+	assert.Equal(t, "@bob: review pr-1", text)
+
+	data.ReplacedReviewerID = "u3"
+	text, err = templates.Render(KindReviewerAssigned, data)
+	require.NoError(t, err)
+	assert.Equal(t, "@bob: review pr-1 instead of u3", text)
+
+	// обращение к несуществующему полю - ошибка выполнения шаблона
+	_, err = templates.Render(KindMerged, data)
+	require.Error(t, err)
+
+	_, err = templates.Render(Kind("unknown"), data)
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// Name и Handle реализуют синк outbox: ревьюверу сообщается о назначении,
+// автору - о merge. Ошибка отправки возвращается диспетчеру для повтора; получатели,
+// которым сообщение уже ушло, при повторе пропускаются по журналу доставок.
+func (n *ChatNotifier) Name() string {
+	return "chat"
+}
+
+func (n *ChatNotifier) Handle(ctx context.Context, event outboxdomain.Event) error {
+	notifications, err := domain.FromEvent(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, notification := range notifications {
+		if !n.templates.Has(notification.Kind) {
+			continue
+		}
+		if err := n.deliver(ctx, event, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver отправляет уведомление, если оно ещё не уходило получателю, и записывает доставку.
+func (n *ChatNotifier) deliver(ctx context.Context, event outboxdomain.Event, notification domain.Notification) error {
+	delivery := domain.Delivery{
+		EventID:     event.ID,
+		Sink:        n.Name(),
+		RecipientID: notification.RecipientID,
+		Kind:        notification.Kind,
+	}
+
+	delivered, err := n.deliveries.IsDelivered(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("check notification delivery in provider: %w", err)
+	}
+	if delivered {
+		slog.Info("ChatNotifier.Handle: already delivered, skipped",
+			slog.Int64("event_id", event.ID),
+			slog.String("user_id", notification.RecipientID),
+		)
+		return nil
+	}
+
+	if err := n.notify(ctx, event, notification); err != nil {
+		return err
+	}
+
+	if err := n.deliveries.MarkDelivered(ctx, delivery); err != nil {
+		return fmt.Errorf("mark notification delivered in provider: %w", err)
+	}
+	return nil
+}
+
+func (n *ChatNotifier) notify(ctx context.Context, event outboxdomain.Event, notification domain.Notification) error {
+	user, err := n.userReader.GetUser(ctx, notification.RecipientID)
+	if err != nil {
+		// пользователь удалён - повторять бессмысленно
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Warn("ChatNotifier.Handle: recipient not found",
+				slog.Int64("event_id", event.ID),
+				slog.String("user_id", notification.RecipientID),
+			)
+			return nil
+		}
+		return fmt.Errorf("get recipient in provider: %w", err)
+	}
+
+	if user.ChatHandle == "" {
+		slog.Info("ChatNotifier.Handle: recipient has no chat handle, skipped",
+			slog.Int64("event_id", event.ID),
+			slog.String("user_id", user.ID),
+		)
+		return nil
+	}
+
+	data := notification.Data
+	data.Recipient = recipientOf(user)
+
+	text, err := n.templates.Render(notification.Kind, data)
+	if err != nil {
+		return err
+	}
+
+	message := domain.ChatMessage{Text: text}
+	if n.cfg.DirectMessages {
+		message.Channel = "@" + user.ChatHandle
+	}
+
+	if err := n.sender.Send(ctx, message); err != nil {
+		slog.Warn("ChatNotifier.Handle: send failed",
+			slog.Int64("event_id", event.ID),
+			slog.String("user_id", user.ID),
+			slog.Any("error", err),
+		)
+		return fmt.Errorf("send chat message: %w", err)
+	}
+
+	slog.Info("ChatNotifier.Handle: message sent",
+		slog.Int64("event_id", event.ID),
+		slog.String("kind", string(notification.Kind)),
+		slog.String("user_id", user.ID),
+	)
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/adapter/chat"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chatStub struct {
+	mu       sync.Mutex
+	status   int
+	received []map[string]string
+}
+
+func (s *chatStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload map[string]string
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	s.mu.Lock()
+	s.received = append(s.received, payload)
+	s.mu.Unlock()
+
+	w.WriteHeader(s.status)
+	_, _ = w.Write([]byte("ok"))
+}
+
+// memoryDeliveries - журнал доставок в памяти.
+type memoryDeliveries struct {
+	mu        sync.Mutex
+	delivered map[domain.Delivery]bool
+}
+
+func newMemoryDeliveries(delivered ...domain.Delivery) *memoryDeliveries {
+	log := &memoryDeliveries{delivered: make(map[domain.Delivery]bool)}
+	for _, delivery := range delivered {
+		log.delivered[delivery] = true
+	}
+	return log
+}
+
+func (m *memoryDeliveries) IsDelivered(_ context.Context, delivery domain.Delivery) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.delivered[delivery], nil
+}
+
+func (m *memoryDeliveries) MarkDelivered(_ context.Context, delivery domain.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delivered[delivery] = true
+	return nil
+}
+
+func testTemplates(t *testing.T) *domain.Templates {
+	t.Helper()
+
+	templates, err := domain.ParseTemplates(map[domain.Kind]string{
+		domain.KindReviewerAssigned: `{{.Recipient.Mention}} review {{.PullRequest.ID}}{{if .ReplacedReviewerID}} (was {{.ReplacedReviewerID}}){{end}}`,
+		domain.KindMerged:           `{{.Recipient.Mention}} {{.PullRequest.ID}} merged`,
+	})
+	require.NoError(t, err)
+
+	return templates
+}
+
+func TestChatNotifier_Handle(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", AssignedReviewers: []string{"u2"}}
+
+	assigned, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2", ReplacedReviewerID: "u3"})
+	require.NoError(t, err)
+
+	merged, err := outboxdomain.NewEvent(outboxdomain.EventPRMerged, pr.ID, outboxdomain.PRPayload{PullRequest: pr})
+	require.NoError(t, err)
+
+	activity, err := outboxdomain.NewEvent(outboxdomain.EventUserActivityChanged, "u1",
+		outboxdomain.UserActivityPayload{User: userdomain.User{ID: "u1"}})
+	require.NoError(t, err)
+
+	type tc struct {
+		name        string
+		event       outboxdomain.Event
+		direct      bool
+		stubStatus  int
+		wantUserID  string
+		stubUser    *userdomain.User
+		stubErr     error
+		delivered   []domain.Delivery
+		wantText    string
+		wantChannel string
+		wantErr     bool
+	}
+
+	tests := []tc{
+		{
+			name:       "reviewer_assigned",
+			event:      assigned,
+			stubStatus: http.StatusOK,
+			wantUserID: "u2",
+			stubUser:   &userdomain.User{ID: "u2", Name: "Bob", ChatHandle: "bob"},
+			wantText:   "@bob review pr-1 (was u3)",
+		},
+		{
+			name:        "merged_direct_message_to_author",
+			event:       merged,
+			direct:      true,
+			stubStatus:  http.StatusOK,
+			wantUserID:  "u1",
+			stubUser:    &userdomain.User{ID: "u1", Name: "Alice", ChatHandle: "alice"},
+			wantText:    "@alice pr-1 merged",
+			wantChannel: "@alice",
+		},
+		{
+			name:       "recipient_without_handle_is_skipped",
+			event:      assigned,
+			stubStatus: http.StatusOK,
+			wantUserID: "u2",
+			stubUser:   &userdomain.User{ID: "u2", Name: "Bob"},
+		},
+		{
+			name:       "recipient_not_found_is_skipped",
+			event:      assigned,
+			stubStatus: http.StatusOK,
+			wantUserID: "u2",
+			stubErr:    apperr.ErrNotFound,
+		},
+		{
+			name:       "already_delivered_recipient_is_skipped",
+			event:      assigned,
+			stubStatus: http.StatusOK,
+			delivered: []domain.Delivery{
+				{Sink: "chat", RecipientID: "u2", Kind: domain.KindReviewerAssigned},
+			},
+		},
+		{
+			name:       "other_events_are_ignored",
+			event:      activity,
+			stubStatus: http.StatusOK,
+		},
+		{
+			name:       "chat_error_is_retried",
+			event:      assigned,
+			stubStatus: http.StatusInternalServerError,
+			wantUserID: "u2",
+			stubUser:   &userdomain.User{ID: "u2", Name: "Bob", ChatHandle: "bob"},
+			wantText:   "@bob review pr-1 (was u3)",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stub := &chatStub{status: tt.stubStatus}
+			server := httptest.NewServer(stub)
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userReader := mocks.NewMockNotificationUserReader(ctrl)
+			if tt.wantUserID != "" {
+				userReader.EXPECT().GetUser(gomock.Any(), tt.wantUserID).Return(tt.stubUser, tt.stubErr)
+			}
+
+			notifier := NewChatNotifier(
+				userReader,
+				newMemoryDeliveries(tt.delivered...),
+				chat.NewSender(server.URL, "reviewer-bot", time.Second),
+				testTemplates(t),
+				ChatConfig{DirectMessages: tt.direct},
+			)
+
+			err := notifier.Handle(context.Background(), tt.event)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			stub.mu.Lock()
+			defer stub.mu.Unlock()
+
+			if tt.wantText == "" {
+				assert.Empty(t, stub.received)
+				return
+			}
+
+			require.Len(t, stub.received, 1)
+			assert.Equal(t, tt.wantText, stub.received[0]["text"])
+			assert.Equal(t, "reviewer-bot", stub.received[0]["username"])
+			assert.Equal(t, tt.wantChannel, stub.received[0]["channel"])
+		})
+	}
+}
+
+func TestChatNotifier_Handle_RetrySkipsDelivered(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", AssignedReviewers: []string{"u2"}}
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2", ReplacedReviewerID: "u3"})
+	require.NoError(t, err)
+	event.ID = 7
+
+	templates, err := domain.ParseTemplates(map[domain.Kind]string{
+		domain.KindReviewerAssigned:   `{{.Recipient.Mention}} review {{.PullRequest.ID}}`,
+		domain.KindReviewerUnassigned: `{{.Recipient.Mention}} unassigned from {{.PullRequest.ID}}`,
+	})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userReader := mocks.NewMockNotificationUserReader(ctrl)
+	userReader.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*userdomain.User, error) {
+			return &userdomain.User{ID: id, Name: id, ChatHandle: id}, nil
+		})
+
+	// первая попытка: новому ревьюверу сообщение ушло, снятому - нет
+	sender := mocks.NewMockChatSender(ctrl)
+	gomock.InOrder(
+		sender.EXPECT().Send(gomock.Any(), domain.ChatMessage{Text: "@u2 review pr-1"}).Return(nil),
+		sender.EXPECT().Send(gomock.Any(), domain.ChatMessage{Text: "@u3 unassigned from pr-1"}).
+			Return(errors.New("chat unavailable")),
+		// повтор отправляет только недоставленное
+		sender.EXPECT().Send(gomock.Any(), domain.ChatMessage{Text: "@u3 unassigned from pr-1"}).Return(nil),
+	)
+
+	deliveries := newMemoryDeliveries()
+	notifier := NewChatNotifier(userReader, deliveries, sender, templates, ChatConfig{})
+
+	require.Error(t, notifier.Handle(context.Background(), event))
+	require.NoError(t, notifier.Handle(context.Background(), event))
+	assert.Len(t, deliveries.delivered, 2)
+}
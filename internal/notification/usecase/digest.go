@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+)
+
+// RunDigest периодически отправляет накопленные дайджесты, пока не отменён ctx.
+func (n *EmailNotifier) RunDigest(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := n.SendDigests(ctx); err != nil {
+				slog.Error("EmailNotifier.RunDigest: send digests failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// SendDigests отправляет по одному письму каждому пользователю с накопленными уведомлениями.
+// Записи блокируются на время отправки, поэтому несколько реплик не дублируют письма.
+func (n *EmailNotifier) SendDigests(ctx context.Context) error {
+	userIDs, err := n.digestProvider.ListDigestRecipients(ctx)
+	if err != nil {
+		return fmt.Errorf("list digest recipients in provider: %w", err)
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := n.sendDigest(ctx, userID); err != nil {
+			slog.Warn("EmailNotifier.SendDigests: digest failed",
+				slog.String("user_id", userID),
+				slog.Any("error", err),
+			)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *EmailNotifier) sendDigest(ctx context.Context, userID string) error {
+	return n.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		items, err := n.digestProvider.ClaimDigestItems(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("claim digest items in provider: %w", err)
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+
+		user, err := n.userReader.GetUser(ctx, userID)
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			return fmt.Errorf("get recipient in provider: %w", err)
+		}
+
+		// пользователь отписался после постановки в очередь - записи сбрасываются без отправки
+		if user != nil && user.Email != "" && !user.EmailOptOut {
+			email, err := n.templates.Render(domain.KindDigest, user.Email, domain.DigestData{
+				Recipient: recipientOf(user),
+				Items:     items,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := n.sender.Send(ctx, email); err != nil {
+				return fmt.Errorf("send digest email: %w", err)
+			}
+
+			slog.Info("EmailNotifier.sendDigest: digest sent",
+				slog.String("user_id", userID),
+				slog.Int("items", len(items)),
+			)
+		}
+
+		if err := n.digestProvider.MarkDigestSent(ctx, ids); err != nil {
+			return fmt.Errorf("mark digest sent in provider: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// Name и Handle реализуют синк outbox. Письма уходят из фонового диспетчера,
+// поэтому SMTP не влияет на время ответа API. Письма и записи дайджеста, уже доставленные
+// получателю, при повторе события пропускаются по журналу доставок.
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+func (n *EmailNotifier) Handle(ctx context.Context, event outboxdomain.Event) error {
+	notifications, err := domain.FromEvent(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, notification := range notifications {
+		if !n.templates.Has(notification.Kind) {
+			continue
+		}
+		if err := n.deliver(ctx, event, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deliver отправляет уведомление, если оно ещё не уходило получателю, и записывает доставку.
+func (n *EmailNotifier) deliver(ctx context.Context, event outboxdomain.Event, notification domain.Notification) error {
+	delivery := domain.Delivery{
+		EventID:     event.ID,
+		Sink:        n.Name(),
+		RecipientID: notification.RecipientID,
+		Kind:        notification.Kind,
+	}
+
+	delivered, err := n.deliveries.IsDelivered(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("check notification delivery in provider: %w", err)
+	}
+	if delivered {
+		slog.Info("EmailNotifier.deliver: already delivered, skipped",
+			slog.Int64("event_id", event.ID),
+			slog.String("user_id", notification.RecipientID),
+		)
+		return nil
+	}
+
+	if err := n.notify(ctx, event, notification); err != nil {
+		return err
+	}
+
+	if err := n.deliveries.MarkDelivered(ctx, delivery); err != nil {
+		return fmt.Errorf("mark notification delivered in provider: %w", err)
+	}
+	return nil
+}
+
+func (n *EmailNotifier) notify(ctx context.Context, event outboxdomain.Event, notification domain.Notification) error {
+	user, err := n.userReader.GetUser(ctx, notification.RecipientID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Warn("EmailNotifier.notify: recipient not found",
+				slog.Int64("event_id", event.ID),
+				slog.String("user_id", notification.RecipientID),
+			)
+			return nil
+		}
+		return fmt.Errorf("get recipient in provider: %w", err)
+	}
+
+	if user.Email == "" || user.EmailOptOut {
+		return nil
+	}
+
+	if user.EmailDigest {
+		if err := n.digestProvider.AppendDigestItem(ctx, user.ID, domain.DigestItem{
+			Kind: notification.Kind,
+			Data: notification.Data,
+		}); err != nil {
+			return fmt.Errorf("append digest item in provider: %w", err)
+		}
+		return nil
+	}
+
+	data := notification.Data
+	data.Recipient = recipientOf(user)
+
+	email, err := n.templates.Render(notification.Kind, user.Email, data)
+	if err != nil {
+		return err
+	}
+
+	if err := n.sender.Send(ctx, email); err != nil {
+		slog.Warn("EmailNotifier.notify: send failed",
+			slog.Int64("event_id", event.ID),
+			slog.String("user_id", user.ID),
+			slog.Any("error", err),
+		)
+		return fmt.Errorf("send email: %w", err)
+	}
+
+	slog.Info("EmailNotifier.notify: email sent",
+		slog.Int64("event_id", event.ID),
+		slog.String("kind", string(notification.Kind)),
+		slog.String("user_id", user.ID),
+	)
+
+	return nil
+}
+
+func recipientOf(user *userdomain.User) domain.Recipient {
+	return domain.Recipient{
+		UserID:     user.ID,
+		Name:       user.Name,
+		ChatHandle: user.ChatHandle,
+		Email:      user.Email,
+	}
+}
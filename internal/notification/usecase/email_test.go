@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/adapter/smtp"
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedEmail struct {
+	to      string
+	subject string
+	text    string
+	html    string
+}
+
+// parseEmail разбирает письмо, принятое тестовым SMTP-сервером.
+func parseEmail(t *testing.T, data string) receivedEmail {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+
+	email := receivedEmail{to: msg.Header.Get("To"), subject: subject}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+
+		if strings.HasPrefix(part.Header.Get("Content-Type"), "text/html") {
+			email.html = string(body)
+		} else {
+			email.text = string(body)
+		}
+	}
+
+	return email
+}
+
+func newTestSMTPSender(t *testing.T, server *testutils.SMTPServer) *smtp.Sender {
+	t.Helper()
+
+	sender, err := smtp.NewSender(smtp.Config{
+		Host:    server.Host(),
+		Port:    server.Port(),
+		From:    "Reviewer Assigner <noreply@example.com>",
+		TLS:     smtp.TLSNone,
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	return sender
+}
+
+func testEmailTemplates(t *testing.T) *domain.EmailTemplates {
+	t.Helper()
+
+	templates, err := domain.ParseEmailTemplates(domain.DefaultEmailTemplates(),
+		domain.KindReviewerAssigned, domain.KindReviewerUnassigned, domain.KindDigest)
+	require.NoError(t, err)
+
+	return templates
+}
+
+func TestEmailNotifier_Handle(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", AssignedReviewers: []string{"u2"}}
+
+	reassigned, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2", ReplacedReviewerID: "u3"})
+	require.NoError(t, err)
+
+	merged, err := outboxdomain.NewEvent(outboxdomain.EventPRMerged, pr.ID, outboxdomain.PRPayload{PullRequest: pr})
+	require.NoError(t, err)
+
+	users := map[string]*userdomain.User{
+		"u2": {ID: "u2", Name: "Bob", Email: "bob@example.com"},
+		"u3": {ID: "u3", Name: "Carol", Email: "carol@example.com"},
+	}
+
+	type tc struct {
+		name       string
+		event      outboxdomain.Event
+		users      map[string]*userdomain.User
+		wantDigest []string
+		wantTo     []string
+		wantErr    bool
+	}
+
+	tests := []tc{
+		{
+			name:   "reassign_emails_new_and_replaced_reviewer",
+			event:  reassigned,
+			users:  users,
+			wantTo: []string{"<bob@example.com>", "<carol@example.com>"},
+		},
+		{
+			name:  "opt_out_and_missing_email_are_skipped",
+			event: reassigned,
+			users: map[string]*userdomain.User{
+				"u2": {ID: "u2", Name: "Bob", Email: "bob@example.com", EmailOptOut: true},
+				"u3": {ID: "u3", Name: "Carol"},
+			},
+		},
+		{
+			name:  "digest_mode_is_queued",
+			event: reassigned,
+			users: map[string]*userdomain.User{
+				"u2": {ID: "u2", Name: "Bob", Email: "bob@example.com", EmailDigest: true},
+				"u3": {ID: "u3", Name: "Carol", Email: "carol@example.com"},
+			},
+			wantDigest: []string{"u2"},
+			wantTo:     []string{"<carol@example.com>"},
+		},
+		{
+			name:  "merge_has_no_email_template",
+			event: merged,
+			users: users,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := testutils.NewSMTPServer(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userReader := mocks.NewMockNotificationUserReader(ctrl)
+			userReader.EXPECT().
+				GetUser(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, id string) (*userdomain.User, error) {
+					if user, ok := tt.users[id]; ok {
+						return user, nil
+					}
+					return nil, apperr.ErrNotFound
+				}).
+				AnyTimes()
+
+			digestProvider := mocks.NewMockDigestProvider(ctrl)
+			for _, userID := range tt.wantDigest {
+				digestProvider.EXPECT().
+					AppendDigestItem(gomock.Any(), userID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, item domain.DigestItem) error {
+						assert.Equal(t, domain.KindReviewerAssigned, item.Kind)
+						assert.Equal(t, "pr-1", item.Data.PullRequest.ID)
+						return nil
+					})
+			}
+
+			notifier := NewEmailNotifier(userReader, digestProvider, newMemoryDeliveries(), newTestSMTPSender(t, server),
+				testEmailTemplates(t), testutils.InlineTx{})
+
+			err := notifier.Handle(context.Background(), tt.event)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			messages := server.Messages()
+			require.Len(t, messages, len(tt.wantTo))
+			for i, to := range tt.wantTo {
+				email := parseEmail(t, messages[i].Data)
+				assert.Equal(t, to, email.to)
+				assert.Equal(t, "noreply@example.com", messages[i].From)
+				assert.Contains(t, email.text, "Fix")
+				assert.Contains(t, email.html, "<b>Fix</b>")
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_Handle_SMTPError(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.NewSMTPServer(t)
+	server.RejectRecipients(true)
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1"}
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2"})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userReader := mocks.NewMockNotificationUserReader(ctrl)
+	userReader.EXPECT().GetUser(gomock.Any(), "u2").
+		Return(&userdomain.User{ID: "u2", Name: "Bob", Email: "bob@example.com"}, nil)
+
+	notifier := NewEmailNotifier(userReader, nil, newMemoryDeliveries(), newTestSMTPSender(t, server),
+		testEmailTemplates(t), testutils.InlineTx{})
+
+	// ошибка возвращается диспетчеру outbox, и событие будет повторено
+	require.Error(t, notifier.Handle(context.Background(), event))
+	assert.Empty(t, server.Messages())
+}
+
+func TestEmailNotifier_Handle_RetrySkipsDelivered(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.NewSMTPServer(t)
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", AssignedReviewers: []string{"u2"}}
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, pr.ID,
+		outboxdomain.ReviewerAssignedPayload{PullRequest: pr, ReviewerID: "u2", ReplacedReviewerID: "u3"})
+	require.NoError(t, err)
+	event.ID = 7
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	users := map[string]*userdomain.User{
+		"u2": {ID: "u2", Name: "Bob", Email: "bob@example.com", EmailDigest: true},
+		"u3": {ID: "u3", Name: "Carol", Email: "carol@example.com"},
+	}
+	userReader := mocks.NewMockNotificationUserReader(ctrl)
+	userReader.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*userdomain.User, error) {
+			return users[id], nil
+		})
+
+	// первая попытка: дайджест ревьювера не записался, письмо снятому ушло
+	digestProvider := mocks.NewMockDigestProvider(ctrl)
+	gomock.InOrder(
+		digestProvider.EXPECT().AppendDigestItem(gomock.Any(), "u2", gomock.Any()).Return(errors.New("db error")),
+		digestProvider.EXPECT().AppendDigestItem(gomock.Any(), "u2", gomock.Any()).Return(nil),
+	)
+
+	notifier := NewEmailNotifier(userReader, digestProvider, newMemoryDeliveries(), newTestSMTPSender(t, server),
+		testEmailTemplates(t), testutils.InlineTx{})
+
+	require.Error(t, notifier.Handle(context.Background(), event))
+	require.NoError(t, notifier.Handle(context.Background(), event))
+
+	// повтор не отправил снятому второе письмо
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "<carol@example.com>", parseEmail(t, messages[0].Data).to)
+}
+
+func TestEmailNotifier_SendDigests(t *testing.T) {
+	t.Parallel()
+
+	server := testutils.NewSMTPServer(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pr := prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1"}
+
+	userReader := mocks.NewMockNotificationUserReader(ctrl)
+	userReader.EXPECT().GetUser(gomock.Any(), "u2").
+		Return(&userdomain.User{ID: "u2", Name: "Bob", Email: "bob@example.com", EmailDigest: true}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u3").
+		Return(&userdomain.User{ID: "u3", Name: "Carol", Email: "carol@example.com", EmailOptOut: true}, nil)
+
+	digestProvider := mocks.NewMockDigestProvider(ctrl)
+	digestProvider.EXPECT().ListDigestRecipients(gomock.Any()).Return([]string{"u2", "u3", "u4"}, nil)
+	digestProvider.EXPECT().ClaimDigestItems(gomock.Any(), "u2").Return([]domain.DigestItem{
+		{ID: 1, Kind: domain.KindReviewerAssigned, Data: domain.TemplateData{PullRequest: pr}},
+		{ID: 2, Kind: domain.KindReviewerUnassigned, Data: domain.TemplateData{PullRequest: pr, NewReviewerID: "u5"}},
+	}, nil)
+	digestProvider.EXPECT().ClaimDigestItems(gomock.Any(), "u3").Return([]domain.DigestItem{
+		{ID: 3, Kind: domain.KindReviewerAssigned, Data: domain.TemplateData{PullRequest: pr}},
+	}, nil)
+	// записи u4 уже забрала другая реплика
+	digestProvider.EXPECT().ClaimDigestItems(gomock.Any(), "u4").Return(nil, nil)
+	digestProvider.EXPECT().MarkDigestSent(gomock.Any(), []int64{1, 2}).Return(nil)
+	// отписавшемуся письмо не отправляется, записи сбрасываются
+	digestProvider.EXPECT().MarkDigestSent(gomock.Any(), []int64{3}).Return(nil)
+
+	notifier := NewEmailNotifier(userReader, digestProvider, newMemoryDeliveries(), newTestSMTPSender(t, server),
+		testEmailTemplates(t), testutils.InlineTx{})
+
+	require.NoError(t, notifier.SendDigests(context.Background()))
+
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+
+	email := parseEmail(t, messages[0].Data)
+	assert.Equal(t, "<bob@example.com>", email.to)
+	assert.Equal(t, "Review digest: 2 update(s)", email.subject)
+	assert.Contains(t, email.text, `- assigned to "Fix" (pr-1) by u1`)
+	assert.Contains(t, email.text, `- unassigned from "Fix" (pr-1), now reviewed by u5`)
+}
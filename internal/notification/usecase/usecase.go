@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type UserReader interface {
+	GetUser(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type DigestProvider interface {
+	AppendDigestItem(ctx context.Context, userID string, item domain.DigestItem) error
+	ListDigestRecipients(ctx context.Context) ([]string, error)
+	ClaimDigestItems(ctx context.Context, userID string) ([]domain.DigestItem, error)
+	MarkDigestSent(ctx context.Context, ids []int64) error
+}
+
+// DeliveryLog помнит, каким получателям уведомление о событии outbox уже отправлено: синк
+// outbox повторяет событие целиком, и без журнала ошибка на одном получателе дублировала бы
+// сообщения остальным.
+type DeliveryLog interface {
+	IsDelivered(ctx context.Context, delivery domain.Delivery) (bool, error)
+	MarkDelivered(ctx context.Context, delivery domain.Delivery) error
+}
+
+type EmailSender interface {
+	Send(ctx context.Context, email *domain.Email) error
+}
+
+type ChatSender interface {
+	Send(ctx context.Context, message domain.ChatMessage) error
+}
+
+type ChatConfig struct {
+	// DirectMessages - отправлять в личные сообщения ("@login"), а не в канал вебхука.
+	DirectMessages bool
+}
+
+type ChatNotifier struct {
+	userReader UserReader
+	deliveries DeliveryLog
+	sender     ChatSender
+	templates  *domain.Templates
+	cfg        ChatConfig
+}
+
+func NewChatNotifier(userReader UserReader, deliveries DeliveryLog, sender ChatSender,
+	templates *domain.Templates, cfg ChatConfig) *ChatNotifier {
+
+	return &ChatNotifier{
+		userReader: userReader,
+		deliveries: deliveries,
+		sender:     sender,
+		templates:  templates,
+		cfg:        cfg,
+	}
+}
+
+type EmailNotifier struct {
+	userReader     UserReader
+	digestProvider DigestProvider
+	deliveries     DeliveryLog
+	sender         EmailSender
+	templates      *domain.EmailTemplates
+	txManager      Transactor
+}
+
+func NewEmailNotifier(userReader UserReader, digestProvider DigestProvider, deliveries DeliveryLog,
+	sender EmailSender, templates *domain.EmailTemplates, txManager Transactor) *EmailNotifier {
+
+	return &EmailNotifier{
+		userReader:     userReader,
+		digestProvider: digestProvider,
+		deliveries:     deliveries,
+		sender:         sender,
+		templates:      templates,
+		txManager:      txManager,
+	}
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+const selectPeriod = `
+	SELECT id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, created_at
+	FROM user_ooo_periods
+`
+
+type PeriodRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewPeriodRepository(conn *pgxpool.Pool) *PeriodRepository {
+	return &PeriodRepository{conn: conn}
+}
+
+func scanPeriod(row pgx.Row) (*domain.Period, error) {
+	var period domain.Period
+	if err := row.Scan(
+		&period.ID,
+		&period.UserID,
+		&period.StartsAt,
+		&period.EndsAt,
+		&period.Reason,
+		&period.ReassignReviews,
+		&period.ReassignedAt,
+		&period.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &period, nil
+}
+
+func (p *PeriodRepository) CreatePeriod(ctx context.Context, period *domain.Period) (*domain.Period, error) {
+	query := `
+		INSERT INTO user_ooo_periods (user_id, starts_at, ends_at, reason, reassign_reviews)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, created_at
+	`
+
+	created, err := scanPeriod(storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query,
+		period.UserID,
+		period.StartsAt,
+		period.EndsAt,
+		period.Reason,
+		period.ReassignReviews,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to create out-of-office period: %w", err)
+	}
+
+	return created, nil
+}
+
+func (p *PeriodRepository) ListPeriods(ctx context.Context, userID string, now time.Time) ([]domain.Period, error) {
+	query := selectPeriod + `
+		WHERE user_id = $1 AND ends_at > $2
+		ORDER BY starts_at, id
+	`
+
+	return p.listPeriods(ctx, query, userID, now)
+}
+
+// DeletePeriod удаляет период и возвращает его, чтобы было видно, шёл ли он в момент удаления.
+func (p *PeriodRepository) DeletePeriod(ctx context.Context, id int64) (*domain.Period, error) {
+	query := `
+		DELETE FROM user_ooo_periods
+		WHERE id = $1
+		RETURNING id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at, created_at
+	`
+
+	deleted, err := scanPeriod(storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to delete out-of-office period: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (p *PeriodRepository) ListDueReassignments(ctx context.Context, now time.Time, limit int) ([]domain.Period, error) {
+	query := selectPeriod + `
+		WHERE reassign_reviews AND reassigned_at IS NULL
+		  AND starts_at <= $1 AND ends_at > $1
+		ORDER BY starts_at, id
+		LIMIT $2
+	`
+
+	return p.listPeriods(ctx, query, now, limit)
+}
+
+func (p *PeriodRepository) MarkReassigned(ctx context.Context, id int64, at time.Time) error {
+	query := `
+		UPDATE user_ooo_periods
+		SET reassigned_at = $2
+		WHERE id = $1
+	`
+
+	if _, err := storage.QuerierFrom(ctx, p.conn).Exec(ctx, query, id, at); err != nil {
+		return fmt.Errorf("db: failed to mark out-of-office period reassigned: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PeriodRepository) ListEndedPeriods(ctx context.Context, now time.Time, limit int) ([]domain.Period, error) {
+	query := selectPeriod + `
+		WHERE ended_announced_at IS NULL AND ends_at <= $1
+		ORDER BY ends_at, id
+		LIMIT $2
+	`
+
+	return p.listPeriods(ctx, query, now, limit)
+}
+
+func (p *PeriodRepository) MarkEndAnnounced(ctx context.Context, id int64, at time.Time) error {
+	query := `
+		UPDATE user_ooo_periods
+		SET ended_announced_at = $2
+		WHERE id = $1
+	`
+
+	if _, err := storage.QuerierFrom(ctx, p.conn).Exec(ctx, query, id, at); err != nil {
+		return fmt.Errorf("db: failed to mark out-of-office period end announced: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PeriodRepository) listPeriods(ctx context.Context, query string, args ...any) ([]domain.Period, error) {
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list out-of-office periods: %w", err)
+	}
+	defer rows.Close()
+
+	periods := make([]domain.Period, 0)
+
+	for rows.Next() {
+		period, err := scanPeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: failed to scan out-of-office period: %w", err)
+		}
+		periods = append(periods, *period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return periods, nil
+}
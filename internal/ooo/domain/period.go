@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxPeriod - ограничение длины одного периода, чтобы опечатка в годе не выключила человека навсегда.
+const maxPeriod = 366 * 24 * time.Hour
+
+// Period - период отсутствия пользователя. В полуинтервале [StartsAt, EndsAt) участник
+// не назначается ревьювером. Если ReassignReviews включён, его OPEN ревью передаются
+// другим участникам, когда период начинается.
+type Period struct {
+	ID              int64      `json:"period_id"`
+	UserID          string     `json:"user_id"`
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	Reason          string     `json:"reason,omitempty"`
+	ReassignReviews bool       `json:"reassign_reviews"`
+	ReassignedAt    *time.Time `json:"reassigned_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Validate проверяет границы периода относительно текущего момента now.
+func (p *Period) Validate(now time.Time) error {
+	if !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", apperr.ErrInvalidOOO)
+	}
+
+	if !p.EndsAt.After(now) {
+		return fmt.Errorf("%w: period is already over", apperr.ErrInvalidOOO)
+	}
+
+	if p.EndsAt.Sub(p.StartsAt) > maxPeriod {
+		return fmt.Errorf("%w: period must not be longer than a year", apperr.ErrInvalidOOO)
+	}
+
+	return nil
+}
+
+// ActiveAt - идёт ли период в момент t.
+func (p *Period) ActiveAt(t time.Time) bool {
+	return !t.Before(p.StartsAt) && t.Before(p.EndsAt)
+}
+
+// ParseStart разбирает начало периода: RFC3339 или дата (начало дня в UTC).
+func ParseStart(value string) (time.Time, error) {
+	return parseBound(value, "starts_at", false)
+}
+
+// ParseEnd разбирает конец периода: RFC3339 или дата. Дата включается в период
+// целиком, то есть "2025-12-31" означает конец 31 декабря.
+func ParseEnd(value string) (time.Time, error) {
+	return parseBound(value, "ends_at", true)
+}
+
+func parseBound(value, field string, inclusiveDate bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be RFC3339 timestamp or YYYY-MM-DD date", apperr.ErrInvalidOOO, field)
+	}
+
+	if inclusiveDate {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
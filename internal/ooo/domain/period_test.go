@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "dates_include_last_day",
+			start:     "2025-12-20",
+			end:       "2025-12-31",
+			wantStart: time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "timestamps_are_taken_as_is",
+			start:     "2025-12-20T09:00:00+03:00",
+			end:       "2025-12-20T18:00:00+03:00",
+			wantStart: time.Date(2025, 12, 20, 6, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2025, 12, 20, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "garbage",
+			start:   "next monday",
+			end:     "2025-12-31",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start, err := ParseStart(tt.start)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, apperr.ErrInvalidOOO))
+				return
+			}
+			require.NoError(t, err)
+
+			end, err := ParseEnd(tt.end)
+			require.NoError(t, err)
+
+			assert.True(t, tt.wantStart.Equal(start), "start: %s", start)
+			assert.True(t, tt.wantEnd.Equal(end), "end: %s", end)
+		})
+	}
+}
+
+func TestPeriod_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		period  Period
+		wantErr bool
+	}{
+		{
+			name:   "future_period",
+			period: Period{StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(72 * time.Hour)},
+		},
+		{
+			name:   "already_started",
+			period: Period{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		},
+		{
+			name:    "ends_before_start",
+			period:  Period{StartsAt: now.Add(time.Hour), EndsAt: now},
+			wantErr: true,
+		},
+		{
+			name:    "already_over",
+			period:  Period{StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-24 * time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "too_long",
+			period:  Period{StartsAt: now, EndsAt: now.AddDate(2, 0, 0)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.period.Validate(now)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, apperr.ErrInvalidOOO))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.period.ActiveAt(tt.period.StartsAt))
+			assert.False(t, tt.period.ActiveAt(tt.period.EndsAt))
+		})
+	}
+}
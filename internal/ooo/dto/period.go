@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+
+type AddPeriodRequest struct {
+	UserID          string `json:"user_id" validate:"required"`
+	StartsAt        string `json:"starts_at" validate:"required"`
+	EndsAt          string `json:"ends_at" validate:"required"`
+	Reason          string `json:"reason" validate:"max=200"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type AddPeriodResponse struct {
+	Period domain.Period `json:"period"`
+}
+
+type ListPeriodsRequest struct {
+	UserID string `query:"user_id" validate:"required"`
+}
+
+type ListPeriodsResponse struct {
+	UserID  string          `json:"user_id"`
+	Periods []domain.Period `json:"periods"`
+}
+
+type DeletePeriodRequest struct {
+	PeriodID int64 `json:"period_id" validate:"required"`
+}
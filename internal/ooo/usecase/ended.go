@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// AnnounceEnded пишет событие user.ooo_ended для закончившихся периодов: по нему синк topup
+// доназначает вернувшегося на свободные места открытых PR его команды. Событие и отметка
+// периода пишутся в одной транзакции, поэтому о каждом периоде сообщается один раз.
+// Возвращает число обработанных периодов.
+func (u *OOOUsecase) AnnounceEnded(ctx context.Context, now time.Time) (int, error) {
+	periods, err := u.periodProvider.ListEndedPeriods(ctx, now, u.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list ended out-of-office periods in provider: %w", err)
+	}
+
+	announced := 0
+	for _, period := range periods {
+		err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := u.appendEnded(ctx, &period, period.EndsAt); err != nil {
+				return err
+			}
+			return u.periodProvider.MarkEndAnnounced(ctx, period.ID, now)
+		})
+		if err != nil {
+			slog.Error("OOOUsecase.AnnounceEnded: failed to announce period end",
+				slog.Int64("period_id", period.ID),
+				slog.String("user_id", period.UserID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		announced++
+	}
+
+	return announced, nil
+}
+
+// appendEnded пишет событие о конце периода. Удалённому пользователю доназначать нечего,
+// поэтому для него событие не пишется.
+func (u *OOOUsecase) appendEnded(ctx context.Context, period *domain.Period, endedAt time.Time) error {
+	user, err := u.userReader.GetUser(ctx, period.UserID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get user from provider: %w", err)
+	}
+
+	event, err := outboxdomain.NewEvent(outboxdomain.EventUserOOOEnded, user.ID, outboxdomain.UserOOOEndedPayload{
+		User:     *user,
+		PeriodID: period.ID,
+		EndedAt:  endedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("build out-of-office ended event: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, event); err != nil {
+		return fmt.Errorf("append out-of-office ended event: %w", err)
+	}
+	return nil
+}
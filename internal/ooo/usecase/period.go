@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/dto"
+)
+
+func (u *OOOUsecase) AddPeriod(ctx context.Context, request *dto.AddPeriodRequest) (*dto.AddPeriodResponse, error) {
+	startsAt, err := domain.ParseStart(request.StartsAt)
+	if err != nil {
+		return nil, err
+	}
+	endsAt, err := domain.ParseEnd(request.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	period := &domain.Period{
+		UserID:          request.UserID,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+		Reason:          request.Reason,
+		ReassignReviews: request.ReassignReviews,
+	}
+
+	if err := period.Validate(time.Now()); err != nil {
+		slog.Info("OOOUsecase.AddPeriod: invalid period",
+			slog.String("user_id", request.UserID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if _, err := u.userReader.GetUser(ctx, request.UserID); err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get user from provider: %w", err)
+	}
+
+	created, err := u.periodProvider.CreatePeriod(ctx, period)
+	if err != nil {
+		slog.Error("OOOUsecase.AddPeriod: provider error",
+			slog.String("user_id", request.UserID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("create out-of-office period in provider: %w", err)
+	}
+
+	slog.Info("OOOUsecase.AddPeriod: period created",
+		slog.Int64("period_id", created.ID),
+		slog.String("user_id", created.UserID),
+		slog.Time("starts_at", created.StartsAt),
+		slog.Time("ends_at", created.EndsAt),
+		slog.Bool("reassign_reviews", created.ReassignReviews),
+	)
+
+	return &dto.AddPeriodResponse{
+		Period: *created,
+	}, nil
+}
+
+func (u *OOOUsecase) ListPeriods(ctx context.Context, request *dto.ListPeriodsRequest) (*dto.ListPeriodsResponse, error) {
+	if _, err := u.userReader.GetUser(ctx, request.UserID); err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get user from provider: %w", err)
+	}
+
+	periods, err := u.periodProvider.ListPeriods(ctx, request.UserID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("list out-of-office periods in provider: %w", err)
+	}
+
+	return &dto.ListPeriodsResponse{
+		UserID:  request.UserID,
+		Periods: periods,
+	}, nil
+}
+
+// DeletePeriod удаляет период. Если период шёл, пользователь возвращается досрочно:
+// в той же транзакции пишется user.ooo_ended, как при обычном окончании периода.
+func (u *OOOUsecase) DeletePeriod(ctx context.Context, request *dto.DeletePeriodRequest) error {
+	now := time.Now()
+
+	var active bool
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		deleted, err := u.periodProvider.DeletePeriod(ctx, request.PeriodID)
+		if err != nil {
+			return err
+		}
+
+		active = deleted.ActiveAt(now)
+		if !active {
+			return nil
+		}
+		return u.appendEnded(ctx, deleted, now)
+	})
+	if err != nil {
+		return fmt.Errorf("delete out-of-office period in provider: %w", err)
+	}
+
+	slog.Info("OOOUsecase.DeletePeriod: period deleted",
+		slog.Int64("period_id", request.PeriodID),
+		slog.Bool("was_active", active),
+	)
+
+	return nil
+}
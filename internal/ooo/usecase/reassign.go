@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+// ReassignResult - итог одного прохода по начавшимся периодам отсутствия.
+type ReassignResult struct {
+	Periods    int
+	Reassigned int
+	Skipped    int
+	Failed     int
+}
+
+// Run периодически переназначает OPEN ревью пользователей, у которых начался период
+// отсутствия, и сообщает о закончившихся периодах, пока не отменён контекст. Проход
+// выполняет только реплика с advisory-блокировкой.
+func (u *OOOUsecase) Run(ctx context.Context) {
+	slog.Info("OOOUsecase.Run: out-of-office reassigner started",
+		slog.Duration("check_interval", u.cfg.CheckInterval),
+	)
+
+	ticker := time.NewTicker(u.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("OOOUsecase.Run: out-of-office reassigner stopped")
+			return
+		case <-ticker.C:
+			var result ReassignResult
+			var ended int
+			leader, err := u.locker.TryRun(ctx, func(ctx context.Context) error {
+				now := time.Now()
+
+				var err error
+				result, err = u.ReassignDue(ctx, now)
+				if err != nil {
+					return err
+				}
+				ended, err = u.AnnounceEnded(ctx, now)
+				return err
+			})
+			if err != nil {
+				slog.Error("OOOUsecase.Run: reassign failed", slog.Any("error", err))
+				continue
+			}
+			if !leader {
+				continue
+			}
+			if result.Periods > 0 {
+				slog.Info("OOOUsecase.Run: reassign finished",
+					slog.Int("periods", result.Periods),
+					slog.Int("reassigned", result.Reassigned),
+					slog.Int("skipped", result.Skipped),
+					slog.Int("failed", result.Failed),
+				)
+			}
+			if ended > 0 {
+				slog.Info("OOOUsecase.Run: ended periods announced",
+					slog.Int("periods", ended),
+				)
+			}
+		}
+	}
+}
+
+// ReassignDue передаёт OPEN ревью отсутствующих другим участникам. Период помечается
+// обработанным, только если все его ревью удалось либо передать, либо осознанно пропустить
+// (нет кандидатов, PR слит или закрыт). После сбоя период будет обработан повторно:
+// уже переданные ревью в выборку не попадут.
+func (u *OOOUsecase) ReassignDue(ctx context.Context, now time.Time) (ReassignResult, error) {
+	var result ReassignResult
+
+	periods, err := u.periodProvider.ListDueReassignments(ctx, now, u.cfg.BatchSize)
+	if err != nil {
+		return result, fmt.Errorf("list due out-of-office periods in provider: %w", err)
+	}
+
+	for _, period := range periods {
+		result.Periods++
+
+		failed, err := u.reassignPeriod(ctx, &period, &result)
+		if err != nil {
+			slog.Error("OOOUsecase.ReassignDue: failed to process period",
+				slog.Int64("period_id", period.ID),
+				slog.String("user_id", period.UserID),
+				slog.Any("error", err),
+			)
+			result.Failed++
+			continue
+		}
+		if failed {
+			continue
+		}
+
+		if err := u.periodProvider.MarkReassigned(ctx, period.ID, now); err != nil {
+			slog.Error("OOOUsecase.ReassignDue: failed to mark period",
+				slog.Int64("period_id", period.ID),
+				slog.Any("error", err),
+			)
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// reassignPeriod возвращает true, если хотя бы одно ревью не удалось передать из-за сбоя.
+func (u *OOOUsecase) reassignPeriod(ctx context.Context, period *domain.Period, result *ReassignResult) (bool, error) {
+	reviews, err := u.reviewReader.GetReview(ctx, period.UserID)
+	if err != nil {
+		return false, fmt.Errorf("get reviews from provider: %w", err)
+	}
+
+	failed := false
+	for _, pr := range *reviews {
+		if pr.Status != prdomain.StatusOpen {
+			continue
+		}
+
+		resp, err := u.reassigner.ReassignPR(ctx, &prdto.ReassignPRRequest{
+			PrID:          pr.ID,
+			OldReviewerId: period.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, apperr.ErrNoCandidate) ||
+				errors.Is(err, apperr.ErrLevelPolicy) ||
+				errors.Is(err, apperr.ErrPairingRule) ||
+				errors.Is(err, apperr.ErrPRMerged) ||
+				errors.Is(err, apperr.ErrPRClosed) ||
+				errors.Is(err, apperr.ErrNotAssigned) ||
+				errors.Is(err, apperr.ErrNotFound) {
+				slog.Info("OOOUsecase.ReassignDue: review left in place",
+					slog.String("pr_id", pr.ID),
+					slog.String("reviewer_id", period.UserID),
+					slog.Any("reason", err),
+				)
+				result.Skipped++
+				continue
+			}
+			slog.Error("OOOUsecase.ReassignDue: failed to reassign review",
+				slog.String("pr_id", pr.ID),
+				slog.String("reviewer_id", period.UserID),
+				slog.Any("error", err),
+			)
+			result.Failed++
+			failed = true
+			continue
+		}
+
+		slog.Info("OOOUsecase.ReassignDue: review reassigned",
+			slog.String("pr_id", pr.ID),
+			slog.String("old_reviewer_id", period.UserID),
+			slog.String("new_reviewer_id", resp.ReplacedBy),
+			slog.Int64("period_id", period.ID),
+		)
+		result.Reassigned++
+	}
+
+	return failed, nil
+}
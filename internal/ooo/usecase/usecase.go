@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type PeriodProvider interface {
+	CreatePeriod(ctx context.Context, period *domain.Period) (*domain.Period, error)
+	// ListPeriods возвращает периоды пользователя, которые ещё не закончились к моменту now.
+	ListPeriods(ctx context.Context, userID string, now time.Time) ([]domain.Period, error)
+	// DeletePeriod удаляет период и возвращает удалённый.
+	DeletePeriod(ctx context.Context, id int64) (*domain.Period, error)
+	// ListDueReassignments возвращает начавшиеся и не закончившиеся периоды,
+	// ревью которых ещё не переназначались.
+	ListDueReassignments(ctx context.Context, now time.Time, limit int) ([]domain.Period, error)
+	MarkReassigned(ctx context.Context, id int64, at time.Time) error
+	// ListEndedPeriods возвращает закончившиеся периоды, о конце которых ещё не сообщено.
+	ListEndedPeriods(ctx context.Context, now time.Time, limit int) ([]domain.Period, error)
+	MarkEndAnnounced(ctx context.Context, id int64, at time.Time) error
+}
+
+type UserReader interface {
+	GetUser(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+type ReviewReader interface {
+	GetReview(ctx context.Context, userId string) (*[]prdomain.PullRequest, error)
+}
+
+type Reassigner interface {
+	ReassignPR(ctx context.Context, request *prdto.ReassignPRRequest) (*prdto.ReassignPRResponse, error)
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker выбирает реплику, которая переназначает ревью отсутствующих.
+type Locker interface {
+	TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+type Config struct {
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+type OOOUsecase struct {
+	periodProvider PeriodProvider
+	userReader     UserReader
+	reviewReader   ReviewReader
+	reassigner     Reassigner
+	eventWriter    EventWriter
+	txManager      Transactor
+	locker         Locker
+	cfg            Config
+}
+
+func NewOOOUsecase(
+	periodProvider PeriodProvider,
+	userReader UserReader,
+	reviewReader ReviewReader,
+	reassigner Reassigner,
+	eventWriter EventWriter,
+	txManager Transactor,
+	locker Locker,
+	cfg Config,
+) *OOOUsecase {
+	return &OOOUsecase{
+		periodProvider: periodProvider,
+		userReader:     userReader,
+		reviewReader:   reviewReader,
+		reassigner:     reassigner,
+		eventWriter:    eventWriter,
+		txManager:      txManager,
+		locker:         locker,
+		cfg:            cfg,
+	}
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/ooo/dto"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOOOUsecase_AddPeriod(t *testing.T) {
+	t.Parallel()
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	nextWeek := time.Now().UTC().AddDate(0, 0, 7).Format("2006-01-02")
+
+	tests := []struct {
+		name       string
+		request    dto.AddPeriodRequest
+		userErr    error
+		wantCreate bool
+		wantErr    error
+	}{
+		{
+			name:       "created",
+			request:    dto.AddPeriodRequest{UserID: "u1", StartsAt: tomorrow, EndsAt: nextWeek, ReassignReviews: true},
+			wantCreate: true,
+		},
+		{
+			name:    "ends_before_start",
+			request: dto.AddPeriodRequest{UserID: "u1", StartsAt: nextWeek, EndsAt: tomorrow},
+			wantErr: apperr.ErrInvalidOOO,
+		},
+		{
+			name:    "unknown_user",
+			request: dto.AddPeriodRequest{UserID: "u404", StartsAt: tomorrow, EndsAt: nextWeek},
+			userErr: apperr.ErrNotFound,
+			wantErr: apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			periodProvider := mocks.NewMockPeriodProvider(ctrl)
+			userReader := mocks.NewMockOOOUserReader(ctrl)
+
+			if !errors.Is(tt.wantErr, apperr.ErrInvalidOOO) {
+				userReader.EXPECT().GetUser(gomock.Any(), tt.request.UserID).
+					Return(&userdomain.User{ID: tt.request.UserID}, tt.userErr)
+			}
+
+			if tt.wantCreate {
+				periodProvider.EXPECT().CreatePeriod(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, period *domain.Period) (*domain.Period, error) {
+						assert.True(t, period.ReassignReviews)
+						assert.Equal(t, 0, period.EndsAt.Hour())
+						assert.Equal(t, 7*24*time.Hour, period.EndsAt.Sub(period.StartsAt))
+						created := *period
+						created.ID = 1
+						return &created, nil
+					})
+			}
+
+			u := &OOOUsecase{
+				periodProvider: periodProvider,
+				userReader:     userReader,
+			}
+
+			resp, err := u.AddPeriod(context.Background(), &tt.request)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), resp.Period.ID)
+		})
+	}
+}
+
+func TestOOOUsecase_ReassignDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 4, 12, 0, 0, 0, time.UTC)
+	period := domain.Period{ID: 7, UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(48 * time.Hour), ReassignReviews: true}
+
+	reviews := []prdomain.PullRequest{
+		{ID: "pr-1", Status: prdomain.StatusOpen, AssignedReviewers: []string{"u1", "u2"}},
+		{ID: "pr-2", Status: prdomain.StatusMerged, AssignedReviewers: []string{"u1"}},
+		{ID: "pr-3", Status: prdomain.StatusOpen, AssignedReviewers: []string{"u1"}},
+	}
+
+	tests := []struct {
+		name       string
+		pr3Err     error
+		wantMark   bool
+		wantResult ReassignResult
+	}{
+		{
+			name:       "all_reassigned",
+			wantMark:   true,
+			wantResult: ReassignResult{Periods: 1, Reassigned: 2},
+		},
+		{
+			name:       "no_candidate_is_skipped",
+			pr3Err:     apperr.ErrNoCandidate,
+			wantMark:   true,
+			wantResult: ReassignResult{Periods: 1, Reassigned: 1, Skipped: 1},
+		},
+		{
+			name:       "failure_keeps_period_pending",
+			pr3Err:     errors.New("db down"),
+			wantResult: ReassignResult{Periods: 1, Reassigned: 1, Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			periodProvider := mocks.NewMockPeriodProvider(ctrl)
+			reviewReader := mocks.NewMockReviewReader(ctrl)
+			reassigner := mocks.NewMockOOOReassigner(ctrl)
+
+			periodProvider.EXPECT().ListDueReassignments(gomock.Any(), now, 10).
+				Return([]domain.Period{period}, nil)
+
+			prs := reviews
+			reviewReader.EXPECT().GetReview(gomock.Any(), "u1").Return(&prs, nil)
+
+			reassigner.EXPECT().
+				ReassignPR(gomock.Any(), &prdto.ReassignPRRequest{PrID: "pr-1", OldReviewerId: "u1"}).
+				Return(&prdto.ReassignPRResponse{ReplacedBy: "u3"}, nil)
+
+			if tt.pr3Err != nil {
+				reassigner.EXPECT().
+					ReassignPR(gomock.Any(), &prdto.ReassignPRRequest{PrID: "pr-3", OldReviewerId: "u1"}).
+					Return(nil, tt.pr3Err)
+			} else {
+				reassigner.EXPECT().
+					ReassignPR(gomock.Any(), &prdto.ReassignPRRequest{PrID: "pr-3", OldReviewerId: "u1"}).
+					Return(&prdto.ReassignPRResponse{ReplacedBy: "u4"}, nil)
+			}
+
+			if tt.wantMark {
+				periodProvider.EXPECT().MarkReassigned(gomock.Any(), int64(7), now).Return(nil)
+			}
+
+			u := &OOOUsecase{
+				periodProvider: periodProvider,
+				reviewReader:   reviewReader,
+				reassigner:     reassigner,
+				cfg:            Config{BatchSize: 10},
+			}
+
+			result, err := u.ReassignDue(context.Background(), now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
+
+func TestOOOUsecase_AnnounceEnded(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 18, 12, 0, 0, 0, time.UTC)
+	returned := domain.Period{ID: 1, UserID: "u1", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-time.Hour)}
+	deleted := domain.Period{ID: 2, UserID: "u404", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-time.Hour)}
+	failing := domain.Period{ID: 3, UserID: "u3", StartsAt: now.Add(-48 * time.Hour), EndsAt: now.Add(-time.Minute)}
+
+	ctrl := gomock.NewController(t)
+	periodProvider := mocks.NewMockPeriodProvider(ctrl)
+	userReader := mocks.NewMockOOOUserReader(ctrl)
+	eventWriter := mocks.NewMockOOOEventWriter(ctrl)
+
+	periodProvider.EXPECT().ListEndedPeriods(gomock.Any(), now, 10).
+		Return([]domain.Period{returned, deleted, failing}, nil)
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, outboxdomain.EventUserOOOEnded, events[0].Type)
+
+			var payload outboxdomain.UserOOOEndedPayload
+			require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+			assert.Equal(t, "backend", payload.User.TeamName)
+			assert.Equal(t, int64(1), payload.PeriodID)
+			assert.True(t, returned.EndsAt.Equal(payload.EndedAt))
+			return nil
+		})
+	periodProvider.EXPECT().MarkEndAnnounced(gomock.Any(), int64(1), now).Return(nil)
+
+	// удалённому пользователю событие не пишется, но период отмечается
+	userReader.EXPECT().GetUser(gomock.Any(), "u404").Return(nil, apperr.ErrNotFound)
+	periodProvider.EXPECT().MarkEndAnnounced(gomock.Any(), int64(2), now).Return(nil)
+
+	// сбой оставляет период для следующего прохода
+	userReader.EXPECT().GetUser(gomock.Any(), "u3").Return(nil, errors.New("db down"))
+
+	u := &OOOUsecase{
+		periodProvider: periodProvider,
+		userReader:     userReader,
+		eventWriter:    eventWriter,
+		txManager:      testutils.InlineTx{},
+		cfg:            Config{BatchSize: 10},
+	}
+
+	announced, err := u.AnnounceEnded(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, announced)
+}
+
+func TestOOOUsecase_DeletePeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		period    *domain.Period
+		stubErr   error
+		wantEvent bool
+		wantErr   error
+	}{
+		{
+			name:      "active_period_announces_return",
+			period:    &domain.Period{ID: 1, UserID: "u1", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+			wantEvent: true,
+		},
+		{
+			name:   "future_period_deleted_silently",
+			period: &domain.Period{ID: 1, UserID: "u1", StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		},
+		{
+			name:    "not_found",
+			stubErr: apperr.ErrNotFound,
+			wantErr: apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			periodProvider := mocks.NewMockPeriodProvider(ctrl)
+			userReader := mocks.NewMockOOOUserReader(ctrl)
+			eventWriter := mocks.NewMockOOOEventWriter(ctrl)
+
+			periodProvider.EXPECT().DeletePeriod(gomock.Any(), int64(1)).Return(tt.period, tt.stubErr)
+
+			if tt.wantEvent {
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventUserOOOEnded, events[0].Type)
+						return nil
+					})
+			}
+
+			u := &OOOUsecase{
+				periodProvider: periodProvider,
+				userReader:     userReader,
+				eventWriter:    eventWriter,
+				txManager:      testutils.InlineTx{},
+			}
+
+			err := u.DeletePeriod(context.Background(), &dto.DeletePeriodRequest{PeriodID: 1})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// Sink пишет события outbox в лог. Полезен как синк по умолчанию и для отладки.
+type Sink struct{}
+
+func NewSink() *Sink {
+	return &Sink{}
+}
+
+func (s *Sink) Name() string {
+	return "log"
+}
+
+func (s *Sink) Handle(_ context.Context, event domain.Event) error {
+	slog.Info("outbox event",
+		slog.Int64("event_id", event.ID),
+		slog.String("event_type", string(event.Type)),
+		slog.String("aggregate_id", event.AggregateID),
+		slog.String("payload", string(event.Payload)),
+	)
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+type OutboxRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewOutboxRepository(conn *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{conn: conn}
+}
+
+// Append пишет события в outbox. Вызывается внутри транзакции usecase'а,
+// поэтому событие появляется только вместе с изменением состояния.
+func (o *OutboxRepository) Append(ctx context.Context, events ...domain.Event) error {
+	query := `
+		INSERT INTO outbox_events (event_type, aggregate_id, payload)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, event := range events {
+		if _, err := storage.QuerierFrom(ctx, o.conn).Exec(
+			ctx,
+			query,
+			event.Type,
+			event.AggregateID,
+			event.Payload,
+		); err != nil {
+			return fmt.Errorf("db: failed to append outbox event %s: %w", event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// Claim забирает пачку готовых к отправке событий и продлевает им next_attempt_at на lease,
+// чтобы другой экземпляр сервиса не взял их одновременно.
+func (o *OutboxRepository) Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]domain.Event, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE outbox_events
+			SET next_attempt_at = $2
+			WHERE id IN (
+				SELECT id
+				FROM outbox_events
+				WHERE sent_at IS NULL
+				  AND failed_at IS NULL
+				  AND next_attempt_at <= $1
+				ORDER BY id
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, event_type, aggregate_id, payload, attempts, delivered_to,
+			          last_error, created_at, next_attempt_at
+		)
+		SELECT id, event_type, aggregate_id, payload, attempts, delivered_to,
+		       COALESCE(last_error, ''), created_at, next_attempt_at
+		FROM claimed
+		ORDER BY id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, o.conn).Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.Event, 0, limit)
+
+	for rows.Next() {
+		var event domain.Event
+		if err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&event.Payload,
+			&event.Attempts,
+			&event.DeliveredTo,
+			&event.LastError,
+			&event.CreatedAt,
+			&event.NextAttemptAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan outbox event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// Save сохраняет результат попытки доставки.
+func (o *OutboxRepository) Save(ctx context.Context, event *domain.Event) error {
+	query := `
+		UPDATE outbox_events
+		SET attempts = $2,
+		    delivered_to = $3,
+		    last_error = NULLIF($4, ''),
+		    next_attempt_at = $5,
+		    sent_at = $6,
+		    failed_at = $7
+		WHERE id = $1
+	`
+
+	deliveredTo := event.DeliveredTo
+	if deliveredTo == nil {
+		deliveredTo = []string{}
+	}
+
+	if _, err := storage.QuerierFrom(ctx, o.conn).Exec(
+		ctx,
+		query,
+		event.ID,
+		event.Attempts,
+		deliveredTo,
+		event.LastError,
+		event.NextAttemptAt,
+		event.SentAt,
+		event.FailedAt,
+	); err != nil {
+		return fmt.Errorf("db: failed to save outbox event %d: %w", event.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventPRCreated            EventType = "pr.created"
+	EventPRReviewerAssigned   EventType = "pr.reviewer_assigned"
+	EventPRReviewerUnassigned EventType = "pr.reviewer_unassigned"
+	EventPRMerged             EventType = "pr.merged"
+	EventPRReviewReminder     EventType = "pr.review_reminder"
+	EventPRStale              EventType = "pr.stale"
+	EventPRClosed             EventType = "pr.closed"
+	EventUserActivityChanged  EventType = "user.activity_changed"
+	EventTeamMembersChanged   EventType = "team.members_changed"
+	EventPoolMembersChanged   EventType = "pool.members_changed"
+	EventUserOOOEnded         EventType = "user.ooo_ended"
+)
+
+// EventTypes - все типы событий, которые публикует сервис.
+var EventTypes = []EventType{
+	EventPRCreated,
+	EventPRReviewerAssigned,
+	EventPRReviewerUnassigned,
+	EventPRMerged,
+	EventPRReviewReminder,
+	EventPRStale,
+	EventPRClosed,
+	EventUserActivityChanged,
+	EventTeamMembersChanged,
+	EventPoolMembersChanged,
+	EventUserOOOEnded,
+}
+
+func (t EventType) Valid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event - запись outbox, которая пишется в одной транзакции с изменением состояния
+// и доставляется в синки фоновым диспетчером.
+type Event struct {
+	ID            int64
+	Type          EventType
+	AggregateID   string
+	Payload       json.RawMessage
+	Attempts      int
+	DeliveredTo   []string
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	FailedAt      *time.Time
+}
+
+func NewEvent(eventType EventType, aggregateID string, payload any) (Event, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+
+	return Event{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     raw,
+	}, nil
+}
+
+func (e *Event) DeliveredToSink(sink string) bool {
+	return slices.Contains(e.DeliveredTo, sink)
+}
+
+func (e *Event) MarkDelivered(sink string) {
+	if e.DeliveredToSink(sink) {
+		return
+	}
+	e.DeliveredTo = append(e.DeliveredTo, sink)
+}
+
+func (e *Event) MarkSent(now time.Time) {
+	e.SentAt = &now
+	e.LastError = ""
+}
+
+// ScheduleRetry увеличивает счётчик попыток и назначает следующую с экспоненциальной задержкой.
+// После maxAttempts событие помечается как окончательно не доставленное.
+func (e *Event) ScheduleRetry(now time.Time, cause error, policy RetryPolicy) {
+	e.Attempts++
+	e.LastError = cause.Error()
+
+	if e.Attempts >= policy.MaxAttempts {
+		e.FailedAt = &now
+		return
+	}
+
+	e.NextAttemptAt = now.Add(policy.Backoff(e.Attempts))
+}
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff возвращает задержку перед попыткой номер attempt+1: base * 2^(attempt-1), но не больше MaxDelay.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
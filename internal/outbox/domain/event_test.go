@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		MaxAttempts: 10,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Second,
+	}
+
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{name: "zero_attempt_uses_base", attempt: 0, want: time.Second},
+		{name: "first_attempt", attempt: 1, want: time.Second},
+		{name: "second_attempt_doubles", attempt: 2, want: 2 * time.Second},
+		{name: "fourth_attempt", attempt: 4, want: 8 * time.Second},
+		{name: "capped_by_max_delay", attempt: 5, want: 10 * time.Second},
+		{name: "large_attempt_capped", attempt: 100, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, policy.Backoff(tt.attempt))
+		})
+	}
+}
+
+func TestEvent_ScheduleRetry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 11, 25, 12, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Minute}
+
+	e := &Event{Type: EventPRMerged}
+
+	e.ScheduleRetry(now, errors.New("sink down"), policy)
+	assert.Equal(t, 1, e.Attempts)
+	assert.Equal(t, "sink down", e.LastError)
+	assert.Equal(t, now.Add(time.Second), e.NextAttemptAt)
+	assert.Nil(t, e.FailedAt)
+
+	e.ScheduleRetry(now, errors.New("sink still down"), policy)
+	assert.Equal(t, 2, e.Attempts)
+	require.NotNil(t, e.FailedAt)
+	assert.Equal(t, now, *e.FailedAt)
+}
+
+func TestEvent_MarkDelivered(t *testing.T) {
+	t.Parallel()
+
+	e := &Event{}
+	e.MarkDelivered("log")
+	e.MarkDelivered("log")
+	e.MarkDelivered("webhook")
+
+	assert.Equal(t, []string{"log", "webhook"}, e.DeliveredTo)
+	assert.True(t, e.DeliveredToSink("webhook"))
+	assert.False(t, e.DeliveredToSink("email"))
+}
+
+func TestPRCreatedEvents(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{
+		ID:                "pr-1",
+		Name:              "Add search",
+		AuthorId:          "u1",
+		Status:            prdomain.StatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+
+	events, err := PRCreatedEvents(pr)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, EventPRCreated, events[0].Type)
+	assert.Equal(t, "pr-1", events[0].AggregateID)
+
+	for i, reviewerID := range pr.AssignedReviewers {
+		event := events[i+1]
+		assert.Equal(t, EventPRReviewerAssigned, event.Type)
+
+		var payload ReviewerAssignedPayload
+		require.NoError(t, json.Unmarshal(event.Payload, &payload))
+		assert.Equal(t, reviewerID, payload.ReviewerID)
+		assert.Equal(t, "pr-1", payload.PullRequest.ID)
+		assert.Empty(t, payload.ReplacedReviewerID)
+	}
+}
+
+func TestReviewersReplacedEvents(t *testing.T) {
+	t.Parallel()
+
+	pr := prdomain.PullRequest{ID: "pr-1", AuthorId: "u1", Status: prdomain.StatusOpen}
+
+	type want struct {
+		eventType  EventType
+		reviewerID string
+		replacedID string
+	}
+
+	tests := []struct {
+		name    string
+		added   []string
+		removed []string
+		want    []want
+	}{
+		{
+			name:    "replaced_one_to_one",
+			added:   []string{"u4"},
+			removed: []string{"u2"},
+			want:    []want{{EventPRReviewerAssigned, "u4", "u2"}},
+		},
+		{
+			name:    "extra_added",
+			added:   []string{"u4", "u5"},
+			removed: []string{"u2"},
+			want:    []want{{EventPRReviewerAssigned, "u4", "u2"}, {EventPRReviewerAssigned, "u5", ""}},
+		},
+		{
+			name:    "extra_removed",
+			removed: []string{"u2", "u3"},
+			want:    []want{{EventPRReviewerUnassigned, "u2", ""}, {EventPRReviewerUnassigned, "u3", ""}},
+		},
+		{
+			name: "nothing_changed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			events, err := ReviewersReplacedEvents(pr, tt.added, tt.removed)
+			require.NoError(t, err)
+			require.Len(t, events, len(tt.want))
+
+			for i, w := range tt.want {
+				assert.Equal(t, w.eventType, events[i].Type)
+				assert.Equal(t, "pr-1", events[i].AggregateID)
+
+				var payload ReviewerAssignedPayload
+				require.NoError(t, json.Unmarshal(events[i].Payload, &payload))
+				assert.Equal(t, w.reviewerID, payload.ReviewerID)
+				assert.Equal(t, w.replacedID, payload.ReplacedReviewerID)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type PRPayload struct {
+	PullRequest prdomain.PullRequest `json:"pull_request"`
+}
+
+type ReviewerAssignedPayload struct {
+	PullRequest        prdomain.PullRequest `json:"pull_request"`
+	ReviewerID         string               `json:"reviewer_id"`
+	ReplacedReviewerID string               `json:"replaced_reviewer_id,omitempty"`
+}
+
+// ReviewerUnassignedPayload - ревьювер снят с PR без замены.
+type ReviewerUnassignedPayload struct {
+	PullRequest prdomain.PullRequest `json:"pull_request"`
+	ReviewerID  string               `json:"reviewer_id"`
+}
+
+// ReviewReminderPayload - ревьювер не отреагировал на PR в пределах SLA команды.
+type ReviewReminderPayload struct {
+	PullRequest prdomain.PullRequest `json:"pull_request"`
+	ReviewerID  string               `json:"reviewer_id"`
+	AssignedAt  time.Time            `json:"assigned_at"`
+}
+
+// PRStalePayload - PR помечен заброшенным; CloseAt - когда он будет закрыт, если закрытие включено.
+type PRStalePayload struct {
+	PullRequest prdomain.PullRequest `json:"pull_request"`
+	CloseAt     *time.Time           `json:"close_at,omitempty"`
+}
+
+// PRClosedPayload - заброшенный PR закрыт, FreedReviewers сняты с ревью.
+type PRClosedPayload struct {
+	PullRequest    prdomain.PullRequest `json:"pull_request"`
+	FreedReviewers []string             `json:"freed_reviewers"`
+}
+
+type UserActivityPayload struct {
+	User userdomain.User `json:"user"`
+}
+
+// TeamMembersPayload - состав команды после изменения; участники могли прийти из других команд.
+type TeamMembersPayload struct {
+	Team teamdomain.Team `json:"team"`
+}
+
+// PoolMembersPayload - состав пула ревьюверов после изменения через /pool/set.
+type PoolMembersPayload struct {
+	Pool teamdomain.ReviewerPool `json:"pool"`
+}
+
+// UserOOOEndedPayload - период отсутствия пользователя закончился или удалён, пока шёл.
+type UserOOOEndedPayload struct {
+	User     userdomain.User `json:"user"`
+	PeriodID int64           `json:"period_id"`
+	EndedAt  time.Time       `json:"ended_at"`
+}
+
+// PRCreatedEvents собирает события создания PR: само создание и назначение каждого ревьювера.
+func PRCreatedEvents(pr prdomain.PullRequest) ([]Event, error) {
+	events := make([]Event, 0, 1+len(pr.AssignedReviewers))
+
+	created, err := NewEvent(EventPRCreated, pr.ID, PRPayload{PullRequest: pr})
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, created)
+
+	assigned, err := ReviewersAssignedEvents(pr, pr.AssignedReviewers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(events, assigned...), nil
+}
+
+// ReviewersAssignedEvents собирает по событию назначения на каждого нового ревьювера PR.
+func ReviewersAssignedEvents(pr prdomain.PullRequest, reviewerIDs ...string) ([]Event, error) {
+	events := make([]Event, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		assigned, err := NewEvent(EventPRReviewerAssigned, pr.ID, ReviewerAssignedPayload{
+			PullRequest: pr,
+			ReviewerID:  reviewerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, assigned)
+	}
+
+	return events, nil
+}
+
+// ReviewersUnassignedEvents собирает по событию на каждого ревьювера, снятого с PR без замены.
+func ReviewersUnassignedEvents(pr prdomain.PullRequest, reviewerIDs ...string) ([]Event, error) {
+	events := make([]Event, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		unassigned, err := NewEvent(EventPRReviewerUnassigned, pr.ID, ReviewerUnassignedPayload{
+			PullRequest: pr,
+			ReviewerID:  reviewerID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, unassigned)
+	}
+
+	return events, nil
+}
+
+// ReviewersReplacedEvents собирает события ручной замены списка ревьюверов: новые ревьюверы
+// по порядку занимают места снятых (назначение с replaced_reviewer_id), лишние новые получают
+// обычное назначение, а лишние снятые - событие снятия без замены.
+func ReviewersReplacedEvents(pr prdomain.PullRequest, added, removed []string) ([]Event, error) {
+	replaced := min(len(added), len(removed))
+
+	events := make([]Event, 0, max(len(added), len(removed)))
+	for i := range replaced {
+		assigned, err := NewEvent(EventPRReviewerAssigned, pr.ID, ReviewerAssignedPayload{
+			PullRequest:        pr,
+			ReviewerID:         added[i],
+			ReplacedReviewerID: removed[i],
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, assigned)
+	}
+
+	assigned, err := ReviewersAssignedEvents(pr, added[replaced:]...)
+	if err != nil {
+		return nil, err
+	}
+	unassigned, err := ReviewersUnassignedEvents(pr, removed[replaced:]...)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(events, assigned...), unassigned...), nil
+}
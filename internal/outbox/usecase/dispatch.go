@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// Run опрашивает outbox и доставляет события в синки, пока не отменён контекст.
+func (d *Dispatcher) Run(ctx context.Context) {
+	slog.Info("Dispatcher.Run: outbox dispatcher started",
+		slog.Int("sinks", len(d.sinks)),
+		slog.Duration("poll_interval", d.cfg.PollInterval),
+	)
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Dispatcher.Run: outbox dispatcher stopped")
+			return
+		case <-ticker.C:
+			// выгребаем всё накопившееся, не дожидаясь следующего тика
+			for {
+				n, err := d.DispatchBatch(ctx)
+				if err != nil {
+					slog.Error("Dispatcher.Run: failed to dispatch batch", slog.Any("error", err))
+					break
+				}
+				if n < d.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
+
+// DispatchBatch забирает одну пачку событий и пытается доставить каждое во все синки.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	events, err := d.eventProvider.Claim(ctx, d.cfg.BatchSize, time.Now(), d.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("claim outbox events in provider: %w", err)
+	}
+
+	for i := range events {
+		event := &events[i]
+
+		d.deliver(ctx, event)
+
+		if err := d.eventProvider.Save(ctx, event); err != nil {
+			slog.Error("Dispatcher.DispatchBatch: failed to save event state",
+				slog.Int64("event_id", event.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return len(events), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event *domain.Event) {
+	var errs []error
+
+	for _, sink := range d.sinks {
+		if event.DeliveredToSink(sink.Name()) {
+			continue
+		}
+
+		if err := sink.Handle(ctx, *event); err != nil {
+			slog.Warn("Dispatcher.deliver: sink failed",
+				slog.Int64("event_id", event.ID),
+				slog.String("event_type", string(event.Type)),
+				slog.String("sink", sink.Name()),
+				slog.Int("attempt", event.Attempts+1),
+				slog.Any("error", err),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+
+		event.MarkDelivered(sink.Name())
+	}
+
+	now := time.Now()
+
+	if len(errs) == 0 {
+		event.MarkSent(now)
+		return
+	}
+
+	event.ScheduleRetry(now, errors.Join(errs...), d.cfg.Retry)
+
+	if event.FailedAt != nil {
+		slog.Error("Dispatcher.deliver: event delivery failed permanently",
+			slog.Int64("event_id", event.ID),
+			slog.String("event_type", string(event.Type)),
+			slog.Int("attempts", event.Attempts),
+			slog.String("last_error", event.LastError),
+		)
+	}
+}
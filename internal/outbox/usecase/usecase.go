@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+type EventProvider interface {
+	Claim(ctx context.Context, limit int, now time.Time, lease time.Duration) ([]domain.Event, error)
+	Save(ctx context.Context, event *domain.Event) error
+}
+
+// Sink - получатель событий outbox (лог, вебхуки, уведомления и т.д.).
+// Handle должен быть идемпотентным: при ошибке другого синка событие будет доставлено повторно
+// только в те синки, которые его ещё не приняли.
+type Sink interface {
+	Name() string
+	Handle(ctx context.Context, event domain.Event) error
+}
+
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	Retry        domain.RetryPolicy
+}
+
+type Dispatcher struct {
+	eventProvider EventProvider
+	sinks         []Sink
+	cfg           DispatcherConfig
+}
+
+func NewDispatcher(repo EventProvider, cfg DispatcherConfig, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		eventProvider: repo,
+		sinks:         sinks,
+		cfg:           cfg,
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		Lease:        time.Minute,
+		Retry: domain.RetryPolicy{
+			MaxAttempts: 3,
+			BaseDelay:   time.Second,
+			MaxDelay:    time.Minute,
+		},
+	}
+}
+
+func newSink(ctrl *gomock.Controller, name string) *mocks.MockSink {
+	sink := mocks.NewMockSink(ctrl)
+	sink.EXPECT().Name().Return(name).AnyTimes()
+	return sink
+}
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name          string
+		event         domain.Event
+		logErr        error
+		webhookErr    error
+		skipWebhook   bool
+		wantSent      bool
+		wantFailed    bool
+		wantAttempts  int
+		wantDelivered []string
+	}
+
+	tests := []tc{
+		{
+			name:          "all_sinks_succeed",
+			event:         domain.Event{ID: 1, Type: domain.EventPRMerged},
+			wantSent:      true,
+			wantDelivered: []string{"log", "webhook"},
+		},
+		{
+			name:          "one_sink_fails_retry_scheduled",
+			event:         domain.Event{ID: 2, Type: domain.EventPRCreated},
+			webhookErr:    errors.New("503"),
+			wantAttempts:  1,
+			wantDelivered: []string{"log"},
+		},
+		{
+			name: "already_delivered_sink_skipped",
+			event: domain.Event{
+				ID:          3,
+				Type:        domain.EventPRCreated,
+				Attempts:    1,
+				DeliveredTo: []string{"webhook"},
+			},
+			skipWebhook:   true,
+			wantSent:      true,
+			wantAttempts:  1,
+			wantDelivered: []string{"webhook", "log"},
+		},
+		{
+			name: "last_attempt_marks_failed",
+			event: domain.Event{
+				ID:       4,
+				Type:     domain.EventPRReviewerAssigned,
+				Attempts: 2,
+			},
+			logErr:        errors.New("log down"),
+			webhookErr:    errors.New("webhook down"),
+			wantFailed:    true,
+			wantAttempts:  3,
+			wantDelivered: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventProvider := mocks.NewMockEventProvider(ctrl)
+			logSink := newSink(ctrl, "log")
+			webhookSink := newSink(ctrl, "webhook")
+
+			eventProvider.EXPECT().
+				Claim(gomock.Any(), 10, gomock.Any(), time.Minute).
+				Return([]domain.Event{tt.event}, nil)
+
+			logSink.EXPECT().Handle(gomock.Any(), gomock.Any()).Return(tt.logErr)
+			if !tt.skipWebhook {
+				webhookSink.EXPECT().Handle(gomock.Any(), gomock.Any()).Return(tt.webhookErr)
+			}
+
+			eventProvider.EXPECT().
+				Save(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, event *domain.Event) error {
+					assert.Equal(t, tt.event.ID, event.ID)
+					assert.Equal(t, tt.wantSent, event.SentAt != nil)
+					assert.Equal(t, tt.wantFailed, event.FailedAt != nil)
+					assert.Equal(t, tt.wantAttempts, event.Attempts)
+					assert.Equal(t, tt.wantDelivered, event.DeliveredTo)
+					if !tt.wantSent && !tt.wantFailed {
+						assert.NotEmpty(t, event.LastError)
+						assert.False(t, event.NextAttemptAt.IsZero())
+					}
+					return nil
+				})
+
+			d := NewDispatcher(eventProvider, testDispatcherConfig(), logSink, webhookSink)
+
+			n, err := d.DispatchBatch(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+		})
+	}
+}
+
+func TestDispatcher_DispatchBatch_ClaimError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventProvider := mocks.NewMockEventProvider(ctrl)
+	eventProvider.EXPECT().
+		Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("db error"))
+
+	d := NewDispatcher(eventProvider, testDispatcherConfig())
+
+	n, err := d.DispatchBatch(context.Background())
+	require.Error(t, err)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+const selectRule = `
+	SELECT id, kind, author_id, reviewer_id, reason, expires_at, created_at
+	FROM reviewer_pairing_rules
+`
+
+type RuleRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewRuleRepository(conn *pgxpool.Pool) *RuleRepository {
+	return &RuleRepository{conn: conn}
+}
+
+func scanRule(row pgx.Row) (*domain.Rule, error) {
+	var rule domain.Rule
+	if err := row.Scan(
+		&rule.ID,
+		&rule.Kind,
+		&rule.AuthorID,
+		&rule.ReviewerID,
+		&rule.Reason,
+		&rule.ExpiresAt,
+		&rule.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *RuleRepository) SaveRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	query := `
+		INSERT INTO reviewer_pairing_rules (kind, author_id, reviewer_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (author_id, reviewer_id) DO UPDATE
+		SET kind = EXCLUDED.kind,
+		    reason = EXCLUDED.reason,
+		    expires_at = EXCLUDED.expires_at,
+		    created_at = NOW()
+		RETURNING id, kind, author_id, reviewer_id, reason, expires_at, created_at
+	`
+
+	saved, err := scanRule(storage.QuerierFrom(ctx, r.conn).QueryRow(ctx, query,
+		string(rule.Kind),
+		rule.AuthorID,
+		rule.ReviewerID,
+		rule.Reason,
+		rule.ExpiresAt,
+	))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to save pairing rule: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (r *RuleRepository) ListRules(ctx context.Context, userID string, now time.Time) ([]domain.Rule, error) {
+	query := selectRule + `
+		WHERE (author_id = $1 OR reviewer_id = $1)
+		  AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY id
+	`
+
+	return r.listRules(ctx, query, userID, now)
+}
+
+// ListAuthorRules возвращает действующие на момент now правила для PR автора.
+func (r *RuleRepository) ListAuthorRules(ctx context.Context, authorID string, now time.Time) ([]domain.Rule, error) {
+	query := selectRule + `
+		WHERE author_id = $1
+		  AND (expires_at IS NULL OR expires_at > $2)
+		ORDER BY id
+	`
+
+	return r.listRules(ctx, query, authorID, now)
+}
+
+func (r *RuleRepository) DeleteRule(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM reviewer_pairing_rules
+		WHERE id = $1
+	`
+
+	tag, err := storage.QuerierFrom(ctx, r.conn).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("db: failed to delete pairing rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RuleRepository) listRules(ctx context.Context, query string, args ...any) ([]domain.Rule, error) {
+	rows, err := storage.QuerierFrom(ctx, r.conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list pairing rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]domain.Rule, 0)
+
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: failed to scan pairing rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return rules, nil
+}
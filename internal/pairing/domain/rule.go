@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+// Kind - тип правила пары автор-ревьювер.
+type Kind string
+
+const (
+	// KindExclude - ReviewerID никогда не ревьюит PR автора AuthorID (конфликт интересов).
+	KindExclude Kind = "exclude"
+	// KindRequire - ReviewerID назначается на каждый PR автора AuthorID (наставник новичка).
+	KindRequire Kind = "require"
+)
+
+func (k Kind) Valid() bool {
+	return k == KindExclude || k == KindRequire
+}
+
+// Rule - жёсткое правило выбора ревьюверов для PR автора. На пару автор-ревьювер действует
+// не больше одного правила; ExpiresAt ограничивает срок правила, nil - бессрочно.
+type Rule struct {
+	ID         int64      `json:"rule_id"`
+	Kind       Kind       `json:"kind"`
+	AuthorID   string     `json:"author_id"`
+	ReviewerID string     `json:"reviewer_id"`
+	Reason     string     `json:"reason,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Validate проверяет правило относительно текущего момента now.
+func (r *Rule) Validate(now time.Time) error {
+	if !r.Kind.Valid() {
+		return fmt.Errorf("%w: unknown kind %q", apperr.ErrInvalidPairingRule, r.Kind)
+	}
+
+	if r.AuthorID == r.ReviewerID {
+		return fmt.Errorf("%w: author and reviewer must differ", apperr.ErrInvalidPairingRule)
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: expires_at is in the past", apperr.ErrInvalidPairingRule)
+	}
+
+	return nil
+}
+
+// ActiveAt - действует ли правило в момент t.
+func (r *Rule) ActiveAt(t time.Time) bool {
+	return r.ExpiresAt == nil || t.Before(*r.ExpiresAt)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.AddDate(0, 3, 0)
+
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr error
+	}{
+		{
+			name: "exclude",
+			rule: Rule{Kind: KindExclude, AuthorID: "u1", ReviewerID: "u2"},
+		},
+		{
+			name: "require_with_expiry",
+			rule: Rule{Kind: KindRequire, AuthorID: "u1", ReviewerID: "u2", ExpiresAt: &future},
+		},
+		{
+			name:    "unknown_kind",
+			rule:    Rule{Kind: "prefer", AuthorID: "u1", ReviewerID: "u2"},
+			wantErr: apperr.ErrInvalidPairingRule,
+		},
+		{
+			name:    "self",
+			rule:    Rule{Kind: KindExclude, AuthorID: "u1", ReviewerID: "u1"},
+			wantErr: apperr.ErrInvalidPairingRule,
+		},
+		{
+			name:    "expired",
+			rule:    Rule{Kind: KindRequire, AuthorID: "u1", ReviewerID: "u2", ExpiresAt: &past},
+			wantErr: apperr.ErrInvalidPairingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.rule.Validate(now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRule_ActiveAt(t *testing.T) {
+	t.Parallel()
+
+	expires := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rule := Rule{Kind: KindRequire, ExpiresAt: &expires}
+
+	assert.True(t, rule.ActiveAt(expires.Add(-time.Second)))
+	assert.False(t, rule.ActiveAt(expires))
+	assert.True(t, (&Rule{Kind: KindExclude}).ActiveAt(expires))
+}
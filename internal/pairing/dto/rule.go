@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+)
+
+// AddRuleRequest - правило заменяет прежнее правило той же пары автор-ревьювер.
+type AddRuleRequest struct {
+	Kind       string     `json:"kind" validate:"required,oneof=exclude require"`
+	AuthorID   string     `json:"author_id" validate:"required"`
+	ReviewerID string     `json:"reviewer_id" validate:"required"`
+	Reason     string     `json:"reason" validate:"max=200"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type AddRuleResponse struct {
+	Rule domain.Rule `json:"rule"`
+}
+
+// ListRulesRequest - правила, где пользователь автор или ревьювер.
+type ListRulesRequest struct {
+	UserID string `query:"user_id" validate:"required"`
+}
+
+type ListRulesResponse struct {
+	UserID string        `json:"user_id"`
+	Rules  []domain.Rule `json:"rules"`
+}
+
+type DeleteRuleRequest struct {
+	RuleID int64 `json:"rule_id" validate:"required"`
+}
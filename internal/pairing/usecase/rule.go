@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/dto"
+)
+
+func (u *PairingUsecase) AddRule(ctx context.Context, request *dto.AddRuleRequest) (*dto.AddRuleResponse, error) {
+	rule := &domain.Rule{
+		Kind:       domain.Kind(request.Kind),
+		AuthorID:   request.AuthorID,
+		ReviewerID: request.ReviewerID,
+		Reason:     request.Reason,
+		ExpiresAt:  request.ExpiresAt,
+	}
+
+	if err := rule.Validate(time.Now()); err != nil {
+		slog.Info("PairingUsecase.AddRule: invalid rule",
+			slog.String("author_id", request.AuthorID),
+			slog.String("reviewer_id", request.ReviewerID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	saved, err := u.ruleProvider.SaveRule(ctx, rule)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		slog.Error("PairingUsecase.AddRule: provider error",
+			slog.String("author_id", request.AuthorID),
+			slog.String("reviewer_id", request.ReviewerID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("save pairing rule in provider: %w", err)
+	}
+
+	slog.Info("PairingUsecase.AddRule: rule saved",
+		slog.Int64("rule_id", saved.ID),
+		slog.String("kind", string(saved.Kind)),
+		slog.String("author_id", saved.AuthorID),
+		slog.String("reviewer_id", saved.ReviewerID),
+	)
+
+	return &dto.AddRuleResponse{
+		Rule: *saved,
+	}, nil
+}
+
+func (u *PairingUsecase) ListRules(ctx context.Context, request *dto.ListRulesRequest) (*dto.ListRulesResponse, error) {
+	if _, err := u.userReader.GetUser(ctx, request.UserID); err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get user from provider: %w", err)
+	}
+
+	rules, err := u.ruleProvider.ListRules(ctx, request.UserID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("list pairing rules in provider: %w", err)
+	}
+
+	return &dto.ListRulesResponse{
+		UserID: request.UserID,
+		Rules:  rules,
+	}, nil
+}
+
+func (u *PairingUsecase) DeleteRule(ctx context.Context, request *dto.DeleteRuleRequest) error {
+	if err := u.ruleProvider.DeleteRule(ctx, request.RuleID); err != nil {
+		if err == apperr.ErrNotFound {
+			return apperr.ErrNotFound
+		}
+		return fmt.Errorf("delete pairing rule in provider: %w", err)
+	}
+
+	slog.Info("PairingUsecase.DeleteRule: rule deleted",
+		slog.Int64("rule_id", request.RuleID),
+	)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type RuleProvider interface {
+	// SaveRule создаёт правило или заменяет правило той же пары автор-ревьювер.
+	SaveRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error)
+	// ListRules возвращает действующие на момент now правила, где пользователь автор или ревьювер.
+	ListRules(ctx context.Context, userID string, now time.Time) ([]domain.Rule, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
+
+type UserReader interface {
+	GetUser(ctx context.Context, id string) (*userdomain.User, error)
+}
+
+type PairingUsecase struct {
+	ruleProvider RuleProvider
+	userReader   UserReader
+}
+
+func NewPairingUsecase(ruleProvider RuleProvider, userReader UserReader) *PairingUsecase {
+	return &PairingUsecase{
+		ruleProvider: ruleProvider,
+		userReader:   userReader,
+	}
+}
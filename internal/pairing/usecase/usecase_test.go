@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pairing/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPairingUsecase_AddRule(t *testing.T) {
+	t.Parallel()
+
+	threeMonths := time.Now().AddDate(0, 3, 0)
+
+	tests := []struct {
+		name     string
+		request  dto.AddRuleRequest
+		saveErr  error
+		wantSave bool
+		wantErr  error
+	}{
+		{
+			name:     "mentor",
+			request:  dto.AddRuleRequest{Kind: "require", AuthorID: "u1", ReviewerID: "u2", ExpiresAt: &threeMonths},
+			wantSave: true,
+		},
+		{
+			name:    "self",
+			request: dto.AddRuleRequest{Kind: "exclude", AuthorID: "u1", ReviewerID: "u1"},
+			wantErr: apperr.ErrInvalidPairingRule,
+		},
+		{
+			name:     "unknown_user",
+			request:  dto.AddRuleRequest{Kind: "exclude", AuthorID: "u1", ReviewerID: "u404"},
+			saveErr:  apperr.ErrNotFound,
+			wantSave: true,
+			wantErr:  apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			ruleProvider := mocks.NewMockRuleProvider(ctrl)
+
+			if tt.wantSave {
+				ruleProvider.EXPECT().SaveRule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, rule *domain.Rule) (*domain.Rule, error) {
+						if tt.saveErr != nil {
+							return nil, tt.saveErr
+						}
+						assert.Equal(t, domain.KindRequire, rule.Kind)
+						saved := *rule
+						saved.ID = 1
+						return &saved, nil
+					})
+			}
+
+			u := &PairingUsecase{ruleProvider: ruleProvider}
+
+			resp, err := u.AddRule(context.Background(), &tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), resp.Rule.ID)
+		})
+	}
+}
+
+func TestPairingUsecase_ListRules(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	ruleProvider := mocks.NewMockRuleProvider(ctrl)
+	userReader := mocks.NewMockPairingUserReader(ctrl)
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").Return(&userdomain.User{ID: "u1"}, nil)
+	ruleProvider.EXPECT().ListRules(gomock.Any(), "u1", gomock.Any()).Return([]domain.Rule{
+		{ID: 1, Kind: domain.KindExclude, AuthorID: "u1", ReviewerID: "u2"},
+	}, nil)
+
+	u := &PairingUsecase{ruleProvider: ruleProvider, userReader: userReader}
+
+	resp, err := u.ListRules(context.Background(), &dto.ListRulesRequest{UserID: "u1"})
+	require.NoError(t, err)
+	assert.Len(t, resp.Rules, 1)
+}
+
+func TestPairingUsecase_DeleteRule(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	ruleProvider := mocks.NewMockRuleProvider(ctrl)
+	ruleProvider.EXPECT().DeleteRule(gomock.Any(), int64(7)).Return(apperr.ErrNotFound)
+	ruleProvider.EXPECT().DeleteRule(gomock.Any(), int64(8)).Return(errors.New("db down"))
+
+	u := &PairingUsecase{ruleProvider: ruleProvider}
+
+	err := u.DeleteRule(context.Background(), &dto.DeleteRuleRequest{RuleID: 7})
+	assert.ErrorIs(t, err, apperr.ErrNotFound)
+
+	err = u.DeleteRule(context.Background(), &dto.DeleteRuleRequest{RuleID: 8})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, apperr.ErrNotFound)
+}
@@ -2,16 +2,41 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
 )
 
+// selectPR читает PR вместе с ревьюверами в порядке назначения слотов.
+const selectPR = `
+	SELECT p.id, p.name, p.author_id, p.status,
+	       ARRAY(
+	           SELECT r.reviewer_id FROM pull_request_reviewers r
+	           WHERE r.pull_request_id = p.id
+	           ORDER BY r.position
+	       ),
+	       p.created_at, p.merged_at, p.stale_at, p.closed_at, p.tags,
+	       ARRAY(
+	           SELECT COALESCE(r.pool_name, '') FROM pull_request_reviewers r
+	           WHERE r.pull_request_id = p.id
+	           ORDER BY r.position
+	       ),
+	       ARRAY(
+	           SELECT COALESCE(r.source, '') FROM pull_request_reviewers r
+	           WHERE r.pull_request_id = p.id
+	           ORDER BY r.position
+	       )
+	FROM pull_requests p
+`
+
 type PRRepository struct {
 	conn *pgxpool.Pool
 }
@@ -20,139 +45,237 @@ func NewPRRepository(conn *pgxpool.Pool) *PRRepository {
 	return &PRRepository{conn: conn}
 }
 
-func (p *PRRepository) GetPR(ctx context.Context, id string) (*domain.PullRequest, error) {
-	query := `
-		SELECT id, name, author_id, status, reviewer1_id, reviewer2_id, created_at, merged_at
-		FROM pull_requests
-		WHERE id = $1
-	`
-
+func scanPR(row pgx.Row) (*domain.PullRequest, error) {
 	var pr domain.PullRequest
-	var reviewer1, reviewer2 *string
-
-	if err := p.conn.QueryRow(ctx, query, id).Scan(
+	var pools, sources []string
+	if err := row.Scan(
 		&pr.ID,
 		&pr.Name,
 		&pr.AuthorId,
 		&pr.Status,
-		&reviewer1,
-		&reviewer2,
+		&pr.AssignedReviewers,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.StaleAt,
+		&pr.ClosedAt,
+		&pr.Tags,
+		&pools,
+		&sources,
 	); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperr.ErrNotFound
-		}
-		return nil, fmt.Errorf("db: failed to get pull request: %w", err)
+		return nil, err
+	}
+
+	if pr.AssignedReviewers == nil {
+		pr.AssignedReviewers = make([]string, 0)
 	}
 
-	pr.AssignedReviewers = make([]string, 0, 2)
-	if reviewer1 != nil {
-		pr.AssignedReviewers = append(pr.AssignedReviewers, *reviewer1)
+	for i, pool := range pools {
+		if pool == "" || i >= len(pr.AssignedReviewers) {
+			continue
+		}
+		if pr.ReviewerPools == nil {
+			pr.ReviewerPools = make(map[string]string)
+		}
+		pr.ReviewerPools[pr.AssignedReviewers[i]] = pool
 	}
-	if reviewer2 != nil {
-		pr.AssignedReviewers = append(pr.AssignedReviewers, *reviewer2)
+
+	for i, source := range sources {
+		if source == "" || i >= len(pr.AssignedReviewers) {
+			continue
+		}
+		pr.SetSource(pr.AssignedReviewers[i], domain.ReviewerSource(source))
 	}
 
 	return &pr, nil
 }
 
-func (p *PRRepository) UpdatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
-	var reviewer1, reviewer2 *string
-	if len(pr.AssignedReviewers) > 0 {
-		reviewer1 = &pr.AssignedReviewers[0]
+func (p *PRRepository) GetPR(ctx context.Context, id string) (*domain.PullRequest, error) {
+	query := selectPR + `WHERE p.id = $1`
+
+	pr, err := scanPR(storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to get pull request: %w", err)
 	}
-	if len(pr.AssignedReviewers) > 1 {
-		reviewer2 = &pr.AssignedReviewers[1]
+
+	return pr, nil
+}
+
+// LockPR читает PR с блокировкой строки; вызывается внутри транзакции.
+func (p *PRRepository) LockPR(ctx context.Context, id string) (*domain.PullRequest, error) {
+	query := selectPR + `WHERE p.id = $1 FOR UPDATE OF p`
+
+	pr, err := scanPR(storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to lock pull request: %w", err)
 	}
 
+	return pr, nil
+}
+
+// UpdatePR сохраняет имя и ревьюверов открытого PR: снятые удаляются, новые получают
+// assigned_at = NOW(), у оставшихся время назначения не меняется. Статус и его даты меняют
+// только MergePR, MarkStale и ClosePR, поэтому устаревшая копия не может переоткрыть PR;
+// для слитого или закрытого PR возвращается ErrPRMerged или ErrPRClosed.
+func (p *PRRepository) UpdatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
 	query := `
-		UPDATE pull_requests
-		SET name = $2,
-		    status = $3,
-		    reviewer1_id = $4,
-		    reviewer2_id = $5,
-		    merged_at = $6
-		WHERE id = $1
-		RETURNING id, name, author_id, status, reviewer1_id, reviewer2_id, created_at, merged_at
+		WITH updated AS (
+			UPDATE pull_requests
+			SET name = $2
+			WHERE id = $1 AND status = $3
+			RETURNING id
+		), removed AS (
+			DELETE FROM pull_request_reviewers
+			WHERE pull_request_id IN (SELECT id FROM updated)
+			  AND NOT (reviewer_id = ANY($4::text[]))
+		), upserted AS (
+			INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, position, pool_name, source)
+			SELECT updated.id, r.reviewer_id, r.ord - 1, NULLIF(r.pool_name, ''), NULLIF(r.source, '')
+			FROM updated, unnest($4::text[], $5::text[], $6::text[])
+			    WITH ORDINALITY AS r(reviewer_id, pool_name, source, ord)
+			ON CONFLICT (pull_request_id, reviewer_id) DO UPDATE
+			SET position = EXCLUDED.position,
+			    pool_name = EXCLUDED.pool_name,
+			    source = EXCLUDED.source
+		)
+		SELECT id FROM updated
 	`
 
-	var updated domain.PullRequest
-	var dbReviewer1, dbReviewer2 *string
-
-	if err := p.conn.QueryRow(
+	var id string
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(
 		ctx,
 		query,
 		pr.ID,
 		pr.Name,
-		pr.Status,
-		reviewer1,
-		reviewer2,
-		pr.MergedAt,
-	).Scan(
-		&updated.ID,
-		&updated.Name,
-		&updated.AuthorId,
-		&updated.Status,
-		&dbReviewer1,
-		&dbReviewer2,
-		&updated.CreatedAt,
-		&updated.MergedAt,
-	); err != nil {
+		domain.StatusOpen,
+		reviewersOf(pr),
+		poolsOf(pr),
+		sourcesOf(pr),
+	).Scan(&id); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperr.ErrNotFound
+			return nil, p.notOpen(ctx, pr.ID)
 		}
 		return nil, fmt.Errorf("db: failed to update pull request: %w", err)
 	}
 
-	updated.AssignedReviewers = make([]string, 0, 2)
-	if dbReviewer1 != nil {
-		updated.AssignedReviewers = append(updated.AssignedReviewers, *dbReviewer1)
+	return p.GetPR(ctx, id)
+}
+
+// notOpen объясняет, почему PR не удалось изменить: его нет или он уже не открыт.
+func (p *PRRepository) notOpen(ctx context.Context, id string) error {
+	current, err := p.GetPR(ctx, id)
+	if err != nil {
+		return err
 	}
-	if dbReviewer2 != nil {
-		updated.AssignedReviewers = append(updated.AssignedReviewers, *dbReviewer2)
+	if err := current.CanReassign(); err != nil {
+		return err
 	}
-
-	return &updated, nil
+	return apperr.ErrNotFound
 }
 
-func (p *PRRepository) CreatePR(ctx context.Context, pullRequest *domain.PullRequest) (*domain.PullRequest, error) {
+// MarkStale помечает открытый PR заброшенным.
+func (p *PRRepository) MarkStale(ctx context.Context, id string, at time.Time) (*domain.PullRequest, error) {
 	query := `
-		INSERT INTO pull_requests (id, name, author_id, status, reviewer1_id, reviewer2_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, name, author_id, status, reviewer1_id, reviewer2_id, created_at, merged_at
+		UPDATE pull_requests
+		SET stale_at = $3
+		WHERE id = $1 AND status = $2 AND stale_at IS NULL
+		RETURNING id
 	`
 
-	var reviewer1, reviewer2 *string
-	var createdPR domain.PullRequest
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query, id, domain.StatusOpen, at).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, p.notOpen(ctx, id)
+		}
+		return nil, fmt.Errorf("db: failed to mark pull request as stale: %w", err)
+	}
+
+	return p.GetPR(ctx, id)
+}
 
-	var r1, r2 *string
-	if len(pullRequest.AssignedReviewers) > 0 {
-		r1 = &pullRequest.AssignedReviewers[0]
+// ClosePR закрывает открытый PR и снимает с него всех ревьюверов.
+func (p *PRRepository) ClosePR(ctx context.Context, id string, at time.Time) (*domain.PullRequest, error) {
+	query := `
+		WITH closed AS (
+			UPDATE pull_requests
+			SET status = $3,
+			    closed_at = $4
+			WHERE id = $1 AND status = $2
+			RETURNING id
+		), released AS (
+			DELETE FROM pull_request_reviewers
+			WHERE pull_request_id IN (SELECT id FROM closed)
+		)
+		SELECT id FROM closed
+	`
+
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(
+		ctx, query, id, domain.StatusOpen, domain.StatusClosed, at,
+	).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, p.notOpen(ctx, id)
+		}
+		return nil, fmt.Errorf("db: failed to close pull request: %w", err)
 	}
-	if len(pullRequest.AssignedReviewers) > 1 {
-		r2 = &pullRequest.AssignedReviewers[1]
+
+	return p.GetPR(ctx, id)
+}
+
+// ReopenPR снова открывает закрытый PR и снимает с него пометку о заброшенности.
+func (p *PRRepository) ReopenPR(ctx context.Context, id string) (*domain.PullRequest, error) {
+	query := `
+		UPDATE pull_requests
+		SET status = $3,
+		    closed_at = NULL,
+		    stale_at = NULL
+		WHERE id = $1 AND status = $2
+		RETURNING id
+	`
+
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(
+		ctx, query, id, domain.StatusClosed, domain.StatusOpen,
+	).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to reopen pull request: %w", err)
 	}
 
-	if err := p.conn.QueryRow(
+	return p.GetPR(ctx, id)
+}
+
+func (p *PRRepository) CreatePR(ctx context.Context, pullRequest *domain.PullRequest) (*domain.PullRequest, error) {
+	query := `
+		WITH created AS (
+			INSERT INTO pull_requests (id, name, author_id, status, tags)
+			VALUES ($1, $2, $3, $4, $6)
+			RETURNING id
+		), assigned AS (
+			INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, position, pool_name, source)
+			SELECT created.id, r.reviewer_id, r.ord - 1, NULLIF(r.pool_name, ''), NULLIF(r.source, '')
+			FROM created, unnest($5::text[], $7::text[], $8::text[])
+			    WITH ORDINALITY AS r(reviewer_id, pool_name, source, ord)
+		)
+		SELECT id FROM created
+	`
+
+	var id string
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(
 		ctx,
 		query,
 		pullRequest.ID,
 		pullRequest.Name,
 		pullRequest.AuthorId,
 		domain.StatusOpen,
-		r1,
-		r2,
-	).Scan(
-		&createdPR.ID,
-		&createdPR.Name,
-		&createdPR.AuthorId,
-		&createdPR.Status,
-		&reviewer1,
-		&reviewer2,
-		&createdPR.CreatedAt,
-		&createdPR.MergedAt,
-	); err != nil {
+		reviewersOf(pullRequest),
+		tagsOf(pullRequest),
+		poolsOf(pullRequest),
+		sourcesOf(pullRequest),
+	).Scan(&id); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return nil, apperr.ErrPRExists
@@ -160,31 +283,28 @@ func (p *PRRepository) CreatePR(ctx context.Context, pullRequest *domain.PullReq
 		return nil, fmt.Errorf("db: failed to create pull request: %w", err)
 	}
 
-	createdPR.AssignedReviewers = make([]string, 0, 2)
-	if reviewer1 != nil {
-		createdPR.AssignedReviewers = append(createdPR.AssignedReviewers, *reviewer1)
-	}
-	if reviewer2 != nil {
-		createdPR.AssignedReviewers = append(createdPR.AssignedReviewers, *reviewer2)
-	}
-
-	return &createdPR, nil
+	return p.GetPR(ctx, id)
 }
 
+// MergePR сливает PR, в том числе закрытый: его могли слить во внешней системе после
+// автозакрытия. Повторный merge не меняет merged_at.
 func (p *PRRepository) MergePR(ctx context.Context, id string) (*domain.PullRequest, error) {
-	pr, err := p.GetPR(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	pr.Merge()
+	query := `
+		UPDATE pull_requests
+		SET status = $2,
+		    merged_at = COALESCE(merged_at, NOW())
+		WHERE id = $1
+		RETURNING id
+	`
 
-	updated, err := p.UpdatePR(ctx, pr)
-	if err != nil {
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(ctx, query, id, domain.StatusMerged).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
 		return nil, fmt.Errorf("db: failed to merge pull request: %w", err)
 	}
 
-	return updated, nil
+	return p.GetPR(ctx, id)
 }
 
 func (p *PRRepository) ReassignPR(ctx context.Context, prId string, oldReviewerId string) (*domain.PullRequest, string, error) {
@@ -197,13 +317,13 @@ func (p *PRRepository) ReassignPR(ctx context.Context, prId string, oldReviewerI
 }
 
 func (p *PRRepository) GetReview(ctx context.Context, userId string) (*[]domain.PullRequest, error) {
-	query := `
-		SELECT id, name, author_id, status, reviewer1_id, reviewer2_id, created_at, merged_at
-		FROM pull_requests
-		WHERE reviewer1_id = $1 OR reviewer2_id = $1
+	query := selectPR + `
+		WHERE p.id IN (
+			SELECT pull_request_id FROM pull_request_reviewers WHERE reviewer_id = $1
+		)
 	`
 
-	rows, err := p.conn.Query(ctx, query, userId)
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, userId)
 	if err != nil {
 		return nil, fmt.Errorf("db: failed to get pull requests for review: %w", err)
 	}
@@ -212,36 +332,272 @@ func (p *PRRepository) GetReview(ctx context.Context, userId string) (*[]domain.
 	pullRequests := make([]domain.PullRequest, 0)
 
 	for rows.Next() {
-		var pr domain.PullRequest
-		var reviewer1, reviewer2 *string
-
-		if err := rows.Scan(
-			&pr.ID,
-			&pr.Name,
-			&pr.AuthorId,
-			&pr.Status,
-			&reviewer1,
-			&reviewer2,
-			&pr.CreatedAt,
-			&pr.MergedAt,
-		); err != nil {
+		pr, err := scanPR(rows)
+		if err != nil {
 			return nil, fmt.Errorf("db: failed to scan pull request: %w", err)
 		}
 
-		pr.AssignedReviewers = make([]string, 0, 2)
-		if reviewer1 != nil {
-			pr.AssignedReviewers = append(pr.AssignedReviewers, *reviewer1)
+		pullRequests = append(pullRequests, *pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return &pullRequests, nil
+}
+
+// ListStaleCandidates возвращает OPEN PR, которые пора пометить заброшенными (созданы до staleBefore
+// и ещё не помечены) или закрыть (созданы до closeBefore и помечены до flaggedBefore).
+func (p *PRRepository) ListStaleCandidates(
+	ctx context.Context,
+	staleBefore, closeBefore, flaggedBefore time.Time,
+	limit int,
+) ([]domain.PullRequest, error) {
+	query := selectPR + `
+		WHERE p.status = $1
+		  AND (
+		      (p.stale_at IS NULL AND p.created_at <= $2)
+		      OR (p.stale_at IS NOT NULL AND p.created_at <= $3 AND p.stale_at <= $4)
+		  )
+		ORDER BY p.created_at
+		LIMIT $5
+	`
+
+	return p.listPRs(ctx, query, domain.StatusOpen, staleBefore, closeBefore, flaggedBefore, limit)
+}
+
+// ListOpenCreatedBefore возвращает OPEN PR, созданные не позже before, от самых старых.
+func (p *PRRepository) ListOpenCreatedBefore(ctx context.Context, before time.Time) ([]domain.PullRequest, error) {
+	query := selectPR + `
+		WHERE p.status = $1 AND p.created_at <= $2
+		ORDER BY p.created_at
+	`
+
+	return p.listPRs(ctx, query, domain.StatusOpen, before)
+}
+
+// ListOpenByAuthorTeam возвращает OPEN PR, авторы которых сейчас состоят в команде teamName,
+// от самых старых.
+func (p *PRRepository) ListOpenByAuthorTeam(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	query := selectPR + `
+		JOIN users u ON u.id = p.author_id
+		WHERE p.status = $1 AND u.team_name = $2
+		ORDER BY p.created_at
+	`
+
+	return p.listPRs(ctx, query, domain.StatusOpen, teamName)
+}
+
+// CountOpenReviews возвращает число открытых PR, на которые назначен каждый из reviewerIDs,
+// включая PR других команд и места пулов. Ревьюверы без открытых ревью в результат не попадают.
+func (p *PRRepository) CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	query := `
+		SELECT r.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers r
+		JOIN pull_requests p ON p.id = r.pull_request_id
+		WHERE p.status = $1 AND r.reviewer_id = ANY($2::text[])
+		GROUP BY r.reviewer_id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, domain.StatusOpen, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(reviewerIDs))
+	for rows.Next() {
+		var reviewerID string
+		var count int
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("db: failed to scan open reviews: %w", err)
+		}
+		counts[reviewerID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RecentReviewers возвращает ревьюверов последних limit PR автора, от новых к старым, кроме
+// PR excludeID. Запрос идёт по индексу (author_id, created_at DESC) и не зависит от размера истории.
+func (p *PRRepository) RecentReviewers(ctx context.Context, authorID, excludeID string,
+	limit int) ([][]string, error) {
+
+	query := `
+		SELECT ARRAY(
+		           SELECT r.reviewer_id FROM pull_request_reviewers r
+		           WHERE r.pull_request_id = p.id
+		           ORDER BY r.position
+		       )
+		FROM pull_requests p
+		WHERE p.author_id = $1 AND p.id <> $2
+		ORDER BY p.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, authorID, excludeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to get recent reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([][]string, 0, limit)
+	for rows.Next() {
+		var reviewers []string
+		if err := rows.Scan(&reviewers); err != nil {
+			return nil, fmt.Errorf("db: failed to scan recent reviewers: %w", err)
+		}
+		history = append(history, reviewers)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return history, nil
+}
+
+// SaveDecision сохраняет запись о выборе ревьюверов; входные данные хранятся как JSONB.
+func (p *PRRepository) SaveDecision(ctx context.Context, decision *domain.Decision) error {
+	input, err := json.Marshal(decision.Input)
+	if err != nil {
+		return fmt.Errorf("marshal decision input: %w", err)
+	}
+
+	query := `
+		INSERT INTO assignment_decisions (pull_request_id, kind, seed, input, reviewers, warnings)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	if err := storage.QuerierFrom(ctx, p.conn).QueryRow(
+		ctx,
+		query,
+		decision.PullRequestID,
+		decision.Kind,
+		decision.Seed,
+		input,
+		nonNil(decision.Reviewers),
+		nonNil(decision.Warnings),
+	).Scan(&decision.ID, &decision.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return apperr.ErrNotFound
+		}
+		return fmt.Errorf("db: failed to save assignment decision: %w", err)
+	}
+
+	return nil
+}
+
+// ListDecisions возвращает записи о выборе ревьюверов PR в порядке их появления.
+func (p *PRRepository) ListDecisions(ctx context.Context, prID string) ([]domain.Decision, error) {
+	query := `
+		SELECT id, pull_request_id, kind, seed, input, reviewers, warnings, created_at
+		FROM assignment_decisions
+		WHERE pull_request_id = $1
+		ORDER BY id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list assignment decisions: %w", err)
+	}
+	defer rows.Close()
+
+	decisions := make([]domain.Decision, 0)
+	for rows.Next() {
+		var decision domain.Decision
+		var input []byte
+		if err := rows.Scan(
+			&decision.ID,
+			&decision.PullRequestID,
+			&decision.Kind,
+			&decision.Seed,
+			&input,
+			&decision.Reviewers,
+			&decision.Warnings,
+			&decision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan assignment decision: %w", err)
 		}
-		if reviewer2 != nil {
-			pr.AssignedReviewers = append(pr.AssignedReviewers, *reviewer2)
+		if err := json.Unmarshal(input, &decision.Input); err != nil {
+			return nil, fmt.Errorf("db: failed to decode assignment decision input: %w", err)
 		}
+		decisions = append(decisions, decision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
 
-		pullRequests = append(pullRequests, pr)
+	return decisions, nil
+}
+
+func (p *PRRepository) listPRs(ctx context.Context, query string, args ...any) ([]domain.PullRequest, error) {
+	rows, err := storage.QuerierFrom(ctx, p.conn).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	pullRequests := make([]domain.PullRequest, 0)
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, fmt.Errorf("db: failed to scan pull request: %w", err)
+		}
+		pullRequests = append(pullRequests, *pr)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("db: rows error: %w", err)
 	}
 
-	return &pullRequests, nil
+	return pullRequests, nil
+}
+
+func reviewersOf(pr *domain.PullRequest) []string {
+	if pr.AssignedReviewers == nil {
+		return []string{}
+	}
+	return pr.AssignedReviewers
+}
+
+func tagsOf(pr *domain.PullRequest) []string {
+	if pr.Tags == nil {
+		return []string{}
+	}
+	return pr.Tags
+}
+
+// poolsOf - пулы ревьюверов в порядке AssignedReviewers, пустая строка - своя команда.
+func poolsOf(pr *domain.PullRequest) []string {
+	pools := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		pools = append(pools, pr.ReviewerPools[id])
+	}
+	return pools
+}
+
+// sourcesOf - причины назначения ревьюверов в порядке AssignedReviewers, пустая строка -
+// обычный выбор.
+func sourcesOf(pr *domain.PullRequest) []string {
+	sources := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		sources = append(sources, string(pr.ReviewerSources[id]))
+	}
+	return sources
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// BalanceMove - перенос одного назначения: From снимается с PR, To назначается вместо него.
+type BalanceMove struct {
+	PullRequestID string `json:"pull_request_id"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+}
+
+// MemberLoad - сколько открытых ревью участника команды до и после переносов.
+type MemberLoad struct {
+	UserID string `json:"user_id"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// PlanBalance предлагает переносы назначений между доступными участниками team так, чтобы
+// число открытых ревью у них отличалось не больше чем на один, где это позволяют ограничения.
+// Нагрузка берётся из openReviews - все открытые ревью участника, в том числе на PR других
+// команд и из пулов, - а переносятся только места команды в prs: ревьюверы из пулов и не из
+// team не переносятся. optsOf возвращает ограничения выбора для PR автора (правила пар,
+// требование к грейду). Переносы детерминированы: сначала разгружается самый загруженный
+// участник, назначение получает наименее загруженный, PR перебираются в порядке prs.
+// PR в prs не меняются.
+func PlanBalance(team *teamdomain.Team, prs []PullRequest, openReviews map[string]int,
+	optsOf func(authorID string) SelectOptions) ([]BalanceMove, []MemberLoad) {
+
+	loads := make(map[string]int)
+	members := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		if m.Available() {
+			loads[m.ID] = openReviews[m.ID]
+			members = append(members, m.ID)
+		}
+	}
+
+	work := make([]*PullRequest, 0, len(prs))
+	for i := range prs {
+		work = append(work, ClonePR(&prs[i]))
+	}
+
+	before := make(map[string]int, len(loads))
+	for id, load := range loads {
+		before[id] = load
+	}
+
+	var moves []BalanceMove
+	for {
+		move, ok := nextMove(team, work, members, loads, optsOf)
+		if !ok {
+			break
+		}
+		moves = append(moves, move)
+		loads[move.From]--
+		loads[move.To]++
+	}
+
+	result := make([]MemberLoad, 0, len(members))
+	for _, id := range members {
+		result = append(result, MemberLoad{UserID: id, Before: before[id], After: loads[id]})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+
+	return moves, result
+}
+
+// nextMove ищет один перенос от загруженного участника к менее загруженному (разница
+// нагрузок не меньше двух, иначе перенос ничего не выравнивает) и применяет его к work.
+func nextMove(team *teamdomain.Team, work []*PullRequest, members []string, loads map[string]int,
+	optsOf func(authorID string) SelectOptions) (BalanceMove, bool) {
+
+	byLoad := slices.Clone(members)
+	sort.Slice(byLoad, func(i, j int) bool {
+		if loads[byLoad[i]] != loads[byLoad[j]] {
+			return loads[byLoad[i]] > loads[byLoad[j]]
+		}
+		return byLoad[i] < byLoad[j]
+	})
+
+	for _, from := range byLoad {
+		for i := len(byLoad) - 1; i >= 0; i-- {
+			to := byLoad[i]
+			if loads[from]-loads[to] < 2 {
+				break
+			}
+
+			for _, pr := range work {
+				if !CanMove(team, pr, from, to, optsOf(pr.AuthorId)) {
+					continue
+				}
+				_ = pr.ReplaceReviewer(from, to)
+				return BalanceMove{PullRequestID: pr.ID, From: from, To: to}, true
+			}
+		}
+	}
+
+	return BalanceMove{}, false
+}
+
+// CanMove проверяет, что замену from на to на месте команды открытого PR допустили бы правила
+// выбора: to - доступный участник team, не автор и не назначен, не исключён правилами пар,
+// from - не ревьювер из пула, не владелец кода, не указанный явно и не обязательный наставник,
+// а требование политики к грейду выполняется не хуже, чем до замены.
+func CanMove(team *teamdomain.Team, pr *PullRequest, from, to string, opts SelectOptions) bool {
+	if pr.CanReassign() != nil {
+		return false
+	}
+	if !slices.Contains(pr.AssignedReviewers, from) || pr.ReviewerPools[from] != "" ||
+		pr.ReviewerSources[from] != "" {
+		return false
+	}
+	if to == pr.AuthorId || slices.Contains(pr.AssignedReviewers, to) || slices.Contains(opts.Excluded, to) {
+		return false
+	}
+	member := slices.IndexFunc(team.Members, func(m teamdomain.TeamMember) bool { return m.ID == to })
+	if member < 0 || !team.Members[member].Available() {
+		return false
+	}
+	if CheckRemovable(pr.AuthorId, opts, from) != nil {
+		return false
+	}
+
+	if opts.MinLevelReviewers > 0 {
+		levels := memberLevels(team, opts)
+		after := slices.Clone(pr.AssignedReviewers)
+		after[slices.Index(after, from)] = to
+		if levelShortage(after, levels, opts) > levelShortage(pr.AssignedReviewers, levels, opts) {
+			return false
+		}
+	}
+
+	return true
+}
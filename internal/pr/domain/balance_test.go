@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"testing"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanBalance(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "a", IsActive: true},
+			{ID: "r1", IsActive: true, Level: userdomain.LevelSenior},
+			{ID: "r2", IsActive: true},
+			{ID: "r3", IsActive: true},
+			{ID: "r4", IsActive: false},
+		},
+	}
+	prs := func() []PullRequest {
+		return []PullRequest{
+			{ID: "p1", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+			{ID: "p2", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+			{ID: "p3", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+			{ID: "p4", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1", "r2"}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		prs       []PullRequest
+		opts      SelectOptions
+		elsewhere map[string]int
+		wantMoves []BalanceMove
+		wantLoads map[string]int
+	}{
+		{
+			name: "moves to least loaded",
+			prs:  prs(),
+			wantMoves: []BalanceMove{
+				{PullRequestID: "p1", From: "r1", To: "r3"},
+				{PullRequestID: "p2", From: "r2", To: "r3"},
+			},
+			wantLoads: map[string]int{"a": 0, "r1": 3, "r2": 3, "r3": 2},
+		},
+		{
+			name:      "excluded reviewer gets nothing",
+			prs:       prs(),
+			opts:      SelectOptions{Excluded: []string{"r3"}},
+			wantLoads: map[string]int{"a": 0, "r1": 4, "r2": 4, "r3": 0},
+		},
+		{
+			name: "required mentor stays",
+			prs:  prs(),
+			opts: SelectOptions{Required: []teamdomain.TeamMember{{ID: "r1", IsActive: true}}},
+			wantMoves: []BalanceMove{
+				{PullRequestID: "p1", From: "r2", To: "r3"},
+				{PullRequestID: "p2", From: "r2", To: "r3"},
+			},
+			wantLoads: map[string]int{"a": 0, "r1": 4, "r2": 2, "r3": 2},
+		},
+		{
+			name: "level policy keeps senior",
+			prs:  prs(),
+			opts: SelectOptions{MinLevel: userdomain.LevelSenior, MinLevelReviewers: 1},
+			wantMoves: []BalanceMove{
+				{PullRequestID: "p1", From: "r2", To: "r3"},
+				{PullRequestID: "p2", From: "r2", To: "r3"},
+			},
+			wantLoads: map[string]int{"a": 0, "r1": 4, "r2": 2, "r3": 2},
+		},
+		{
+			name: "pool reviewers are not moved",
+			prs: []PullRequest{
+				{ID: "p1", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerPools: map[string]string{"r1": "security"}},
+				{ID: "p2", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerPools: map[string]string{"r1": "security"}},
+			},
+			wantLoads: map[string]int{"a": 0, "r1": 2, "r2": 0, "r3": 0},
+		},
+		{
+			name: "reviews on other teams count",
+			prs: []PullRequest{
+				{ID: "p1", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"}},
+				{ID: "p2", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"}},
+			},
+			// r3 и так ревьюит три чужих PR, поэтому назначение получает r2
+			elsewhere: map[string]int{"r3": 3},
+			wantMoves: []BalanceMove{{PullRequestID: "p1", From: "r1", To: "r2"}},
+			wantLoads: map[string]int{"a": 0, "r1": 1, "r2": 1, "r3": 3},
+		},
+		{
+			name: "code owners are not moved",
+			prs: []PullRequest{
+				{ID: "p1", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerSources: map[string]ReviewerSource{"r1": SourceOwner}},
+				{ID: "p2", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerSources: map[string]ReviewerSource{"r1": SourceOwner}},
+			},
+			wantLoads: map[string]int{"a": 0, "r1": 2, "r2": 0, "r3": 0},
+		},
+		{
+			name: "requested reviewers are not moved",
+			prs: []PullRequest{
+				{ID: "p1", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerSources: map[string]ReviewerSource{"r1": SourceRequested}},
+				{ID: "p2", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"}},
+				{ID: "p3", AuthorId: "a", Status: StatusOpen, AssignedReviewers: []string{"r1"},
+					ReviewerSources: map[string]ReviewerSource{"r1": SourceRequested}},
+			},
+			// переносится только обычное назначение на p2
+			wantMoves: []BalanceMove{{PullRequestID: "p2", From: "r1", To: "r3"}},
+			wantLoads: map[string]int{"a": 0, "r1": 2, "r2": 0, "r3": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := make([]PullRequest, 0, len(tt.prs))
+			for i := range tt.prs {
+				input = append(input, *ClonePR(&tt.prs[i]))
+			}
+
+			openReviews := make(map[string]int)
+			for _, pr := range tt.prs {
+				for _, id := range pr.AssignedReviewers {
+					openReviews[id]++
+				}
+			}
+			for id, n := range tt.elsewhere {
+				openReviews[id] += n
+			}
+
+			moves, loads := PlanBalance(team, tt.prs, openReviews, func(string) SelectOptions { return tt.opts })
+			assert.Equal(t, tt.wantMoves, moves)
+
+			after := make(map[string]int, len(loads))
+			for _, load := range loads {
+				after[load.UserID] = load.After
+			}
+			assert.Equal(t, tt.wantLoads, after)
+			assert.Equal(t, input, tt.prs, "input PRs must not change")
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// DecisionKind - какой операцией выбраны ревьюверы.
+type DecisionKind string
+
+const (
+	DecisionCreate   DecisionKind = "create"
+	DecisionReassign DecisionKind = "reassign"
+	// DecisionAdd - дополнительные ревьюверы на свободные или новые места PR.
+	DecisionAdd DecisionKind = "add"
+	// DecisionBalance - перенос назначения при выравнивании нагрузки команды.
+	DecisionBalance DecisionKind = "balance"
+)
+
+// DecisionInput - всё, от чего зависит выбор: команда (или пул) с состоянием участников,
+// параметры выбора, для замены - PR до неё и заменяемый ревьювер, для добавления - PR до него
+// и число мест.
+type DecisionInput struct {
+	AuthorID      string          `json:"author_id"`
+	Team          teamdomain.Team `json:"team"`
+	Options       SelectOptions   `json:"options"`
+	PullRequest   *PullRequest    `json:"pull_request,omitempty"`
+	OldReviewerID string          `json:"old_reviewer_id,omitempty"`
+	Slots         int             `json:"slots,omitempty"`
+}
+
+// Decision - запись об одном выборе ревьюверов: зерно генератора, входные данные и результат.
+// По ней Replay повторяет выбор, чтобы его можно было проверить и объяснить.
+type Decision struct {
+	ID            int64         `json:"decision_id"`
+	PullRequestID string        `json:"pull_request_id"`
+	Kind          DecisionKind  `json:"kind"`
+	Seed          int64         `json:"seed"`
+	Input         DecisionInput `json:"input"`
+	Reviewers     []string      `json:"reviewers"`
+	Warnings      []string      `json:"warnings,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// NewCreateDecision записывает выбор ревьюверов при создании PR.
+func NewCreateDecision(prID, authorID string, team *teamdomain.Team, seed int64,
+	opts SelectOptions, selection Selection) *Decision {
+
+	return &Decision{
+		PullRequestID: prID,
+		Kind:          DecisionCreate,
+		Seed:          seed,
+		Input:         DecisionInput{AuthorID: authorID, Team: *team, Options: opts},
+		Reviewers:     selection.Reviewers,
+		Warnings:      selection.Warnings,
+	}
+}
+
+// NewReassignDecision записывает замену ревьювера; before - PR до замены.
+func NewReassignDecision(before *PullRequest, team *teamdomain.Team, oldReviewerID string, seed int64,
+	opts SelectOptions, selection Selection) *Decision {
+
+	return &Decision{
+		PullRequestID: before.ID,
+		Kind:          DecisionReassign,
+		Seed:          seed,
+		Input: DecisionInput{
+			AuthorID:      before.AuthorId,
+			Team:          *team,
+			Options:       opts,
+			PullRequest:   before,
+			OldReviewerID: oldReviewerID,
+		},
+		Reviewers: selection.Reviewers,
+	}
+}
+
+// NewAddDecision записывает выбор дополнительных ревьюверов; before - PR до добавления.
+func NewAddDecision(before *PullRequest, team *teamdomain.Team, slots int, seed int64,
+	opts SelectOptions, selection Selection) *Decision {
+
+	return &Decision{
+		PullRequestID: before.ID,
+		Kind:          DecisionAdd,
+		Seed:          seed,
+		Input: DecisionInput{
+			AuthorID:    before.AuthorId,
+			Team:        *team,
+			Options:     opts,
+			PullRequest: before,
+			Slots:       slots,
+		},
+		Reviewers: selection.Reviewers,
+	}
+}
+
+// NewBalanceDecision записывает перенос назначения при выравнивании нагрузки; before - PR до
+// переноса. Перенос не случаен, поэтому зерно не сохраняется.
+func NewBalanceDecision(before *PullRequest, team *teamdomain.Team, move BalanceMove,
+	opts SelectOptions) *Decision {
+
+	return &Decision{
+		PullRequestID: before.ID,
+		Kind:          DecisionBalance,
+		Input: DecisionInput{
+			AuthorID:      before.AuthorId,
+			Team:          *team,
+			Options:       opts,
+			PullRequest:   before,
+			OldReviewerID: move.From,
+		},
+		Reviewers: []string{move.To},
+	}
+}
+
+// Replay повторяет выбор по сохранённым зерну и входным данным и возвращает выбранных ревьюверов.
+func (d *Decision) Replay() ([]string, error) {
+	opts := d.Input.Options
+	opts.Rand = NewRand(d.Seed)
+	team := d.Input.Team
+
+	if d.Kind == DecisionCreate {
+		return SelectReviewers(&team, d.Input.AuthorID, opts).Reviewers, nil
+	}
+
+	if d.Input.PullRequest == nil {
+		return nil, fmt.Errorf("%s decision without pull request", d.Kind)
+	}
+
+	var (
+		selection Selection
+		err       error
+	)
+	switch d.Kind {
+	case DecisionReassign:
+		selection, err = ReassignReviewerWith(ClonePR(d.Input.PullRequest), &team, d.Input.OldReviewerID, opts)
+	case DecisionAdd:
+		selection, err = PickAdditional(d.Input.PullRequest, &team, d.Input.Slots, opts)
+	case DecisionBalance:
+		// план строится по всей команде, поэтому проверяется только допустимость переноса
+		if len(d.Reviewers) != 1 ||
+			!CanMove(&team, d.Input.PullRequest, d.Input.OldReviewerID, d.Reviewers[0], opts) {
+			return nil, fmt.Errorf("balance move from %s is not allowed by recorded input", d.Input.OldReviewerID)
+		}
+		selection.Reviewers = d.Reviewers
+	default:
+		return nil, fmt.Errorf("unknown decision kind %q", d.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return selection.Reviewers, nil
+}
+
+// ClonePR копирует PR вместе со списками, чтобы снимок не менялся при замене ревьюверов.
+func ClonePR(pr *PullRequest) *PullRequest {
+	clone := *pr
+	clone.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	clone.Tags = slices.Clone(pr.Tags)
+	clone.ReviewerPools = maps.Clone(pr.ReviewerPools)
+	clone.ReviewerSources = maps.Clone(pr.ReviewerSources)
+	return &clone
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decisionTeam() *teamdomain.Team {
+	members := make([]teamdomain.TeamMember, 0, 12)
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5", "u6", "u7", "u8", "u9", "u10"} {
+		members = append(members, teamdomain.TeamMember{ID: id, IsActive: true, Tags: []string{"go"}})
+	}
+	return &teamdomain.Team{Name: "backend", Members: members}
+}
+
+func TestSelectReviewers_SameSeedSameChoice(t *testing.T) {
+	t.Parallel()
+
+	team := decisionTeam()
+
+	for _, mode := range []AssignmentMode{ModeRandom, ModeRotation} {
+		first := SelectReviewers(team, "u1", SelectOptions{Mode: mode, Rand: NewRand(42)})
+		second := SelectReviewers(team, "u1", SelectOptions{Mode: mode, Rand: NewRand(42)})
+		assert.Equal(t, first.Reviewers, second.Reviewers, mode)
+	}
+}
+
+func TestSeedSource_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewSeedSource(7), NewSeedSource(7)
+	for range 5 {
+		assert.Equal(t, a.Next(), b.Next())
+	}
+
+	var none *SeedSource
+	assert.NotEqual(t, none.Next(), none.Next())
+}
+
+func TestDecision_Replay(t *testing.T) {
+	t.Parallel()
+
+	team := decisionTeam()
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		opts := SelectOptions{
+			Mode:            ModeRotation,
+			Now:             now,
+			Tags:            []string{"go"},
+			RecentReviewers: [][]string{{"u2", "u3"}},
+			Excluded:        []string{"u4"},
+			Rand:            NewRand(99),
+		}
+		selection := SelectReviewers(team, "u1", opts)
+		decision := NewCreateDecision("pr-1", "u1", team, 99, opts, selection)
+
+		// решение переживает сохранение в JSON
+		raw, err := json.Marshal(decision)
+		require.NoError(t, err)
+		var stored Decision
+		require.NoError(t, json.Unmarshal(raw, &stored))
+
+		replayed, err := stored.Replay()
+		require.NoError(t, err)
+		assert.Equal(t, selection.Reviewers, replayed)
+	})
+
+	t.Run("reassign", func(t *testing.T) {
+		t.Parallel()
+
+		pr := &PullRequest{ID: "pr-1", AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+		before := ClonePR(pr)
+		opts := SelectOptions{Mode: ModeRandom, Now: now, Rand: NewRand(5)}
+
+		selection, err := ReassignReviewerWith(pr, team, "u2", opts)
+		require.NoError(t, err)
+		decision := NewReassignDecision(before, team, "u2", 5, opts, selection)
+
+		replayed, err := decision.Replay()
+		require.NoError(t, err)
+		assert.Equal(t, selection.Reviewers, replayed)
+		assert.Equal(t, []string{"u2", "u3"}, decision.Input.PullRequest.AssignedReviewers)
+	})
+
+	t.Run("add", func(t *testing.T) {
+		t.Parallel()
+
+		pr := &PullRequest{ID: "pr-1", AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2"}}
+		opts := SelectOptions{Mode: ModeRandom, Now: now, Rand: NewRand(11)}
+
+		selection, err := PickAdditional(pr, team, 2, opts)
+		require.NoError(t, err)
+		decision := NewAddDecision(ClonePR(pr), team, 2, 11, opts, selection)
+
+		replayed, err := decision.Replay()
+		require.NoError(t, err)
+		assert.Equal(t, selection.Reviewers, replayed)
+		assert.Len(t, replayed, 2)
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		t.Parallel()
+
+		pr := &PullRequest{ID: "pr-1", AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+		decision := NewBalanceDecision(pr, team, BalanceMove{PullRequestID: "pr-1", From: "u2", To: "u5"}, SelectOptions{})
+
+		replayed, err := decision.Replay()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u5"}, replayed)
+
+		// перенос на исключённого правилами пар по сохранённым данным недопустим
+		decision.Input.Options.Excluded = []string{"u5"}
+		_, err = decision.Replay()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// memberLevels собирает грейды всех участников, известных при выборе: команды, владельцев
+// кода, пулов и уже назначенных ревьюверов из opts.AssignedLevels.
+func memberLevels(team *teamdomain.Team, opts SelectOptions) map[string]userdomain.Level {
+	levels := make(map[string]userdomain.Level, len(opts.AssignedLevels))
+	for id, level := range opts.AssignedLevels {
+		levels[id] = level
+	}
+
+	add := func(members []teamdomain.TeamMember) {
+		for _, m := range members {
+			if m.Level != "" {
+				levels[m.ID] = m.Level
+			}
+		}
+	}
+
+	if team != nil {
+		add(team.Members)
+	}
+	for _, owner := range opts.Owners {
+		add(owner.Users)
+		add(owner.TeamMembers)
+	}
+	for _, slot := range opts.Pools {
+		add(slot.Members)
+	}
+
+	return levels
+}
+
+// levelShortage - сколько ревьюверов уровня не ниже opts.MinLevel не хватает среди reviewers.
+func levelShortage(reviewers []string, levels map[string]userdomain.Level, opts SelectOptions) int {
+	if opts.MinLevelReviewers <= 0 {
+		return 0
+	}
+
+	have := 0
+	for _, id := range reviewers {
+		if levels[id].AtLeast(opts.MinLevel) {
+			have++
+		}
+	}
+
+	return max(opts.MinLevelReviewers-have, 0)
+}
+
+// CheckRemovableLevel запрещает снимать ревьювера, если без него требование политики к
+// грейду выполнялось бы хуже, чем сейчас. Грейды назначенных берутся из opts.AssignedLevels.
+func CheckRemovableLevel(pr *PullRequest, reviewerID string, opts SelectOptions) error {
+	if opts.MinLevelReviewers <= 0 {
+		return nil
+	}
+
+	levels := memberLevels(nil, opts)
+	remaining := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool {
+		return id == reviewerID
+	})
+
+	if levelShortage(remaining, levels, opts) > levelShortage(pr.AssignedReviewers, levels, opts) {
+		return fmt.Errorf("%w: %s is needed to keep %d reviewers of level %s or higher",
+			apperr.ErrLevelPolicy, reviewerID, opts.MinLevelReviewers, opts.MinLevel)
+	}
+	return nil
+}
+
+// CheckLevelChange запрещает заменять список ревьюверов before на after, если с новым списком
+// требование политики к грейду выполнялось бы хуже, чем с прежним. Грейды берутся из
+// opts.AssignedLevels.
+func CheckLevelChange(before, after []string, opts SelectOptions) error {
+	if opts.MinLevelReviewers <= 0 {
+		return nil
+	}
+
+	levels := memberLevels(nil, opts)
+	if levelShortage(after, levels, opts) > levelShortage(before, levels, opts) {
+		return fmt.Errorf("%w: new reviewers must keep %d reviewers of level %s or higher",
+			apperr.ErrLevelPolicy, opts.MinLevelReviewers, opts.MinLevel)
+	}
+	return nil
+}
+
+func membersAtLeast(members []teamdomain.TeamMember, min userdomain.Level) []teamdomain.TeamMember {
+	result := make([]teamdomain.TeamMember, 0, len(members))
+	for _, m := range members {
+		if m.Level.AtLeast(min) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func membersExcept(members []teamdomain.TeamMember, ids []string) []teamdomain.TeamMember {
+	result := make([]teamdomain.TeamMember, 0, len(members))
+	for _, m := range members {
+		if !slices.Contains(ids, m.ID) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// selectRequired добавляет в selection наставника, которого правило пары требует на каждый
+// PR автора. Недоступный наставник пропускается с предупреждением.
+func selectRequired(selection *Selection, mentor teamdomain.TeamMember) {
+	if slices.Contains(selection.Reviewers, mentor.ID) {
+		return
+	}
+
+	if !mentor.Available() {
+		selection.warn(fmt.Sprintf("required reviewer %s is inactive or out of office, skipped", mentor.ID))
+		return
+	}
+
+	selection.Reviewers = append(selection.Reviewers, mentor.ID)
+	selection.Required = append(selection.Required, mentor.ID)
+}
+
+// CheckRemovable запрещает снимать с PR доступного наставника, которого требуют правила пар автора.
+func CheckRemovable(authorID string, opts SelectOptions, reviewerIDs ...string) error {
+	for _, mentor := range opts.Required {
+		if slices.Contains(reviewerIDs, mentor.ID) && mentor.Available() {
+			return fmt.Errorf("%w: %s is required to review PRs of %s", apperr.ErrPairingRule, mentor.ID, authorID)
+		}
+	}
+	return nil
+}
+
+// barredMembers - доступные участники, которых правила пар не допускают к ревью; нужны,
+// чтобы объяснить, почему не хватило кандидатов.
+func barredMembers(members []teamdomain.TeamMember, opts SelectOptions, except ...string) []string {
+	var barred []string
+	for _, m := range members {
+		if m.Available() && slices.Contains(opts.Excluded, m.ID) && !slices.Contains(except, m.ID) {
+			barred = append(barred, m.ID)
+		}
+	}
+	return barred
+}
+
+func excludedMessage(barred []string, authorID string) string {
+	return fmt.Sprintf("%s excluded by pairing rules for author %s", strings.Join(barred, ", "), authorID)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectReviewers_PairingRules(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}
+
+	t.Run("excluded_never_selected", func(t *testing.T) {
+		t.Parallel()
+
+		for range 20 {
+			got := SelectReviewers(team, "u1", SelectOptions{Excluded: []string{"u2"}})
+			assert.ElementsMatch(t, []string{"u3", "u4"}, got.Reviewers)
+			assert.Empty(t, got.Warnings)
+		}
+	})
+
+	t.Run("mentor_first", func(t *testing.T) {
+		t.Parallel()
+
+		mentor := teamdomain.TeamMember{ID: "m1", IsActive: true, TeamName: "platform"}
+		got := SelectReviewers(team, "u1", SelectOptions{Required: []teamdomain.TeamMember{mentor}})
+
+		require.Len(t, got.Reviewers, 2)
+		assert.Equal(t, "m1", got.Reviewers[0])
+		assert.Equal(t, []string{"m1"}, got.Required)
+	})
+
+	t.Run("unavailable_mentor", func(t *testing.T) {
+		t.Parallel()
+
+		mentor := teamdomain.TeamMember{ID: "m1", IsActive: false}
+		got := SelectReviewers(team, "u1", SelectOptions{Required: []teamdomain.TeamMember{mentor}})
+
+		assert.Len(t, got.Reviewers, 2)
+		assert.NotContains(t, got.Reviewers, "m1")
+		assert.Equal(t, []string{"required reviewer m1 is inactive or out of office, skipped"}, got.Warnings)
+	})
+
+	t.Run("shortage_explained", func(t *testing.T) {
+		t.Parallel()
+
+		got := SelectReviewers(team, "u1", SelectOptions{Excluded: []string{"u2", "u3"}})
+
+		assert.Equal(t, []string{"u4"}, got.Reviewers)
+		assert.Equal(t, []string{"u2, u3 excluded by pairing rules for author u1"}, got.Warnings)
+	})
+}
+
+func TestReassignReviewerWith_PairingRules(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}
+	newPR := func() *PullRequest {
+		return &PullRequest{AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	}
+
+	t.Run("excluded_skipped", func(t *testing.T) {
+		t.Parallel()
+
+		members := append(team.Members, teamdomain.TeamMember{ID: "u5", IsActive: true})
+		got, err := ReassignReviewerWith(newPR(), &teamdomain.Team{Members: members}, "u2",
+			SelectOptions{Excluded: []string{"u4"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u5"}, got.Reviewers)
+	})
+
+	t.Run("no_candidate_explained", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReassignReviewerWith(newPR(), team, "u2", SelectOptions{Excluded: []string{"u4"}})
+		require.ErrorIs(t, err, apperr.ErrNoCandidate)
+		assert.Contains(t, err.Error(), "u4 excluded by pairing rules for author u1")
+	})
+
+	t.Run("mentor_kept", func(t *testing.T) {
+		t.Parallel()
+
+		pr := newPR()
+		_, err := ReassignReviewerWith(pr, team, "u3", SelectOptions{
+			Required: []teamdomain.TeamMember{{ID: "u3", IsActive: true}},
+		})
+		require.ErrorIs(t, err, apperr.ErrPairingRule)
+		assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	})
+
+	t.Run("absent_mentor_replaced", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := ReassignReviewerWith(newPR(), team, "u3", SelectOptions{
+			Required: []teamdomain.TeamMember{{ID: "u3", IsActive: false}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, got.Reviewers)
+	})
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
@@ -11,6 +12,18 @@ type PrStatus string
 const (
 	StatusOpen   PrStatus = "OPEN"
 	StatusMerged PrStatus = "MERGED"
+	// StatusClosed - PR закрыт как заброшенный, ревьюверы с него сняты.
+	StatusClosed PrStatus = "CLOSED"
+)
+
+// ReviewerSource - почему ревьювер назначен не обычным выбором из команды или пула.
+type ReviewerSource string
+
+const (
+	// SourceOwner - владелец кода, затронутого PR.
+	SourceOwner ReviewerSource = "owner"
+	// SourceRequested - ревьювер указан явно: автором при создании или вручную позже.
+	SourceRequested ReviewerSource = "requested"
 )
 
 type PullRequest struct {
@@ -21,16 +34,33 @@ type PullRequest struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	CreatedAt         time.Time  `json:"createdAt"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	StaleAt           *time.Time `json:"staleAt,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+	// Tags - метки PR ("go", "postgres"), по ним подбираются ревьюверы с такой же экспертизой.
+	Tags []string `json:"tags,omitempty"`
+	// ReviewerPools - из какого пула назначен ревьювер (user_id -> пул); ревьюверы
+	// из своей команды здесь не перечисляются.
+	ReviewerPools map[string]string `json:"reviewer_pools,omitempty"`
+	// ReviewerSources - ревьюверы, назначенные как владельцы кода или указанные явно;
+	// их не снимают при выравнивании нагрузки и пересмотре состава.
+	ReviewerSources map[string]ReviewerSource `json:"reviewer_sources,omitempty"`
 }
 
 func (p *PullRequest) IsMerged() bool {
 	return p.Status == StatusMerged
 }
 
+func (p *PullRequest) IsClosed() bool {
+	return p.Status == StatusClosed
+}
+
 func (p *PullRequest) CanReassign() error {
 	if p.IsMerged() {
 		return apperr.ErrPRMerged
 	}
+	if p.IsClosed() {
+		return apperr.ErrPRClosed
+	}
 	return nil
 }
 
@@ -52,9 +82,135 @@ func (p *PullRequest) ReplaceReviewer(oldID, newID string) error {
 		return apperr.ErrNotAssigned
 	}
 
+	// замена занимает место в том же пуле, но не наследует причину назначения
+	if pool, ok := p.ReviewerPools[oldID]; ok {
+		delete(p.ReviewerPools, oldID)
+		p.ReviewerPools[newID] = pool
+	}
+	delete(p.ReviewerSources, oldID)
+
+	return nil
+}
+
+// SetSource запоминает, почему назначен ревьювер.
+func (p *PullRequest) SetSource(id string, source ReviewerSource) {
+	if p.ReviewerSources == nil {
+		p.ReviewerSources = make(map[string]ReviewerSource)
+	}
+	p.ReviewerSources[id] = source
+}
+
+// AddReviewer добавляет ревьювера к открытому PR, не занимая места пула.
+func (p *PullRequest) AddReviewer(id string) error {
+	if err := p.CanReassign(); err != nil {
+		return err
+	}
+	if slices.Contains(p.AssignedReviewers, id) {
+		return apperr.ErrAssigned
+	}
+
+	p.AssignedReviewers = append(p.AssignedReviewers, id)
+	return nil
+}
+
+// AddSelected добавляет выбранных PickAdditional ревьюверов к PR вместе с их пулами.
+func (p *PullRequest) AddSelected(selection Selection) error {
+	for _, id := range selection.Reviewers {
+		if err := p.AddReviewer(id); err != nil {
+			return err
+		}
+		if pool, ok := selection.Pools[id]; ok {
+			if p.ReviewerPools == nil {
+				p.ReviewerPools = make(map[string]string)
+			}
+			p.ReviewerPools[id] = pool
+		}
+	}
 	return nil
 }
 
+// RemoveReviewer снимает ревьювера с открытого PR без замены.
+func (p *PullRequest) RemoveReviewer(id string) error {
+	if err := p.CanReassign(); err != nil {
+		return err
+	}
+
+	idx := slices.Index(p.AssignedReviewers, id)
+	if idx < 0 {
+		return apperr.ErrNotAssigned
+	}
+
+	p.AssignedReviewers = slices.Delete(p.AssignedReviewers, idx, idx+1)
+	delete(p.ReviewerPools, id)
+	delete(p.ReviewerSources, id)
+	return nil
+}
+
+// SetReviewers заменяет список ревьюверов открытого PR и возвращает добавленных и снятых.
+// Оставшиеся ревьюверы сохраняют свой пул и причину назначения, новые считаются указанными явно.
+func (p *PullRequest) SetReviewers(ids []string) (added, removed []string, err error) {
+	if err := p.CanReassign(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, id := range p.AssignedReviewers {
+		if !slices.Contains(ids, id) {
+			removed = append(removed, id)
+			delete(p.ReviewerPools, id)
+			delete(p.ReviewerSources, id)
+		}
+	}
+	for _, id := range ids {
+		if !slices.Contains(p.AssignedReviewers, id) {
+			added = append(added, id)
+			p.SetSource(id, SourceRequested)
+		}
+	}
+
+	p.AssignedReviewers = slices.Clone(ids)
+	return added, removed, nil
+}
+
+// MarkStale помечает открытый PR как заброшенный. false - PR уже помечен или не открыт.
+func (p *PullRequest) MarkStale(now time.Time) bool {
+	if p.Status != StatusOpen || p.StaleAt != nil {
+		return false
+	}
+
+	p.StaleAt = &now
+	return true
+}
+
+// Close закрывает открытый PR и снимает с него ревьюверов, возвращая их список.
+func (p *PullRequest) Close(now time.Time) []string {
+	if p.Status != StatusOpen {
+		return nil
+	}
+
+	freed := p.AssignedReviewers
+	p.AssignedReviewers = make([]string, 0)
+	p.ReviewerPools = nil
+	p.ReviewerSources = nil
+	p.Status = StatusClosed
+	p.ClosedAt = &now
+
+	return freed
+}
+
+// Reopen снова открывает закрытый PR; пометка о заброшенности снимается, чтобы автор
+// получил полный срок до нового закрытия. false - PR не был закрыт.
+func (p *PullRequest) Reopen() bool {
+	if p.Status != StatusClosed {
+		return false
+	}
+
+	p.Status = StatusOpen
+	p.ClosedAt = nil
+	p.StaleAt = nil
+	return true
+}
+
+// Merge допускается и для закрытого PR: он мог быть слит во внешней системе после автозакрытия.
 func (p *PullRequest) Merge() {
 	if p.IsMerged() {
 		return
@@ -58,6 +58,11 @@ func TestPullRequest_CanReassign(t *testing.T) {
 			status:  StatusMerged,
 			wantErr: apperr.ErrPRMerged,
 		},
+		{
+			name:    "closed_pr_cannot_be_reassigned",
+			status:  StatusClosed,
+			wantErr: apperr.ErrPRClosed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -159,3 +164,183 @@ func TestPullRequest_Merge(t *testing.T) {
 	assert.Same(t, mergedAt, pr.MergedAt)
 	assert.True(t, pr.IsMerged())
 }
+
+func TestPullRequest_MarkStale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		status  PrStatus
+		staleAt *time.Time
+		want    bool
+		wantAt  *time.Time
+	}{
+		{name: "open_pr_is_marked", status: StatusOpen, want: true, wantAt: &now},
+		{name: "already_stale_keeps_time", status: StatusOpen, staleAt: &earlier, want: false, wantAt: &earlier},
+		{name: "merged_pr_is_not_marked", status: StatusMerged, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &PullRequest{Status: tt.status, StaleAt: tt.staleAt}
+			assert.Equal(t, tt.want, pr.MarkStale(now))
+			assert.Equal(t, tt.wantAt, pr.StaleAt)
+		})
+	}
+}
+
+func TestPullRequest_Close(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+
+	pr := &PullRequest{Status: StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	freed := pr.Close(now)
+
+	assert.Equal(t, []string{"u2", "u3"}, freed)
+	assert.Empty(t, pr.AssignedReviewers)
+	assert.Equal(t, StatusClosed, pr.Status)
+	require.NotNil(t, pr.ClosedAt)
+	assert.Equal(t, now, *pr.ClosedAt)
+
+	// повторное закрытие ничего не меняет
+	assert.Nil(t, pr.Close(now.Add(time.Hour)))
+	assert.Equal(t, now, *pr.ClosedAt)
+
+	// закрытый PR ещё может быть слит во внешней системе
+	pr.Merge()
+	assert.Equal(t, StatusMerged, pr.Status)
+}
+
+func TestPullRequest_Reopen(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+
+	pr := &PullRequest{Status: StatusOpen, StaleAt: &now}
+	assert.False(t, pr.Reopen())
+	assert.NotNil(t, pr.StaleAt)
+
+	pr.Close(now)
+	assert.True(t, pr.Reopen())
+	assert.Equal(t, StatusOpen, pr.Status)
+	assert.Nil(t, pr.ClosedAt)
+	assert.Nil(t, pr.StaleAt)
+
+	// слитый PR не переоткрывается
+	pr.Merge()
+	assert.False(t, pr.Reopen())
+	assert.Equal(t, StatusMerged, pr.Status)
+}
+
+func TestPullRequest_SetReviewers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      PrStatus
+		ids         []string
+		wantAdded   []string
+		wantRemoved []string
+		wantPools   map[string]string
+		wantSources map[string]ReviewerSource
+		wantErr     error
+	}{
+		{
+			name:        "replace_keeps_pool_of_retained_reviewer",
+			status:      StatusOpen,
+			ids:         []string{"u3", "p1"},
+			wantAdded:   []string{"u3"},
+			wantRemoved: []string{"u2"},
+			wantPools:   map[string]string{"p1": "security"},
+			wantSources: map[string]ReviewerSource{"u3": SourceRequested},
+		},
+		{
+			name:        "clear_reviewers",
+			status:      StatusOpen,
+			ids:         []string{},
+			wantRemoved: []string{"u2", "p1"},
+			wantPools:   map[string]string{},
+			wantSources: map[string]ReviewerSource{},
+		},
+		{
+			name:    "merged_pr",
+			status:  StatusMerged,
+			ids:     []string{"u3"},
+			wantErr: apperr.ErrPRMerged,
+		},
+		{
+			name:    "closed_pr",
+			status:  StatusClosed,
+			ids:     []string{"u3"},
+			wantErr: apperr.ErrPRClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &PullRequest{
+				Status:            tt.status,
+				AssignedReviewers: []string{"u2", "p1"},
+				ReviewerPools:     map[string]string{"p1": "security"},
+				ReviewerSources:   map[string]ReviewerSource{"u2": SourceOwner},
+			}
+
+			added, removed, err := pr.SetReviewers(tt.ids)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, []string{"u2", "p1"}, pr.AssignedReviewers)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, added)
+			assert.Equal(t, tt.wantRemoved, removed)
+			assert.Equal(t, tt.ids, pr.AssignedReviewers)
+			assert.Equal(t, tt.wantPools, pr.ReviewerPools)
+			assert.Equal(t, tt.wantSources, pr.ReviewerSources)
+		})
+	}
+}
+
+func TestPullRequest_AddRemoveReviewer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add_and_remove", func(t *testing.T) {
+		t.Parallel()
+
+		pr := &PullRequest{
+			Status:            StatusOpen,
+			AssignedReviewers: []string{"u2", "p1"},
+			ReviewerPools:     map[string]string{"p1": "security"},
+		}
+
+		require.NoError(t, pr.AddReviewer("u3"))
+		assert.Equal(t, []string{"u2", "p1", "u3"}, pr.AssignedReviewers)
+		assert.ErrorIs(t, pr.AddReviewer("u3"), apperr.ErrAssigned)
+
+		require.NoError(t, pr.RemoveReviewer("p1"))
+		assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+		assert.Empty(t, pr.ReviewerPools)
+		assert.ErrorIs(t, pr.RemoveReviewer("p1"), apperr.ErrNotAssigned)
+	})
+
+	t.Run("merged_pr_is_immutable", func(t *testing.T) {
+		t.Parallel()
+
+		pr := &PullRequest{Status: StatusMerged, AssignedReviewers: []string{"u2"}}
+
+		assert.ErrorIs(t, pr.AddReviewer("u3"), apperr.ErrPRMerged)
+		assert.ErrorIs(t, pr.RemoveReviewer("u2"), apperr.ErrPRMerged)
+		assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	})
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultSeeds - источник зёрен для выбора без явно переданного генератора.
+var defaultSeeds = NewSeedSource(0)
+
+// SeedSource выдаёт зерно для каждого выбора ревьюверов. С фиксированным зерном конфигурации
+// последовательность выборов воспроизводима; Next безопасен для конкурентного вызова.
+type SeedSource struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// NewSeedSource - seed 0 означает зерно от текущего времени.
+func NewSeedSource(seed int64) *SeedSource {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &SeedSource{r: rand.New(rand.NewSource(seed))}
+}
+
+// Next возвращает зерно для очередного выбора; nil-источник берёт зерно из общего.
+func (s *SeedSource) Next() int64 {
+	if s == nil {
+		return defaultSeeds.Next()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Int63()
+}
+
+// NewRand - генератор одного выбора ревьюверов; одно и то же зерно даёт тот же выбор.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// random - генератор выбора: переданный в opts или новый с зерном из общего источника.
+func (o SelectOptions) random() *rand.Rand {
+	if o.Rand != nil {
+		return o.Rand
+	}
+	return NewRand(defaultSeeds.Next())
+}
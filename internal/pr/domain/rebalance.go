@@ -0,0 +1,49 @@
+package domain
+
+import (
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// StaleReason - почему назначенный ревьювер больше не может ревьюить PR.
+type StaleReason string
+
+const (
+	// StaleInactive - ревьювер деактивирован.
+	StaleInactive StaleReason = "inactive"
+	// StaleOutOfOffice - у ревьювера идёт период отсутствия.
+	StaleOutOfOffice StaleReason = "out_of_office"
+	// StaleNotInTeam - ревьювера больше нет в команде автора или в пуле, из которого он назначен.
+	StaleNotInTeam StaleReason = "not_in_team"
+)
+
+// ReviewerChange - строка diff ребалансировки PR: ReviewerID снят по Reason, ReplacedBy
+// назначен вместо него; пустой ReplacedBy - замены не нашлось и место осталось свободным.
+type ReviewerChange struct {
+	ReviewerID string      `json:"reviewer_id"`
+	Reason     StaleReason `json:"reason"`
+	ReplacedBy string      `json:"replaced_by,omitempty"`
+	Pool       string      `json:"pool_name,omitempty"`
+}
+
+// ReviewerStaleReason проверяет ревьювера по группе, из которой он должен быть назначен
+// (команда автора или пул). Владельцы кода и запрошенные ревьюверы выбраны не из группы,
+// поэтому не проверяются. Второе значение false - ревьювер на месте.
+func ReviewerStaleReason(pr *PullRequest, group *teamdomain.Team, reviewerID string) (StaleReason, bool) {
+	if pr.ReviewerSources[reviewerID] != "" {
+		return "", false
+	}
+
+	for _, member := range group.Members {
+		if member.ID != reviewerID {
+			continue
+		}
+		if !member.IsActive {
+			return StaleInactive, true
+		}
+		if member.OutOfOffice {
+			return StaleOutOfOffice, true
+		}
+		return "", false
+	}
+	return StaleNotInTeam, true
+}
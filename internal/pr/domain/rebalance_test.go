@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerStaleReason(t *testing.T) {
+	t.Parallel()
+
+	pr := &PullRequest{
+		ID: "pr-1",
+		ReviewerSources: map[string]ReviewerSource{
+			"u7": SourceOwner,
+			"u8": SourceRequested,
+		},
+	}
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: false},
+			{ID: "u3", IsActive: true, OutOfOffice: true},
+			{ID: "u8", IsActive: false},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		reviewerID string
+		wantReason StaleReason
+		wantStale  bool
+	}{
+		{name: "active member", reviewerID: "u1"},
+		{name: "inactive member", reviewerID: "u2", wantReason: StaleInactive, wantStale: true},
+		{name: "out of office member", reviewerID: "u3", wantReason: StaleOutOfOffice, wantStale: true},
+		{name: "left the team", reviewerID: "u9", wantReason: StaleNotInTeam, wantStale: true},
+		{name: "code owner from another team", reviewerID: "u7"},
+		{name: "requested reviewer is not checked", reviewerID: "u8"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reason, stale := ReviewerStaleReason(pr, team, tt.reviewerID)
+			assert.Equal(t, tt.wantStale, stale)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// CheckRequested проверяет ревьюверов, указанных явно: каждый должен быть доступным (активным
+// и не в отпуске) участником команды автора, не автором и не исключённым правилами пар. Ошибка объясняет первого
+// неподходящего.
+func CheckRequested(team *teamdomain.Team, authorID string, ids []string, excluded []string) error {
+	for i, id := range ids {
+		if slices.Contains(ids[:i], id) {
+			return fmt.Errorf("%w: %s is listed twice", apperr.ErrInvalidReviewers, id)
+		}
+		if id == authorID {
+			return fmt.Errorf("%w: %s is the author of the pull request", apperr.ErrInvalidReviewers, id)
+		}
+
+		idx := slices.IndexFunc(team.Members, func(m teamdomain.TeamMember) bool { return m.ID == id })
+		if idx < 0 {
+			return fmt.Errorf("%w: %s is not a member of team %s", apperr.ErrInvalidReviewers, id, team.Name)
+		}
+		if member := &team.Members[idx]; !member.Available() {
+			if !member.IsActive {
+				return fmt.Errorf("%w: %s is inactive", apperr.ErrInvalidReviewers, id)
+			}
+			return fmt.Errorf("%w: %s is out of office", apperr.ErrInvalidReviewers, id)
+		}
+		if slices.Contains(excluded, id) {
+			return fmt.Errorf("%w: %s", apperr.ErrInvalidReviewers, excludedMessage([]string{id}, authorID))
+		}
+	}
+	return nil
+}
+
+// selectRequested добавляет в selection ревьюверов, которых автор указал сам; проверены они
+// заранее через CheckRequested.
+func selectRequested(selection *Selection, ids []string) {
+	for _, id := range ids {
+		if slices.Contains(selection.Reviewers, id) {
+			continue
+		}
+		selection.Reviewers = append(selection.Reviewers, id)
+		selection.Requested = append(selection.Requested, id)
+	}
+}
+
+// PickAdditional выбирает до n дополнительных ревьюверов PR среди доступных участников team
+// и закрывает свободные места пулов из opts.Pools (slot.Reviewers - сколько мест пула свободно).
+// Назначенные, автор и исключённые правилами пар не рассматриваются; если политика требует
+// опытных ревьюверов и их не хватает, места команды сначала получают участники нужного уровня.
+// Если теги в opts не заданы, используются теги PR. Если выбрать некого, возвращается
+// ErrNoCandidate; когда кандидатов не осталось из-за правил пар, это видно в тексте ошибки.
+func PickAdditional(pr *PullRequest, team *teamdomain.Team, n int, opts SelectOptions) (Selection, error) {
+	if len(opts.Tags) == 0 {
+		opts.Tags = pr.Tags
+	}
+
+	// уже назначенные входят в выбор, чтобы пулы и команда их не предлагали; в результат они не попадают
+	assigned := len(pr.AssignedReviewers)
+	selection := Selection{Reviewers: slices.Clone(pr.AssignedReviewers)}
+	for _, slot := range opts.Pools {
+		selectFromPool(&selection, slot, pr.AuthorId, opts)
+	}
+
+	except := append([]string{pr.AuthorId}, selection.Reviewers...)
+	if n > 0 {
+		active := team.ActiveMembersExcept(append(slices.Clone(except), opts.Excluded...)...)
+
+		if need := levelShortage(selection.Reviewers, memberLevels(team, opts), opts); need > 0 {
+			experienced := pick(membersAtLeast(active, opts.MinLevel), min(need, n), opts)
+			selection.add(experienced)
+			n -= len(experienced.Reviewers)
+			active = membersExcept(active, experienced.Reviewers)
+		}
+
+		selection.add(pick(active, n, opts))
+	}
+	selection.Reviewers = selection.Reviewers[assigned:]
+
+	if len(selection.Reviewers) == 0 {
+		if barred := barredMembers(team.Members, opts, except...); len(barred) > 0 {
+			return Selection{}, fmt.Errorf("%w: %s", apperr.ErrNoCandidate, excludedMessage(barred, pr.AuthorId))
+		}
+		return Selection{}, apperr.ErrNoCandidate
+	}
+	return selection, nil
+}
+
+// MissingSlots считает свободные места PR по параметрам выбора: сколько мест команды не занято
+// (ревьюверами команды считаются все, кто назначен не из пула) и пулы с числом свободных мест.
+func MissingSlots(pr *PullRequest, opts SelectOptions) (int, []PoolSlot) {
+	inPools := make(map[string]int, len(opts.Pools))
+	fromTeam := 0
+	for _, id := range pr.AssignedReviewers {
+		if pool, ok := pr.ReviewerPools[id]; ok {
+			inPools[pool]++
+			continue
+		}
+		fromTeam++
+	}
+
+	var pools []PoolSlot
+	for _, slot := range opts.Pools {
+		if free := slot.Reviewers - inPools[slot.Pool]; free > 0 {
+			slot.Reviewers = free
+			pools = append(pools, slot)
+		}
+	}
+
+	return max(opts.teamReviewers()-fromTeam, 0), pools
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRequested(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: false},
+			{ID: "u4", IsActive: true},
+			{ID: "u5", IsActive: true, OutOfOffice: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		ids      []string
+		excluded []string
+		wantMsg  string
+	}{
+		{name: "valid", ids: []string{"u2", "u4"}},
+		{name: "author", ids: []string{"u1"}, wantMsg: "u1 is the author of the pull request"},
+		{name: "not_in_team", ids: []string{"x9"}, wantMsg: "x9 is not a member of team backend"},
+		{name: "inactive", ids: []string{"u3"}, wantMsg: "u3 is inactive"},
+		{name: "out_of_office", ids: []string{"u2", "u5"}, wantMsg: "u5 is out of office"},
+		{name: "duplicate", ids: []string{"u2", "u2"}, wantMsg: "u2 is listed twice"},
+		{name: "excluded", ids: []string{"u4"}, excluded: []string{"u4"}, wantMsg: "u4 excluded by pairing rules for author u1"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckRequested(team, "u1", tt.ids, tt.excluded)
+			if tt.wantMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, apperr.ErrInvalidReviewers)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestSelectReviewers_Requested(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}
+
+	t.Run("fills_remaining_slot", func(t *testing.T) {
+		t.Parallel()
+
+		selection := SelectReviewers(team, "u1", SelectOptions{Mode: ModeRandom, Requested: []string{"u3"}})
+		require.Len(t, selection.Reviewers, 2)
+		assert.Equal(t, "u3", selection.Reviewers[0])
+		assert.Equal(t, []string{"u3"}, selection.Requested)
+		assert.NotContains(t, selection.Reviewers[1:], "u1")
+	})
+
+	t.Run("requested_take_all_slots", func(t *testing.T) {
+		t.Parallel()
+
+		selection := SelectReviewers(team, "u1", SelectOptions{Mode: ModeRandom, Requested: []string{"u4", "u2"}})
+		assert.Equal(t, []string{"u4", "u2"}, selection.Reviewers)
+	})
+
+	t.Run("mentor_added_on_top", func(t *testing.T) {
+		t.Parallel()
+
+		selection := SelectReviewers(team, "u1", SelectOptions{
+			Mode:      ModeRandom,
+			Requested: []string{"u4", "u2"},
+			Required:  []teamdomain.TeamMember{{ID: "u3", IsActive: true}},
+		})
+		assert.Equal(t, []string{"u4", "u2", "u3"}, selection.Reviewers)
+	})
+}
+
+func TestPickAdditional(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+			{ID: "u5", IsActive: false},
+		},
+	}
+	pr := &PullRequest{ID: "pr-1", AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	t.Run("picks_unassigned_member", func(t *testing.T) {
+		t.Parallel()
+
+		selection, err := PickAdditional(pr, team, 1, SelectOptions{Mode: ModeRandom})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u4"}, selection.Reviewers)
+	})
+
+	t.Run("excluded_explained", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PickAdditional(pr, team, 1, SelectOptions{Mode: ModeRandom, Excluded: []string{"u4"}})
+		assert.ErrorIs(t, err, apperr.ErrNoCandidate)
+		assert.Contains(t, err.Error(), "u4 excluded by pairing rules for author u1")
+	})
+
+	t.Run("fills_pool_slots", func(t *testing.T) {
+		t.Parallel()
+
+		pools := []PoolSlot{{
+			Pool:      "security",
+			Members:   []teamdomain.TeamMember{{ID: "u3", IsActive: true}, {ID: "s1", IsActive: true}},
+			Reviewers: 1,
+		}}
+		selection, err := PickAdditional(pr, team, 0, SelectOptions{Mode: ModeRandom, Pools: pools})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"s1"}, selection.Reviewers)
+		assert.Equal(t, map[string]string{"s1": "security"}, selection.Pools)
+	})
+
+	t.Run("nothing_to_pick", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := PickAdditional(pr, team, 0, SelectOptions{Mode: ModeRandom})
+		assert.ErrorIs(t, err, apperr.ErrNoCandidate)
+	})
+}
+
+func TestMissingSlots(t *testing.T) {
+	t.Parallel()
+
+	security := PoolSlot{Pool: "security", Reviewers: 1}
+	tests := []struct {
+		name      string
+		pr        *PullRequest
+		opts      SelectOptions
+		wantSlots int
+		wantPools []PoolSlot
+	}{
+		{
+			name:      "default_policy_one_missing",
+			pr:        &PullRequest{AssignedReviewers: []string{"u2"}},
+			wantSlots: 1,
+		},
+		{
+			name: "full",
+			pr:   &PullRequest{AssignedReviewers: []string{"u2", "u3"}},
+		},
+		{
+			name:      "pool_slot_free",
+			pr:        &PullRequest{AssignedReviewers: []string{"u2"}},
+			opts:      SelectOptions{TeamReviewers: 1, Pools: []PoolSlot{security}},
+			wantPools: []PoolSlot{security},
+		},
+		{
+			name: "pool_reviewer_not_counted_for_team",
+			pr: &PullRequest{
+				AssignedReviewers: []string{"s1"},
+				ReviewerPools:     map[string]string{"s1": "security"},
+			},
+			opts:      SelectOptions{TeamReviewers: 2, Pools: []PoolSlot{security}},
+			wantSlots: 2,
+		},
+		{
+			name:      "over_staffed",
+			pr:        &PullRequest{AssignedReviewers: []string{"u2", "u3", "u4"}},
+			opts:      SelectOptions{TeamReviewers: 1},
+			wantSlots: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			slots, pools := MissingSlots(tt.pr, tt.opts)
+			assert.Equal(t, tt.wantSlots, slots)
+			assert.Equal(t, tt.wantPools, pools)
+		})
+	}
+}
@@ -1,49 +1,281 @@
 package domain
 
 import (
+	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
 	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
+// reviewersPerPR - сколько ревьюверов назначается на PR.
+const reviewersPerPR = 2
+
+// AssignmentMode - стратегия выбора ревьюверов среди доступных участников.
+type AssignmentMode string
+
+const (
+	// ModeRandom - случайный выбор, как раньше.
+	ModeRandom AssignmentMode = "random"
+	// ModeWorkingHours - сначала те, у кого сейчас рабочее время, остальные - если таких не хватило.
+	ModeWorkingHours AssignmentMode = "working_hours"
+	// ModeRotation - случайный выбор, в котором недавние ревьюверы автора получают меньший вес.
+	ModeRotation AssignmentMode = "rotation"
+)
+
+func (m AssignmentMode) Valid() bool {
+	return m == ModeRandom || m == ModeWorkingHours || m == ModeRotation
+}
+
+// SelectOptions - параметры выбора. Now - момент, на который проверяются рабочие часы.
+// Owners - правила владения кодом, под которые попал PR: их владельцы назначаются первыми.
+// Tags - теги PR: предпочтение отдаётся участникам с наибольшим числом совпадающих тегов.
+// TeamReviewers и Pools задают политику команды; если не задано ни то ни другое, из команды
+// берётся reviewersPerPR ревьюверов. MinLevelReviewers > 0 требует столько ревьюверов уровня
+// не ниже MinLevel; AssignedLevels - грейды уже назначенных ревьюверов не из team.
+// RecentReviewers - ревьюверы последних PR автора от новых к старым и RotationDecay -
+// затухание их штрафа для режима rotation. Excluded - кого правила пар не допускают к ревью
+// автора, Required - наставники, которых правила пар назначают на каждый PR автора.
+// Rand - генератор выбора; nil - новый генератор на каждый вызов. Параметры сериализуются
+// в Decision, поэтому у полей есть json-теги.
+type SelectOptions struct {
+	Mode              AssignmentMode              `json:"mode"`
+	Now               time.Time                   `json:"now"`
+	Owners            []OwnerRequirement          `json:"owners,omitempty"`
+	Tags              []string                    `json:"tags,omitempty"`
+	TeamReviewers     int                         `json:"team_reviewers,omitempty"`
+	Pools             []PoolSlot                  `json:"pools,omitempty"`
+	MinLevel          userdomain.Level            `json:"min_level,omitempty"`
+	MinLevelReviewers int                         `json:"min_level_reviewers,omitempty"`
+	AssignedLevels    map[string]userdomain.Level `json:"assigned_levels,omitempty"`
+	RecentReviewers   [][]string                  `json:"recent_reviewers,omitempty"`
+	RotationDecay     float64                     `json:"rotation_decay,omitempty"`
+	Excluded          []string                    `json:"excluded,omitempty"`
+	Required          []teamdomain.TeamMember     `json:"required,omitempty"`
+	Requested         []string                    `json:"requested,omitempty"`
+	Rand              *rand.Rand                  `json:"-"`
+}
+
+// PoolSlot - сколько ревьюверов взять из пула и его участники.
+type PoolSlot struct {
+	Pool      string                  `json:"pool_name"`
+	Members   []teamdomain.TeamMember `json:"members"`
+	Reviewers int                     `json:"reviewers"`
+}
+
+// teamReviewers - сколько мест заполняется из команды автора.
+func (o SelectOptions) teamReviewers() int {
+	if o.TeamReviewers == 0 && len(o.Pools) == 0 {
+		return reviewersPerPR
+	}
+	return o.TeamReviewers
+}
+
+// OwnerRequirement - правило владения, под которое попали изменённые файлы: на PR нужен
+// хотя бы один ревьювер из Users (явно указанные владельцы) или TeamMembers (участники
+// команд-владельцев).
+type OwnerRequirement struct {
+	Pattern     string                  `json:"pattern"`
+	Users       []teamdomain.TeamMember `json:"users,omitempty"`
+	TeamMembers []teamdomain.TeamMember `json:"team_members,omitempty"`
+}
+
+// Selection - выбранные ревьюверы. InWorkingHours - те из них, кто выбран потому,
+// что у него сейчас рабочее время (только в режиме working_hours). TagMatched - у кого
+// совпал хотя бы один тег PR. Owners - выбранные как владельцы кода, Warnings - пропущенные
+// владельцы и правила, которые нечем закрыть. Pools - ревьюверы, выбранные из пулов
+// (user_id -> пул). Required - наставники, назначенные по правилам пар, Requested -
+// ревьюверы, которых указал автор.
+type Selection struct {
+	Reviewers      []string
+	InWorkingHours []string
+	TagMatched     []string
+	Owners         []string
+	Required       []string
+	Requested      []string
+	Pools          map[string]string
+	Warnings       []string
+}
+
+// Sources - причины назначения выбранных владельцев кода и указанных автором ревьюверов;
+// указанный автором владелец считается указанным.
+func (s Selection) Sources() map[string]ReviewerSource {
+	if len(s.Owners) == 0 && len(s.Requested) == 0 {
+		return nil
+	}
+
+	sources := make(map[string]ReviewerSource, len(s.Owners)+len(s.Requested))
+	for _, id := range s.Owners {
+		sources[id] = SourceOwner
+	}
+	for _, id := range s.Requested {
+		sources[id] = SourceRequested
+	}
+	return sources
+}
+
 func SelectReviewersForTeam(team *teamdomain.Team, authorID string) []string {
+	return SelectReviewers(team, authorID, SelectOptions{Mode: ModeRandom}).Reviewers
+}
+
+// SelectReviewers выбирает ревьюверов: сначала указанных автором (они занимают места команды)
+// и наставников из правил пар, затем по одному владельцу на каждое правило из opts.Owners,
+// места из пулов политики команды и наконец оставшиеся места команды (по умолчанию два) -
+// среди активных участников, кроме автора и исключённых правилами пар. Если политика требует
+// опытных ревьюверов, а среди уже выбранных их не хватает, места команды в первую очередь
+// отдаются участникам нужного уровня; невыполнимое требование попадает в Warnings.
+// Владельцев может оказаться больше, чем мест команды, если PR затрагивает код нескольких владельцев.
+func SelectReviewers(team *teamdomain.Team, authorID string, opts SelectOptions) Selection {
 	if team == nil {
-		return nil
+		return Selection{}
 	}
 
-	// берём активных членов команды, кроме автора
-	active := team.ActiveMembersExcept(authorID)
-	if len(active) == 0 {
-		return nil
+	var selection Selection
+	selectRequested(&selection, opts.Requested)
+	for _, mentor := range opts.Required {
+		selectRequired(&selection, mentor)
 	}
+	for _, requirement := range opts.Owners {
+		selectOwner(&selection, requirement, authorID, opts)
+	}
+	for _, slot := range opts.Pools {
+		selectFromPool(&selection, slot, authorID, opts)
+	}
+
+	levels := memberLevels(team, opts)
 
-	candidates := make([]string, 0, len(active))
-	for _, m := range active {
-		candidates = append(candidates, m.ID)
+	taken := len(selection.Owners) + len(selection.Required) + len(selection.Requested)
+	if left := opts.teamReviewers() - taken; left > 0 {
+		// берём активных членов команды, кроме автора, уже выбранных и исключённых правилами пар
+		except := append([]string{authorID}, selection.Reviewers...)
+		active := team.ActiveMembersExcept(append(except, opts.Excluded...)...)
+
+		if need := levelShortage(selection.Reviewers, levels, opts); need > 0 {
+			experienced := pick(membersAtLeast(active, opts.MinLevel), min(need, left), opts)
+			selection.add(experienced)
+			left -= len(experienced.Reviewers)
+			active = membersExcept(active, experienced.Reviewers)
+		}
+
+		chosen := pick(active, left, opts)
+		selection.add(chosen)
+
+		if len(chosen.Reviewers) < left {
+			if barred := barredMembers(team.Members, opts, except...); len(barred) > 0 {
+				selection.warn(excludedMessage(barred, authorID))
+			}
+		}
 	}
 
-	if len(candidates) <= 2 {
-		return candidates
+	if need := levelShortage(selection.Reviewers, levels, opts); need > 0 {
+		selection.warn(fmt.Sprintf("policy requires %d reviewer(s) of level %s or higher, %d missing",
+			opts.MinLevelReviewers, opts.MinLevel, need))
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	return selection
+}
+
+// selectOwner добавляет в selection одного доступного владельца правила, если правило
+// ещё не закрыто уже выбранными ревьюверами. Недоступные явные владельцы пропускаются
+// с предупреждением.
+func selectOwner(selection *Selection, requirement OwnerRequirement, authorID string, opts SelectOptions) {
+	for _, m := range requirement.Users {
+		if !m.Available() {
+			selection.warn(fmt.Sprintf("owner %s of %q is inactive or out of office, skipped", m.ID, requirement.Pattern))
+		}
+	}
+
+	owners := make([]teamdomain.TeamMember, 0, len(requirement.Users)+len(requirement.TeamMembers))
+	owners = append(owners, requirement.Users...)
+	owners = append(owners, requirement.TeamMembers...)
+
+	for _, m := range owners {
+		if slices.Contains(selection.Reviewers, m.ID) {
+			return
+		}
+	}
+
+	seen := make(map[string]struct{}, len(owners))
+	candidates := make([]teamdomain.TeamMember, 0, len(owners))
+	for _, m := range owners {
+		if _, dup := seen[m.ID]; dup || m.ID == authorID || !m.Available() || slices.Contains(opts.Excluded, m.ID) {
+			continue
+		}
+		seen[m.ID] = struct{}{}
+		candidates = append(candidates, m)
+	}
 
-	return candidates[:2]
+	if len(candidates) == 0 {
+		selection.warn(fmt.Sprintf("no available owner for %q", requirement.Pattern))
+		return
+	}
+
+	owner := pick(candidates, 1, opts)
+	selection.add(owner)
+	selection.Owners = append(selection.Owners, owner.Reviewers...)
+}
+
+// selectFromPool добавляет в selection до slot.Reviewers доступных участников пула,
+// кроме автора и уже выбранных. Если пул не может закрыть места, остаётся предупреждение.
+func selectFromPool(selection *Selection, slot PoolSlot, authorID string, opts SelectOptions) {
+	pool := &teamdomain.Team{Name: slot.Pool, Members: slot.Members}
+	except := append([]string{authorID}, selection.Reviewers...)
+	candidates := pool.ActiveMembersExcept(append(except, opts.Excluded...)...)
+
+	chosen := pick(candidates, slot.Reviewers, opts)
+	if len(chosen.Reviewers) < slot.Reviewers {
+		selection.warn(fmt.Sprintf("pool %q has %d of %d available reviewers",
+			slot.Pool, len(chosen.Reviewers), slot.Reviewers))
+	}
+
+	selection.add(chosen)
+	for _, id := range chosen.Reviewers {
+		if selection.Pools == nil {
+			selection.Pools = make(map[string]string)
+		}
+		selection.Pools[id] = slot.Pool
+	}
+}
+
+// add дописывает к выбору результат pick.
+func (s *Selection) add(other Selection) {
+	s.Reviewers = append(s.Reviewers, other.Reviewers...)
+	s.InWorkingHours = append(s.InWorkingHours, other.InWorkingHours...)
+	s.TagMatched = append(s.TagMatched, other.TagMatched...)
+}
+
+func (s *Selection) warn(message string) {
+	if !slices.Contains(s.Warnings, message) {
+		s.Warnings = append(s.Warnings, message)
+	}
 }
 
 func ReassignReviewer(pr *PullRequest, team *teamdomain.Team, oldReviewerID string) (string, error) {
+	selection, err := ReassignReviewerWith(pr, team, oldReviewerID, SelectOptions{Mode: ModeRandom})
+	if err != nil {
+		return "", err
+	}
+	return selection.Reviewers[0], nil
+}
+
+// ReassignReviewerWith заменяет oldReviewerID одним участником team (команды или пула,
+// из которого он был назначен) с учётом режима выбора. Если теги в opts не заданы,
+// используются теги PR. Доступного наставника из правил пар заменить нельзя, а исключённые
+// правилами участники не рассматриваются; если из-за них не осталось кандидатов, это видно
+// в тексте ошибки. В Selection.Reviewers возвращается ровно один новый ревьювер.
+func ReassignReviewerWith(pr *PullRequest, team *teamdomain.Team, oldReviewerID string,
+	opts SelectOptions) (Selection, error) {
+
 	if pr == nil || team == nil {
-		return "", apperr.ErrNoCandidate
+		return Selection{}, apperr.ErrNoCandidate
 	}
 
 	// на уже слитых PR переставлять ревьюера нельзя
 	if err := pr.CanReassign(); err != nil {
-		return "", err
+		return Selection{}, err
 	}
 
 	reviewerSet := make(map[string]struct{}, len(pr.AssignedReviewers))
@@ -52,38 +284,161 @@ func ReassignReviewer(pr *PullRequest, team *teamdomain.Team, oldReviewerID stri
 	}
 
 	if _, ok := reviewerSet[oldReviewerID]; !ok {
-		return "", apperr.ErrNotAssigned
+		return Selection{}, apperr.ErrNotAssigned
 	}
 
-	// ищем активных кандидатов вместо старого ревьюера
-	activeMembers := team.ActiveMembersExcept(oldReviewerID)
+	if err := CheckRemovable(pr.AuthorId, opts, oldReviewerID); err != nil {
+		return Selection{}, err
+	}
+
+	// ищем активных кандидатов вместо старого ревьюера; автор может оказаться в пуле
+	activeMembers := team.ActiveMembersExcept(oldReviewerID, pr.AuthorId)
 
-	candidates := make([]string, 0, len(activeMembers))
+	candidates := make([]teamdomain.TeamMember, 0, len(activeMembers))
 	for _, member := range activeMembers {
 		if _, alreadyAssigned := reviewerSet[member.ID]; alreadyAssigned {
 			continue
 		}
-		candidates = append(candidates, member.ID)
+		if slices.Contains(opts.Excluded, member.ID) {
+			continue
+		}
+		candidates = append(candidates, member)
 	}
 
 	if len(candidates) == 0 {
-		return "", apperr.ErrNoCandidate
+		if barred := barredMembers(team.Members, opts, pr.AssignedReviewers...); len(barred) > 0 {
+			return Selection{}, fmt.Errorf("%w: %s", apperr.ErrNoCandidate, excludedMessage(barred, pr.AuthorId))
+		}
+		return Selection{}, apperr.ErrNoCandidate
 	}
 
-	newReviewerID := pickRandomFrom(candidates)
+	// замена не должна нарушать выполненное требование политики к уровню
+	if opts.MinLevelReviewers > 0 {
+		levels := memberLevels(team, opts)
+		remaining := slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(id string) bool {
+			return id == oldReviewerID
+		})
 
-	if err := pr.ReplaceReviewer(oldReviewerID, newReviewerID); err != nil {
-		return "", err
+		if levelShortage(remaining, levels, opts) > 0 {
+			experienced := membersAtLeast(candidates, opts.MinLevel)
+			switch {
+			case len(experienced) > 0:
+				candidates = experienced
+			case levelShortage(pr.AssignedReviewers, levels, opts) == 0:
+				return Selection{}, fmt.Errorf("%w: no available reviewer of level %s or higher to replace %s",
+					apperr.ErrLevelPolicy, opts.MinLevel, oldReviewerID)
+			}
+		}
+	}
+
+	if len(opts.Tags) == 0 {
+		opts.Tags = pr.Tags
+	}
+	selection := pick(candidates, 1, opts)
+
+	if err := pr.ReplaceReviewer(oldReviewerID, selection.Reviewers[0]); err != nil {
+		return Selection{}, err
 	}
 
-	return newReviewerID, nil
+	return selection, nil
 }
 
-func pickRandomFrom(candidates []string) string {
-	if len(candidates) == 0 {
-		return ""
+// pick выбирает до n участников. Если у PR есть теги, участники делятся на группы по числу
+// совпавших тегов и места заполняются начиная с группы с наибольшим счётом; внутри группы
+// и среди участников без совпадений выбор делает pickByMode.
+func pick(members []teamdomain.TeamMember, n int, opts SelectOptions) Selection {
+	if len(opts.Tags) == 0 {
+		return pickByMode(members, n, opts)
+	}
+
+	groups := make(map[int][]teamdomain.TeamMember)
+	scores := make([]int, 0)
+	for _, m := range members {
+		score := userdomain.CountCommonTags(m.Tags, opts.Tags)
+		if _, ok := groups[score]; !ok {
+			scores = append(scores, score)
+		}
+		groups[score] = append(groups[score], m)
+	}
+	slices.Sort(scores)
+	slices.Reverse(scores)
+
+	var selection Selection
+	for _, score := range scores {
+		left := n - len(selection.Reviewers)
+		if left <= 0 {
+			break
+		}
+
+		chosen := pickByMode(groups[score], left, opts)
+		if score > 0 {
+			chosen.TagMatched = slices.Clone(chosen.Reviewers)
+		}
+		selection.add(chosen)
+	}
+
+	return selection
+}
+
+// pickByMode выбирает до n участников. В режиме working_hours сначала случайно выбираются
+// участники в рабочем окне, оставшиеся места заполняются случайно из остальных. В режиме
+// rotation выбор случайный, но недавние ревьюверы автора выпадают реже.
+func pickByMode(members []teamdomain.TeamMember, n int, opts SelectOptions) Selection {
+	var selection Selection
+
+	if opts.Mode == ModeRotation {
+		selection.Reviewers = pickWeightedN(members, rotationWeights(members, opts), n, opts.random())
+		return selection
+	}
+
+	r := opts.random()
+
+	if opts.Mode != ModeWorkingHours {
+		selection.Reviewers = pickRandomN(memberIDs(members), n, r)
+		return selection
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var working, rest []teamdomain.TeamMember
+	for _, m := range members {
+		if m.WorkingHours.Contains(now) {
+			working = append(working, m)
+		} else {
+			rest = append(rest, m)
+		}
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return candidates[r.Intn(len(candidates))]
+	selection.InWorkingHours = pickRandomN(memberIDs(working), n, r)
+	selection.Reviewers = append(selection.Reviewers, selection.InWorkingHours...)
+
+	if left := n - len(selection.Reviewers); left > 0 {
+		selection.Reviewers = append(selection.Reviewers, pickRandomN(memberIDs(rest), left, r)...)
+	}
+
+	return selection
+}
+
+func memberIDs(members []teamdomain.TeamMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+// pickRandomN возвращает до n случайных кандидатов; если их не больше n, берутся все.
+func pickRandomN(candidates []string, n int, r *rand.Rand) []string {
+	if len(candidates) <= n {
+		return candidates
+	}
+
+	r.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	return candidates[:n]
 }
@@ -2,9 +2,11 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
 	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -208,3 +210,551 @@ func TestReassignReviewer(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectReviewers_WorkingHours(t *testing.T) {
+	t.Parallel()
+
+	// 16:30 UTC: в Москве рабочий день закончился, в Белграде ещё идёт
+	now := time.Date(2025, 12, 5, 16, 30, 0, 0, time.UTC)
+	moscow := &userdomain.WorkingHours{Timezone: "Europe/Moscow", Start: "09:00", End: "18:00"}
+	belgrade := &userdomain.WorkingHours{Timezone: "Europe/Belgrade", Start: "09:00", End: "18:00"}
+
+	tests := []struct {
+		name              string
+		members           []teamdomain.TeamMember
+		mode              AssignmentMode
+		wantReviewers     []string
+		wantWorkingHours  []string
+		wantReviewerCount int
+	}{
+		{
+			name: "prefers_members_in_working_hours",
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true, WorkingHours: moscow},
+				{ID: "u3", IsActive: true, WorkingHours: belgrade},
+				{ID: "u4", IsActive: true, WorkingHours: belgrade},
+			},
+			mode:              ModeWorkingHours,
+			wantReviewers:     []string{"u3", "u4"},
+			wantWorkingHours:  []string{"u3", "u4"},
+			wantReviewerCount: 2,
+		},
+		{
+			name: "fills_remaining_slot_from_others",
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true, WorkingHours: moscow},
+				{ID: "u3", IsActive: true, WorkingHours: belgrade},
+			},
+			mode:              ModeWorkingHours,
+			wantReviewers:     []string{"u3", "u2"},
+			wantWorkingHours:  []string{"u3"},
+			wantReviewerCount: 2,
+		},
+		{
+			name: "falls_back_to_everyone",
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true, WorkingHours: moscow},
+				{ID: "u3", IsActive: true},
+			},
+			mode:              ModeWorkingHours,
+			wantReviewers:     []string{"u2", "u3"},
+			wantReviewerCount: 2,
+		},
+		{
+			name: "random_mode_ignores_hours",
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true, WorkingHours: belgrade},
+			},
+			mode:              ModeRandom,
+			wantReviewers:     []string{"u2"},
+			wantReviewerCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			team := &teamdomain.Team{Members: tt.members}
+			got := SelectReviewers(team, "u1", SelectOptions{Mode: tt.mode, Now: now})
+
+			assert.Len(t, got.Reviewers, tt.wantReviewerCount)
+			assert.ElementsMatch(t, tt.wantReviewers, got.Reviewers)
+			assert.ElementsMatch(t, tt.wantWorkingHours, got.InWorkingHours)
+		})
+	}
+}
+
+func TestReassignReviewerWith_WorkingHours(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 5, 16, 30, 0, 0, time.UTC)
+	pr := &PullRequest{Status: StatusOpen, AssignedReviewers: []string{"u2"}}
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true, WorkingHours: &userdomain.WorkingHours{Timezone: "Europe/Moscow", Start: "09:00", End: "18:00"}},
+			{ID: "u4", IsActive: true, WorkingHours: &userdomain.WorkingHours{Timezone: "Europe/Belgrade", Start: "09:00", End: "18:00"}},
+		},
+	}
+
+	got, err := ReassignReviewerWith(pr, team, "u2", SelectOptions{Mode: ModeWorkingHours, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, got.Reviewers)
+	assert.Equal(t, []string{"u4"}, got.InWorkingHours)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+}
+
+func TestSelectReviewers_CodeOwners(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		owners       []OwnerRequirement
+		wantOwners   []string
+		wantContains []string
+		wantLen      int
+		wantWarnings int
+	}{
+		{
+			name: "owner_first_then_random_slot",
+			owners: []OwnerRequirement{
+				{Pattern: "/migrator/", Users: []teamdomain.TeamMember{{ID: "dba", IsActive: true}}},
+			},
+			wantOwners:   []string{"dba"},
+			wantContains: []string{"dba"},
+			wantLen:      2,
+		},
+		{
+			name: "inactive_owner_is_skipped_with_warning",
+			owners: []OwnerRequirement{
+				{Pattern: "/migrator/", Users: []teamdomain.TeamMember{
+					{ID: "dba", IsActive: false},
+					{ID: "dba2", IsActive: true, OutOfOffice: true},
+				}},
+			},
+			wantLen:      2,
+			wantWarnings: 3,
+		},
+		{
+			name: "rule_already_covered_by_previous_owner",
+			owners: []OwnerRequirement{
+				{Pattern: "*.go", TeamMembers: []teamdomain.TeamMember{{ID: "p1", IsActive: true}}},
+				{Pattern: "/internal/", Users: []teamdomain.TeamMember{{ID: "p1", IsActive: true}}},
+			},
+			wantOwners:   []string{"p1"},
+			wantContains: []string{"p1"},
+			wantLen:      2,
+		},
+		{
+			name: "author_is_not_own_owner",
+			owners: []OwnerRequirement{
+				{Pattern: "*.go", Users: []teamdomain.TeamMember{{ID: "u1", IsActive: true}}},
+			},
+			wantLen:      2,
+			wantWarnings: 1,
+		},
+		{
+			name: "more_owners_than_slots",
+			owners: []OwnerRequirement{
+				{Pattern: "/db/", Users: []teamdomain.TeamMember{{ID: "o1", IsActive: true}}},
+				{Pattern: "/api/", Users: []teamdomain.TeamMember{{ID: "o2", IsActive: true}}},
+				{Pattern: "/ui/", Users: []teamdomain.TeamMember{{ID: "o3", IsActive: true}}},
+			},
+			wantOwners:   []string{"o1", "o2", "o3"},
+			wantContains: []string{"o1", "o2", "o3"},
+			wantLen:      3,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SelectReviewers(team, "u1", SelectOptions{Mode: ModeRandom, Owners: tt.owners})
+
+			assert.Len(t, got.Reviewers, tt.wantLen)
+			assert.ElementsMatch(t, tt.wantOwners, got.Owners)
+			for _, id := range tt.wantContains {
+				assert.Contains(t, got.Reviewers, id)
+			}
+			assert.NotContains(t, got.Reviewers, "u1")
+			assert.Len(t, got.Warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestSelectReviewers_Tags(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true, Tags: []string{"go", "postgres"}},
+			{ID: "u2", IsActive: true, Tags: []string{"go"}},
+			{ID: "u3", IsActive: true, Tags: []string{"go", "postgres"}},
+			{ID: "u4", IsActive: true, Tags: []string{"frontend"}},
+			{ID: "u5", IsActive: false, Tags: []string{"go", "postgres"}},
+			{ID: "u6", IsActive: true},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		tags           []string
+		wantReviewers  []string
+		wantTagMatched []string
+		wantContains   []string
+	}{
+		{
+			name:           "best_score_first",
+			tags:           []string{"go", "postgres"},
+			wantReviewers:  []string{"u3", "u2"},
+			wantTagMatched: []string{"u3", "u2"},
+		},
+		{
+			name:           "fallback_to_random_for_free_slot",
+			tags:           []string{"frontend"},
+			wantTagMatched: []string{"u4"},
+			wantContains:   []string{"u4"},
+		},
+		{
+			name: "no_matches_is_random",
+			tags: []string{"ios"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SelectReviewers(team, "u1", SelectOptions{Mode: ModeRandom, Tags: tt.tags})
+
+			require.Len(t, got.Reviewers, 2)
+			if tt.wantReviewers != nil {
+				assert.Equal(t, tt.wantReviewers, got.Reviewers)
+			}
+			assert.Equal(t, tt.wantTagMatched, got.TagMatched)
+			for _, id := range tt.wantContains {
+				assert.Contains(t, got.Reviewers, id)
+			}
+			assert.NotContains(t, got.Reviewers, "u1")
+			assert.NotContains(t, got.Reviewers, "u5")
+		})
+	}
+}
+
+func TestReassignReviewerWith_UsesPRTags(t *testing.T) {
+	t.Parallel()
+
+	pr := &PullRequest{Status: StatusOpen, AssignedReviewers: []string{"u2"}, Tags: []string{"postgres"}}
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u2", IsActive: true, Tags: []string{"postgres"}},
+			{ID: "u3", IsActive: true, Tags: []string{"go"}},
+			{ID: "u4", IsActive: true, Tags: []string{"postgres", "go"}},
+			{ID: "u5", IsActive: true},
+		},
+	}
+
+	got, err := ReassignReviewerWith(pr, team, "u2", SelectOptions{Mode: ModeRandom})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, got.Reviewers)
+	assert.Equal(t, []string{"u4"}, got.TagMatched)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+}
+
+func TestSelectReviewers_Pools(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+	security := PoolSlot{
+		Pool:      "security",
+		Reviewers: 1,
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "s1", IsActive: true},
+			{ID: "s2", IsActive: false},
+		},
+	}
+
+	t.Run("team_plus_pool", func(t *testing.T) {
+		t.Parallel()
+
+		got := SelectReviewers(team, "u1", SelectOptions{TeamReviewers: 1, Pools: []PoolSlot{security}})
+
+		require.Len(t, got.Reviewers, 2)
+		assert.Equal(t, "s1", got.Reviewers[0])
+		assert.Contains(t, []string{"u2", "u3"}, got.Reviewers[1])
+		assert.Equal(t, map[string]string{"s1": "security"}, got.Pools)
+		assert.Empty(t, got.Warnings)
+	})
+
+	t.Run("only_pool", func(t *testing.T) {
+		t.Parallel()
+
+		got := SelectReviewers(team, "u1", SelectOptions{Pools: []PoolSlot{security}})
+
+		assert.Equal(t, []string{"s1"}, got.Reviewers)
+	})
+
+	t.Run("pool_without_available_reviewers", func(t *testing.T) {
+		t.Parallel()
+
+		empty := PoolSlot{Pool: "platform", Reviewers: 1, Members: []teamdomain.TeamMember{{ID: "p1", IsActive: false}}}
+		got := SelectReviewers(team, "u1", SelectOptions{TeamReviewers: 1, Pools: []PoolSlot{empty}})
+
+		require.Len(t, got.Reviewers, 1)
+		assert.Nil(t, got.Pools)
+		require.Len(t, got.Warnings, 1)
+		assert.Contains(t, got.Warnings[0], "platform")
+	})
+}
+
+func TestReassignReviewerWith_Pool(t *testing.T) {
+	t.Parallel()
+
+	pr := &PullRequest{
+		AuthorId:          "u1",
+		Status:            StatusOpen,
+		AssignedReviewers: []string{"u2", "s1"},
+		ReviewerPools:     map[string]string{"s1": "security"},
+	}
+	pool := &teamdomain.Team{
+		Name: "security",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "s1", IsActive: true},
+			{ID: "s2", IsActive: true},
+		},
+	}
+
+	got, err := ReassignReviewerWith(pr, pool, "s1", SelectOptions{Mode: ModeRandom})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s2"}, got.Reviewers)
+	assert.Equal(t, []string{"u2", "s2"}, pr.AssignedReviewers)
+	assert.Equal(t, map[string]string{"s2": "security"}, pr.ReviewerPools)
+}
+
+func TestSelectReviewers_MinLevel(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+			{ID: "u3", IsActive: true, Level: userdomain.LevelJunior},
+			{ID: "u4", IsActive: true, Level: userdomain.LevelLead},
+			{ID: "u5", IsActive: false, Level: userdomain.LevelSenior},
+		},
+	}
+
+	t.Run("senior_first", func(t *testing.T) {
+		t.Parallel()
+
+		for range 20 {
+			got := SelectReviewers(team, "u1", SelectOptions{
+				MinLevel:          userdomain.LevelSenior,
+				MinLevelReviewers: 1,
+			})
+			require.Len(t, got.Reviewers, 2)
+			assert.Equal(t, "u4", got.Reviewers[0])
+			assert.Empty(t, got.Warnings)
+		}
+	})
+
+	t.Run("not_enough_seniors", func(t *testing.T) {
+		t.Parallel()
+
+		got := SelectReviewers(team, "u1", SelectOptions{
+			MinLevel:          userdomain.LevelSenior,
+			MinLevelReviewers: 2,
+		})
+		require.Len(t, got.Reviewers, 2)
+		assert.Contains(t, got.Reviewers, "u4")
+		assert.Equal(t, []string{"policy requires 2 reviewer(s) of level senior or higher, 1 missing"}, got.Warnings)
+	})
+}
+
+func TestReassignReviewerWith_MinLevel(t *testing.T) {
+	t.Parallel()
+
+	newPR := func() *PullRequest {
+		return &PullRequest{AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u4"}}
+	}
+	opts := SelectOptions{Mode: ModeRandom, MinLevel: userdomain.LevelSenior, MinLevelReviewers: 1}
+
+	t.Run("keeps_senior", func(t *testing.T) {
+		t.Parallel()
+
+		team := &teamdomain.Team{
+			Members: []teamdomain.TeamMember{
+				{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+				{ID: "u3", IsActive: true, Level: userdomain.LevelMiddle},
+				{ID: "u4", IsActive: true, Level: userdomain.LevelSenior},
+				{ID: "u5", IsActive: true, Level: userdomain.LevelLead},
+			},
+		}
+
+		pr := newPR()
+		got, err := ReassignReviewerWith(pr, team, "u4", opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u5"}, got.Reviewers)
+		assert.Equal(t, []string{"u2", "u5"}, pr.AssignedReviewers)
+	})
+
+	t.Run("no_senior_left", func(t *testing.T) {
+		t.Parallel()
+
+		team := &teamdomain.Team{
+			Members: []teamdomain.TeamMember{
+				{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+				{ID: "u3", IsActive: true, Level: userdomain.LevelMiddle},
+				{ID: "u4", IsActive: true, Level: userdomain.LevelSenior},
+			},
+		}
+
+		pr := newPR()
+		_, err := ReassignReviewerWith(pr, team, "u4", opts)
+		require.ErrorIs(t, err, apperr.ErrLevelPolicy)
+		assert.Equal(t, []string{"u2", "u4"}, pr.AssignedReviewers)
+	})
+
+	t.Run("junior_replaced_freely", func(t *testing.T) {
+		t.Parallel()
+
+		team := &teamdomain.Team{
+			Members: []teamdomain.TeamMember{
+				{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+				{ID: "u3", IsActive: true, Level: userdomain.LevelMiddle},
+				{ID: "u4", IsActive: true, Level: userdomain.LevelSenior},
+			},
+		}
+
+		got, err := ReassignReviewerWith(newPR(), team, "u2", opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"u3"}, got.Reviewers)
+	})
+}
+
+func TestCheckRemovableLevel(t *testing.T) {
+	t.Parallel()
+
+	pr := &PullRequest{AuthorId: "u1", Status: StatusOpen, AssignedReviewers: []string{"u2", "u4", "u5"}}
+	opts := SelectOptions{
+		MinLevel:          userdomain.LevelSenior,
+		MinLevelReviewers: 2,
+		AssignedLevels: map[string]userdomain.Level{
+			"u2": userdomain.LevelJunior,
+			"u4": userdomain.LevelSenior,
+			"u5": userdomain.LevelLead,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		reviewerID string
+		opts       SelectOptions
+		wantErr    error
+	}{
+		{name: "junior_removed", reviewerID: "u2", opts: opts},
+		{name: "senior_needed", reviewerID: "u4", opts: opts, wantErr: apperr.ErrLevelPolicy},
+		{name: "no_policy", reviewerID: "u4", opts: SelectOptions{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckRemovableLevel(pr, tt.reviewerID, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckLevelChange(t *testing.T) {
+	t.Parallel()
+
+	opts := SelectOptions{
+		MinLevel:          userdomain.LevelSenior,
+		MinLevelReviewers: 2,
+		AssignedLevels: map[string]userdomain.Level{
+			"u2": userdomain.LevelJunior,
+			"u3": userdomain.LevelMiddle,
+			"u4": userdomain.LevelSenior,
+			"u5": userdomain.LevelLead,
+			"u6": userdomain.LevelSenior,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		before  []string
+		after   []string
+		opts    SelectOptions
+		wantErr error
+	}{
+		{name: "senior_replaced_by_senior", before: []string{"u2", "u4"}, after: []string{"u2", "u6"}, opts: opts},
+		{name: "junior_replaced", before: []string{"u2", "u4", "u5"}, after: []string{"u3", "u4", "u5"}, opts: opts},
+		{name: "shortage_not_worse", before: []string{"u2"}, after: []string{"u3"}, opts: opts},
+		{name: "senior_dropped", before: []string{"u4", "u5"}, after: []string{"u2", "u5"}, opts: opts, wantErr: apperr.ErrLevelPolicy},
+		{name: "all_cleared", before: []string{"u4"}, after: []string{}, opts: opts, wantErr: apperr.ErrLevelPolicy},
+		{name: "no_policy", before: []string{"u4"}, after: []string{}, opts: SelectOptions{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckLevelChange(tt.before, tt.after, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSelection_Sources(t *testing.T) {
+	t.Parallel()
+
+	selection := Selection{
+		Reviewers: []string{"u2", "u3", "u4", "u5"},
+		Owners:    []string{"u3", "u4"},
+		Requested: []string{"u2", "u4"},
+	}
+
+	assert.Equal(t, map[string]ReviewerSource{
+		"u2": SourceRequested,
+		"u3": SourceOwner,
+		"u4": SourceRequested,
+	}, selection.Sources())
+	assert.Nil(t, Selection{Reviewers: []string{"u2"}}.Sources())
+}
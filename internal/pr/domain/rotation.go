@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// rotationWeights - веса участников в режиме rotation. Каждый недавний PR автора, где
+// участник был ревьювером, добавляет штраф decay^i (i = 0 для самого нового PR), вес
+// равен 1 / (1 + штраф). Участники без недавних пар получают вес 1.
+func rotationWeights(members []teamdomain.TeamMember, opts SelectOptions) []float64 {
+	decay := opts.RotationDecay
+	if decay <= 0 {
+		decay = teamdomain.DefaultRotationDecay
+	}
+
+	penalty := make(map[string]float64)
+	for i, reviewers := range opts.RecentReviewers {
+		for _, id := range reviewers {
+			penalty[id] += math.Pow(decay, float64(i))
+		}
+	}
+
+	weights := make([]float64, 0, len(members))
+	for _, m := range members {
+		weights = append(weights, 1/(1+penalty[m.ID]))
+	}
+	return weights
+}
+
+// pickWeightedN выбирает до n участников без повторов с вероятностью, пропорциональной весу
+// (алгоритм Efraimidis-Spirakis: ключ u^(1/w), берутся n наибольших ключей).
+func pickWeightedN(members []teamdomain.TeamMember, weights []float64, n int, r *rand.Rand) []string {
+	if len(members) <= n {
+		return memberIDs(members)
+	}
+
+	type keyed struct {
+		id  string
+		key float64
+	}
+	keys := make([]keyed, 0, len(members))
+	for i, m := range members {
+		keys = append(keys, keyed{id: m.ID, key: math.Pow(r.Float64(), 1/weights[i])})
+	}
+	slices.SortFunc(keys, func(a, b keyed) int {
+		switch {
+		case a.key > b.key:
+			return -1
+		case a.key < b.key:
+			return 1
+		}
+		return 0
+	})
+
+	ids := make([]string, 0, n)
+	for _, k := range keys[:n] {
+		ids = append(ids, k.id)
+	}
+	return ids
+}
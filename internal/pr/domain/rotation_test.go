@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotationWeights(t *testing.T) {
+	t.Parallel()
+
+	members := []teamdomain.TeamMember{{ID: "u2"}, {ID: "u3"}, {ID: "u4"}}
+	opts := SelectOptions{
+		Mode:            ModeRotation,
+		RecentReviewers: [][]string{{"u2", "u3"}, {"u2"}, {"u3"}},
+		RotationDecay:   0.5,
+	}
+
+	got := rotationWeights(members, opts)
+
+	require.Len(t, got, 3)
+	// u2: 1 + 0.5, u3: 1 + 0.25, u4 без пар
+	assert.InDelta(t, 1/2.5, got[0], 1e-9)
+	assert.InDelta(t, 1/2.25, got[1], 1e-9)
+	assert.InDelta(t, 1.0, got[2], 1e-9)
+}
+
+func TestSelectReviewers_Rotation(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+	opts := SelectOptions{
+		Mode:            ModeRotation,
+		TeamReviewers:   1,
+		RecentReviewers: [][]string{{"u2"}, {"u2"}, {"u2"}, {"u2"}, {"u2"}},
+		RotationDecay:   1,
+	}
+
+	// у u2 вес 1/6, у u3 - 1: u3 должен выпадать примерно в 6 случаях из 7
+	counts := make(map[string]int)
+	for range 1000 {
+		got := SelectReviewers(team, "u1", opts)
+		require.Len(t, got.Reviewers, 1)
+		counts[got.Reviewers[0]]++
+	}
+
+	assert.Greater(t, counts["u3"], 750)
+	assert.Positive(t, counts["u2"])
+}
+
+func TestSelectReviewers_RotationWithoutHistory(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+
+	got := SelectReviewers(team, "u1", SelectOptions{Mode: ModeRotation})
+	assert.ElementsMatch(t, []string{"u2", "u3"}, got.Reviewers)
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+// AddReviewerRequest - ReviewerID пустой, если ревьювера нужно выбрать автоматически.
+type AddReviewerRequest struct {
+	PrID       string `json:"pull_request_id" validate:"required"`
+	ReviewerID string `json:"reviewer_id"`
+	// AssignmentMode - режим автоматического выбора, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
+}
+
+type AddReviewerResponse struct {
+	PullRequest domain.PullRequest `json:"pr"`
+	Added       string             `json:"added"`
+	// AutoPicked - ревьювер выбран автоматически, а не указан в запросе.
+	AutoPicked bool `json:"auto_picked"`
+	// Warnings - удалённые пулы политики и пулы без доступных ревьюверов при автоматическом выборе.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type RemoveReviewerRequest struct {
+	PrID       string `json:"pull_request_id" validate:"required"`
+	ReviewerID string `json:"reviewer_id" validate:"required"`
+}
+
+type RemoveReviewerResponse struct {
+	PullRequest domain.PullRequest `json:"pr"`
+	Removed     string             `json:"removed"`
+}
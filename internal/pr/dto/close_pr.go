@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type ClosePRRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+}
+
+// ClosePRResponse - FreedReviewers пустой, если PR уже не был открыт.
+type ClosePRResponse struct {
+	PullRequest    domain.PullRequest `json:"pr"`
+	FreedReviewers []string           `json:"freed_reviewers"`
+}
+
+type ReopenPRRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+	// AssignmentMode - режим выбора ревьюверов, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
+}
+
+// ReopenPRResponse - Reopened false, если PR уже был открыт.
+type ReopenPRResponse struct {
+	PullRequest domain.PullRequest `json:"pr"`
+	Reopened    bool               `json:"reopened"`
+	Added       []string           `json:"added"`
+	Warnings    []string           `json:"warnings,omitempty"`
+}
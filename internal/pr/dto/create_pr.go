@@ -8,8 +8,26 @@ type CreatePRRequest struct {
 	PrID     string `json:"pull_request_id" validate:"required"`
 	Name     string `json:"pull_request_name" validate:"required"`
 	AuthorId string `json:"author_id" validate:"required"`
+	// AssignmentMode - режим выбора ревьюверов, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
+	// ChangedPaths - изменённые файлы; по ним сначала назначаются владельцы кода команды.
+	ChangedPaths []string `json:"changed_paths" validate:"omitempty,max=5000,dive,required"`
+	// Tags - метки PR; ревьюверы с такими же тегами выбираются в первую очередь.
+	Tags []string `json:"tags" validate:"max=32"`
+	// RequestedReviewers - ревьюверы, которых автор выбрал сам; случайно заполняются только оставшиеся места.
+	RequestedReviewers []string `json:"requested_reviewers" validate:"omitempty,max=10,unique,dive,required"`
 }
 
 type CreatePRResponse struct {
 	PullRequest domain.PullRequest `json:"pr"`
+	// WorkingHoursReviewers - ревьюверы, выбранные потому, что у них сейчас рабочее время.
+	WorkingHoursReviewers []string `json:"working_hours_reviewers,omitempty"`
+	// TagMatchedReviewers - ревьюверы, у которых совпал хотя бы один тег PR.
+	TagMatchedReviewers []string `json:"tag_matched_reviewers,omitempty"`
+	// CodeOwners - ревьюверы, назначенные как владельцы изменённого кода.
+	CodeOwners []string `json:"code_owners,omitempty"`
+	// RequestedReviewers - назначенные по запросу автора.
+	RequestedReviewers []string `json:"requested_reviewers,omitempty"`
+	// Warnings - пропущенные владельцы и правила, для которых не нашлось доступного ревьювера.
+	Warnings []string `json:"warnings,omitempty"`
 }
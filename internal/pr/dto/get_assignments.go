@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type GetAssignmentsRequest struct {
+	PrID string `query:"pull_request_id" validate:"required"`
+}
+
+// AssignmentDecision - запись о выборе и результат его повторения по сохранённому зерну.
+type AssignmentDecision struct {
+	domain.Decision
+	ReplayMatches bool `json:"replay_matches"`
+}
+
+type GetAssignmentsResponse struct {
+	PrID      string               `json:"pull_request_id"`
+	Decisions []AssignmentDecision `json:"decisions"`
+}
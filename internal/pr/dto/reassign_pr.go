@@ -7,9 +7,15 @@ import (
 type ReassignPRRequest struct {
 	PrID          string `json:"pull_request_id" validate:"required"`
 	OldReviewerId string `json:"old_reviewer_id" validate:"required"`
+	// AssignmentMode - режим выбора замены, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
 }
 
 type ReassignPRResponse struct {
 	PullRequest domain.PullRequest `json:"pr"`
 	ReplacedBy  string             `json:"replaced_by"`
+	// WorkingHoursReviewers - содержит ReplacedBy, если замена выбрана по рабочему времени.
+	WorkingHoursReviewers []string `json:"working_hours_reviewers,omitempty"`
+	// TagMatchedReviewers - содержит ReplacedBy, если у замены совпал тег PR.
+	TagMatchedReviewers []string `json:"tag_matched_reviewers,omitempty"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type RebalancePRRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+	// AssignmentMode - режим выбора замен, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
+}
+
+// RebalancePRResponse - Before и After - ревьюверы PR до и после ребалансировки, Changes -
+// снятые ревьюверы с причиной и заменой. Пустой Changes - все ревьюверы на месте.
+type RebalancePRResponse struct {
+	PullRequest domain.PullRequest      `json:"pr"`
+	Before      []string                `json:"before"`
+	After       []string                `json:"after"`
+	Changes     []domain.ReviewerChange `json:"changes"`
+	Warnings    []string                `json:"warnings,omitempty"`
+}
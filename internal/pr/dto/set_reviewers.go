@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+// SetReviewersRequest - полный новый список ревьюверов PR. Пустой список не принимается:
+// снять ревьювера без замены можно через /pullRequest/removeReviewer.
+type SetReviewersRequest struct {
+	PrID      string   `json:"pull_request_id" validate:"required"`
+	Reviewers []string `json:"reviewers" validate:"required,min=1,max=10,unique,dive,required"`
+}
+
+type SetReviewersResponse struct {
+	PullRequest domain.PullRequest `json:"pr"`
+	// Added и Removed - кто назначен и кто снят по сравнению с прежним списком.
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type TopUpPRRequest struct {
+	PrID string `json:"pull_request_id" validate:"required"`
+	// AssignmentMode - режим выбора, пустой - из конфигурации.
+	AssignmentMode string `json:"assignment_mode" validate:"omitempty,oneof=random working_hours rotation"`
+}
+
+// TopUpPRResponse - Added пустой, если свободных мест у PR не было.
+type TopUpPRResponse struct {
+	PullRequest domain.PullRequest `json:"pr"`
+	Added       []string           `json:"added"`
+	Warnings    []string           `json:"warnings,omitempty"`
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// AddReviewer добавляет к открытому PR ещё одного ревьювера: указанного в запросе (он
+// проверяется как указанные автором при создании) или выбранного по политике команды автора:
+// на свободное место пула, если оно есть, иначе из команды с учётом требования к грейду.
+// Автоматический выбор записывается в историю решений.
+func (u *PRUsecase) AddReviewer(ctx context.Context,
+	request *dto.AddReviewerRequest) (*dto.AddReviewerResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.AddReviewer")
+	defer span.End()
+
+	var resp *dto.AddReviewerResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.addReviewer(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// addReviewer выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) addReviewer(ctx context.Context,
+	request *dto.AddReviewerRequest) (*dto.AddReviewerResponse, error) {
+
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.AddReviewer: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if err := pr.CanReassign(); err != nil {
+		slog.Info("PRUsecase.AddReviewer: cannot add reviewer",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	author, err := u.userReader.GetUser(ctx, pr.AuthorId)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get author from provider: %w", err)
+	}
+
+	team, err := u.teamReader.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	opts, seed := u.selectOptions(request.AssignmentMode)
+	if _, err := u.applyPairingRules(ctx, pr.AuthorId, team, &opts); err != nil {
+		slog.Error("PRUsecase.AddReviewer: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	reviewerID := request.ReviewerID
+	selected := prdomain.Selection{Reviewers: []string{reviewerID}}
+	var decision *prdomain.Decision
+	var warnings []string
+	if reviewerID != "" {
+		if err := prdomain.CheckRequested(team, pr.AuthorId, []string{reviewerID}, opts.Excluded); err != nil {
+			slog.Info("PRUsecase.AddReviewer: invalid reviewer",
+				slog.String("pr_id", request.PrID),
+				slog.String("reviewer_id", reviewerID),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+	} else {
+		policyWarnings, err := u.applyReviewerPolicy(ctx, team, &opts)
+		if err != nil {
+			slog.Error("PRUsecase.AddReviewer: failed to resolve reviewer pools",
+				slog.String("pr_id", request.PrID),
+				slog.String("team_name", team.Name),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+		warnings = append(warnings, policyWarnings...)
+
+		if err := u.applyRotation(ctx, pr.AuthorId, pr.ID, team.ReviewerPolicy, &opts); err != nil {
+			return nil, err
+		}
+
+		before := prdomain.ClonePR(pr)
+		extra, slots, err := pickExtraReviewer(pr, team, opts)
+		if err != nil {
+			observeSelectionError(err)
+			slog.Info("PRUsecase.AddReviewer: no candidate",
+				slog.String("pr_id", request.PrID),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+		selected = extra.Selection
+		warnings = append(warnings, selected.Warnings...)
+		reviewerID = selected.Reviewers[0]
+		decision = prdomain.NewAddDecision(before, team, slots, seed, extra.Options, selected)
+	}
+
+	// AddSelected сохраняет отметку о пуле, если ревьювер выбран на место пула
+	if err := pr.AddSelected(selected); err != nil {
+		slog.Info("PRUsecase.AddReviewer: cannot add reviewer",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", reviewerID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	if decision == nil {
+		pr.SetSource(reviewerID, prdomain.SourceRequested)
+	}
+
+	updated, err := u.prProvider.UpdatePR(ctx, pr)
+	if err != nil {
+		slog.Error("PRUsecase.AddReviewer: failed to update PR",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", reviewerID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	if decision != nil {
+		if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+			return nil, fmt.Errorf("save assignment decision in provider: %w", err)
+		}
+	}
+
+	events, err := outboxdomain.ReviewersAssignedEvents(*updated, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("build reviewer assigned events: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, events...); err != nil {
+		return nil, fmt.Errorf("append reviewer assigned events: %w", err)
+	}
+
+	slog.Info("PRUsecase.AddReviewer: reviewer added",
+		slog.String("pr_id", updated.ID),
+		slog.String("reviewer_id", reviewerID),
+		slog.Bool("auto_picked", decision != nil),
+	)
+
+	return &dto.AddReviewerResponse{
+		PullRequest: *updated,
+		Added:       reviewerID,
+		AutoPicked:  decision != nil,
+		Warnings:    warnings,
+	}, nil
+}
+
+// extraSelection - выбор дополнительного ревьювера вместе с параметрами, по которым он сделан.
+type extraSelection struct {
+	prdomain.Selection
+	Options prdomain.SelectOptions
+}
+
+// pickExtraReviewer выбирает одного ревьювера сверх назначенных: сначала на первое свободное
+// место пула из политики, а если в пуле никого не нашлось - из команды автора. Возвращает
+// число мест команды, участвовавших в выборе, для истории решений.
+func pickExtraReviewer(pr *prdomain.PullRequest, team *teamdomain.Team,
+	opts prdomain.SelectOptions) (extraSelection, int, error) {
+
+	_, pools := prdomain.MissingSlots(pr, opts)
+	opts.Pools = nil
+
+	var warnings []string
+	if len(pools) > 0 {
+		poolOpts := opts
+		poolOpts.Pools = []prdomain.PoolSlot{pools[0]}
+		poolOpts.Pools[0].Reviewers = 1
+
+		selection, err := prdomain.PickAdditional(pr, team, 0, poolOpts)
+		if err == nil {
+			return extraSelection{Selection: selection, Options: poolOpts}, 0, nil
+		}
+		if !errors.Is(err, apperr.ErrNoCandidate) {
+			return extraSelection{}, 0, err
+		}
+		warnings = append(warnings, fmt.Sprintf("pool %q has 0 of 1 available reviewers", pools[0].Pool))
+	}
+
+	selection, err := prdomain.PickAdditional(pr, team, 1, opts)
+	if err != nil {
+		return extraSelection{}, 0, err
+	}
+	selection.Warnings = append(warnings, selection.Warnings...)
+	return extraSelection{Selection: selection, Options: opts}, 1, nil
+}
+
+// RemoveReviewer снимает ревьювера с открытого PR без замены, например когда команда
+// сократилась. Доступного наставника из правил пар и ревьювера, без которого не выполняется
+// требование политики к грейду, снять нельзя. Снятый ревьювер получает событие.
+func (u *PRUsecase) RemoveReviewer(ctx context.Context,
+	request *dto.RemoveReviewerRequest) (*dto.RemoveReviewerResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.RemoveReviewer")
+	defer span.End()
+
+	var resp *dto.RemoveReviewerResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.removeReviewer(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// removeReviewer выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) removeReviewer(ctx context.Context,
+	request *dto.RemoveReviewerRequest) (*dto.RemoveReviewerResponse, error) {
+
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.RemoveReviewer: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if err := pr.CanReassign(); err != nil {
+		slog.Info("PRUsecase.RemoveReviewer: cannot remove reviewer",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", request.ReviewerID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, request.ReviewerID) {
+		slog.Info("PRUsecase.RemoveReviewer: reviewer not assigned",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", request.ReviewerID),
+		)
+		return nil, apperr.ErrNotAssigned
+	}
+
+	var opts prdomain.SelectOptions
+	if _, err := u.applyPairingRules(ctx, pr.AuthorId, nil, &opts); err != nil {
+		slog.Error("PRUsecase.RemoveReviewer: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if err := prdomain.CheckRemovable(pr.AuthorId, opts, request.ReviewerID); err != nil {
+		slog.Info("PRUsecase.RemoveReviewer: reviewer required by pairing rule",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", request.ReviewerID),
+		)
+		return nil, err
+	}
+
+	if err := u.checkRemovableLevel(ctx, pr, request.ReviewerID); err != nil {
+		slog.Info("PRUsecase.RemoveReviewer: cannot remove reviewer",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", request.ReviewerID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if err := pr.RemoveReviewer(request.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.prProvider.UpdatePR(ctx, pr)
+	if err != nil {
+		slog.Error("PRUsecase.RemoveReviewer: failed to update PR",
+			slog.String("pr_id", request.PrID),
+			slog.String("reviewer_id", request.ReviewerID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	events, err := outboxdomain.ReviewersUnassignedEvents(*updated, request.ReviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("build reviewer unassigned events: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, events...); err != nil {
+		return nil, fmt.Errorf("append reviewer unassigned events: %w", err)
+	}
+
+	slog.Info("PRUsecase.RemoveReviewer: reviewer removed",
+		slog.String("pr_id", updated.ID),
+		slog.String("reviewer_id", request.ReviewerID),
+	)
+
+	return &dto.RemoveReviewerResponse{
+		PullRequest: *updated,
+		Removed:     request.ReviewerID,
+	}, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// ClosePR закрывает открытый PR без слияния (например, закрытый во внешней системе) и снимает
+// с него ревьюверов; каждый снятый получает событие о снятии. Слитый или уже закрытый PR
+// возвращается как есть.
+func (u *PRUsecase) ClosePR(ctx context.Context, request *dto.ClosePRRequest) (*dto.ClosePRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.ClosePR")
+	defer span.End()
+
+	var resp *dto.ClosePRResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := u.prProvider.LockPR(ctx, request.PrID)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				return apperr.ErrNotFound
+			}
+			return fmt.Errorf("lock pull request in provider: %w", err)
+		}
+
+		// повторное закрытие и закрытие слитого PR ничего не меняют
+		if pr.Status != prdomain.StatusOpen {
+			resp = &dto.ClosePRResponse{PullRequest: *pr, FreedReviewers: []string{}}
+			return nil
+		}
+
+		now := time.Now()
+		freed := pr.Close(now)
+
+		updated, err := u.prProvider.ClosePR(ctx, pr.ID, now)
+		if err != nil {
+			return fmt.Errorf("close pull request in provider: %w", err)
+		}
+
+		events, err := outboxdomain.ReviewersUnassignedEvents(*updated, freed...)
+		if err != nil {
+			return fmt.Errorf("build reviewer unassigned events: %w", err)
+		}
+		if len(events) > 0 {
+			if err := u.eventWriter.Append(ctx, events...); err != nil {
+				return fmt.Errorf("append reviewer unassigned events: %w", err)
+			}
+		}
+
+		resp = &dto.ClosePRResponse{PullRequest: *updated, FreedReviewers: freed}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, apperr.ErrNotFound) {
+			slog.Error("PRUsecase.ClosePR: provider error",
+				slog.String("pr_id", request.PrID),
+				slog.Any("error", err),
+			)
+		}
+		return nil, err
+	}
+
+	slog.Info("PRUsecase.ClosePR: pull request closed",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.String("status", string(resp.PullRequest.Status)),
+		slog.Any("freed_reviewers", resp.FreedReviewers),
+	)
+
+	return resp, nil
+}
+
+// ReopenPR снова открывает закрытый PR и доназначает ревьюверов так же, как TopUpPR.
+// Если кандидатов нет, PR всё равно открывается, а в warnings появляется предупреждение.
+// Открытый PR возвращается как есть, слитый - с ErrPRMerged.
+func (u *PRUsecase) ReopenPR(ctx context.Context, request *dto.ReopenPRRequest) (*dto.ReopenPRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.ReopenPR")
+	defer span.End()
+
+	var resp *dto.ReopenPRResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.reopenPR(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("PRUsecase.ReopenPR: pull request reopened",
+		slog.String("pr_id", resp.PullRequest.ID),
+		slog.Bool("reopened", resp.Reopened),
+		slog.Any("added", resp.Added),
+	)
+
+	return resp, nil
+}
+
+// reopenPR выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) reopenPR(ctx context.Context, request *dto.ReopenPRRequest) (*dto.ReopenPRResponse, error) {
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if pr.IsMerged() {
+		return nil, apperr.ErrPRMerged
+	}
+	if !pr.Reopen() {
+		return &dto.ReopenPRResponse{PullRequest: *pr, Added: []string{}}, nil
+	}
+
+	reopened, err := u.prProvider.ReopenPR(ctx, pr.ID)
+	if err != nil {
+		slog.Error("PRUsecase.ReopenPR: failed to reopen PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("reopen pull request in provider: %w", err)
+	}
+
+	topUp, err := u.topUpPR(ctx, &dto.TopUpPRRequest{
+		PrID:           reopened.ID,
+		AssignmentMode: request.AssignmentMode,
+	})
+	if err != nil {
+		if !errors.Is(err, apperr.ErrNoCandidate) {
+			return nil, err
+		}
+		return &dto.ReopenPRResponse{
+			PullRequest: *reopened,
+			Reopened:    true,
+			Added:       []string{},
+			Warnings:    []string{fmt.Sprintf("no reviewers assigned: %v", err)},
+		}, nil
+	}
+
+	return &dto.ReopenPRResponse{
+		PullRequest: topUp.PullRequest,
+		Reopened:    true,
+		Added:       topUp.Added,
+		Warnings:    topUp.Warnings,
+	}, nil
+}
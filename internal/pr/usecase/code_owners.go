@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// ownerRequirements сопоставляет изменённые файлы с правилами владения команды автора
+// и собирает владельцев каждого сработавшего правила. Владельцы из других команд читаются
+// вместе со своей командой, чтобы учесть активность и отсутствие так же, как при обычном выборе.
+// Удалённые пользователи и команды не ломают создание PR, а попадают в предупреждения.
+func (u *PRUsecase) ownerRequirements(ctx context.Context, authorTeam *teamdomain.Team,
+	paths []string) ([]prdomain.OwnerRequirement, []string, error) {
+
+	if len(paths) == 0 {
+		return nil, nil, nil
+	}
+
+	owners, err := u.teamReader.GetCodeOwners(ctx, authorTeam.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get code owners from provider: %w", err)
+	}
+
+	rules := owners.Match(paths)
+	if len(rules) == 0 {
+		return nil, nil, nil
+	}
+
+	getTeam := u.teamGetter(ctx, authorTeam)
+
+	var warnings []string
+	requirements := make([]prdomain.OwnerRequirement, 0, len(rules))
+
+	for _, rule := range rules {
+		requirement := prdomain.OwnerRequirement{Pattern: rule.Pattern}
+
+		for _, userID := range rule.Users {
+			member, err := u.userMember(ctx, userID, getTeam)
+			if err != nil {
+				if errors.Is(err, apperr.ErrNotFound) {
+					warnings = append(warnings, fmt.Sprintf("owner %s of %q not found, skipped", userID, rule.Pattern))
+					continue
+				}
+				return nil, nil, err
+			}
+			requirement.Users = append(requirement.Users, *member)
+		}
+
+		for _, teamName := range rule.Teams {
+			team, err := getTeam(teamName)
+			if err != nil {
+				if errors.Is(err, apperr.ErrNotFound) {
+					warnings = append(warnings, fmt.Sprintf("owner team %s of %q not found, skipped", teamName, rule.Pattern))
+					continue
+				}
+				return nil, nil, fmt.Errorf("get owner team from provider: %w", err)
+			}
+			requirement.TeamMembers = append(requirement.TeamMembers, team.Members...)
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, warnings, nil
+}
+
+// teamGetter читает команды с кешем на время одного запроса; known уже загружены.
+func (u *PRUsecase) teamGetter(ctx context.Context,
+	known ...*teamdomain.Team) func(name string) (*teamdomain.Team, error) {
+
+	teams := make(map[string]*teamdomain.Team, len(known))
+	for _, team := range known {
+		if team != nil {
+			teams[team.Name] = team
+		}
+	}
+
+	return func(name string) (*teamdomain.Team, error) {
+		if team, ok := teams[name]; ok {
+			return team, nil
+		}
+		team, err := u.teamReader.GetTeam(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		teams[name] = team
+		return team, nil
+	}
+}
+
+// userMember читает пользователя как участника его команды, чтобы учесть активность,
+// отсутствие и рабочие часы так же, как при обычном выборе.
+func (u *PRUsecase) userMember(ctx context.Context, userID string,
+	getTeam func(name string) (*teamdomain.Team, error)) (*teamdomain.TeamMember, error) {
+
+	user, err := u.userReader.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get user from provider: %w", err)
+	}
+
+	team, err := getTeam(user.TeamName)
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return nil, fmt.Errorf("get user team from provider: %w", err)
+	}
+	if team != nil {
+		for _, m := range team.Members {
+			if m.ID == userID {
+				return &m, nil
+			}
+		}
+	}
+
+	// пользователь без команды: известна только активность
+	return &teamdomain.TeamMember{ID: user.ID, Name: user.Name, IsActive: user.IsActive}, nil
+}
@@ -6,14 +6,28 @@ import (
 	"log/slog"
 
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
 func (u *PRUsecase) CreatePR(
 	ctx context.Context,
 	request *dto.CreatePRRequest,
 ) (*dto.CreatePRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.CreatePR")
+	defer span.End()
+
+	tags, err := userdomain.NormalizeTags(request.Tags)
+	if err != nil {
+		slog.Info("PRUsecase.CreatePR: invalid tags",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
 
 	// читаем автора PR и его команду
 	author, err := u.userReader.GetUser(ctx, request.AuthorId)
@@ -50,32 +64,131 @@ func (u *PRUsecase) CreatePR(
 		return nil, fmt.Errorf("get team from provider: %w", err)
 	}
 
-	reviewers := prdomain.SelectReviewersForTeam(team, author.ID)
+	opts, seed := u.selectOptions(request.AssignmentMode)
+
+	owners, warnings, err := u.ownerRequirements(ctx, team, request.ChangedPaths)
+	if err != nil {
+		slog.Error("PRUsecase.CreatePR: failed to resolve code owners",
+			slog.String("pr_id", request.PrID),
+			slog.String("team_name", team.Name),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	opts.Owners = owners
+	opts.Tags = tags
+
+	poolWarnings, err := u.applyReviewerPolicy(ctx, team, &opts)
+	if err != nil {
+		slog.Error("PRUsecase.CreatePR: failed to resolve reviewer pools",
+			slog.String("pr_id", request.PrID),
+			slog.String("team_name", team.Name),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	warnings = append(warnings, poolWarnings...)
+
+	pairingWarnings, err := u.applyPairingRules(ctx, author.ID, team, &opts)
+	if err != nil {
+		slog.Error("PRUsecase.CreatePR: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", author.ID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	warnings = append(warnings, pairingWarnings...)
+
+	// указанные автором ревьюверы проверяются после правил пар, чтобы не назначить исключённого
+	if err := prdomain.CheckRequested(team, author.ID, request.RequestedReviewers, opts.Excluded); err != nil {
+		slog.Info("PRUsecase.CreatePR: invalid requested reviewers",
+			slog.String("pr_id", request.PrID),
+			slog.Any("requested_reviewers", request.RequestedReviewers),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	opts.Requested = request.RequestedReviewers
+
+	if err := u.applyRotation(ctx, author.ID, request.PrID, team.ReviewerPolicy, &opts); err != nil {
+		slog.Error("PRUsecase.CreatePR: failed to load review history",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", author.ID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	selection := prdomain.SelectReviewers(team, author.ID, opts)
+	warnings = append(warnings, selection.Warnings...)
+	for _, warning := range warnings {
+		slog.Warn("PRUsecase.CreatePR: assignment warning",
+			slog.String("pr_id", request.PrID),
+			slog.String("warning", warning),
+		)
+	}
 
 	pr := &prdomain.PullRequest{
 		ID:                request.PrID,
 		Name:              request.Name,
 		AuthorId:          request.AuthorId,
-		AssignedReviewers: reviewers,
+		AssignedReviewers: selection.Reviewers,
+		Tags:              tags,
+		ReviewerPools:     selection.Pools,
+		ReviewerSources:   selection.Sources(),
 	}
 
-	created, err := u.prProvider.CreatePR(ctx, pr)
+	// PR и события о назначении пишутся атомарно
+	var created *prdomain.PullRequest
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = u.prProvider.CreatePR(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("create pull request in provider: %w", err)
+		}
+
+		decision := prdomain.NewCreateDecision(created.ID, author.ID, team, seed, opts, selection)
+		if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+			return fmt.Errorf("save assignment decision in provider: %w", err)
+		}
+
+		events, err := outboxdomain.PRCreatedEvents(*created)
+		if err != nil {
+			return fmt.Errorf("build pr created events: %w", err)
+		}
+
+		if err := u.eventWriter.Append(ctx, events...); err != nil {
+			return fmt.Errorf("append pr created events: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		slog.Error("PRUsecase.CreatePR: provider error",
 			slog.String("pr_id", request.PrID),
 			slog.String("author_id", request.AuthorId),
 			slog.Any("error", err),
 		)
-		return nil, fmt.Errorf("create pull request in provider: %w", err)
+		return nil, err
 	}
 
+	observeCreated(created, opts)
 	slog.Info("PRUsecase.CreatePR: pull request created",
 		slog.String("pr_id", created.ID),
 		slog.String("author_id", created.AuthorId),
 		slog.String("status", string(created.Status)),
+		slog.Any("working_hours_reviewers", selection.InWorkingHours),
+		slog.Any("tag_matched_reviewers", selection.TagMatched),
+		slog.Any("code_owners", selection.Owners),
+		slog.Int64("seed", seed),
 	)
 
 	return &dto.CreatePRResponse{
-		PullRequest: *created,
+		PullRequest:           *created,
+		WorkingHoursReviewers: selection.InWorkingHours,
+		TagMatchedReviewers:   selection.TagMatched,
+		CodeOwners:            selection.Owners,
+		RequestedReviewers:    selection.Requested,
+		Warnings:              warnings,
 	}, nil
 }
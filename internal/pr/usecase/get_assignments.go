@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// GetAssignments возвращает историю выбора ревьюверов PR; каждое решение повторяется по
+// сохранённым зерну и входным данным, чтобы показать, что выбор воспроизводим.
+func (u *PRUsecase) GetAssignments(ctx context.Context,
+	request *dto.GetAssignmentsRequest) (*dto.GetAssignmentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.GetAssignments")
+	defer span.End()
+
+	if _, err := u.prProvider.GetPR(ctx, request.PrID); err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.GetAssignments: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get pull request from provider: %w", err)
+	}
+
+	decisions, err := u.decisions.ListDecisions(ctx, request.PrID)
+	if err != nil {
+		return nil, fmt.Errorf("list assignment decisions in provider: %w", err)
+	}
+
+	resp := &dto.GetAssignmentsResponse{
+		PrID:      request.PrID,
+		Decisions: make([]dto.AssignmentDecision, 0, len(decisions)),
+	}
+	for _, decision := range decisions {
+		replayed, err := decision.Replay()
+		if err != nil {
+			slog.Warn("PRUsecase.GetAssignments: replay failed",
+				slog.String("pr_id", request.PrID),
+				slog.Int64("decision_id", decision.ID),
+				slog.Any("error", err),
+			)
+		}
+		resp.Decisions = append(resp.Decisions, dto.AssignmentDecision{
+			Decision:      decision,
+			ReplayMatches: err == nil && slices.Equal(replayed, decision.Reviewers),
+		})
+	}
+
+	return resp, nil
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 )
 
 func (u *PRUsecase) GetReview(ctx context.Context,
 	request *dto.GetReviewRequest) (*dto.GetReviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.GetReview")
+	defer span.End()
 
 	prs, err := u.prProvider.GetReview(ctx, request.UserId)
 	if err != nil {
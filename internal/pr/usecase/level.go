@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// applyLevelPolicy переносит требование к грейду из политики команды автора в параметры
+// замены и собирает грейды назначенных ревьюверов. Ревьювер без грейда требованию не
+// засчитывается, поэтому его замена политику нарушить не может.
+func (u *PRUsecase) applyLevelPolicy(ctx context.Context, pr *prdomain.PullRequest,
+	oldReviewer *userdomain.User, policy *teamdomain.ReviewerPolicy, opts *prdomain.SelectOptions) error {
+
+	if oldReviewer.Level == "" || policy == nil || policy.MinLevelReviewers == 0 {
+		return nil
+	}
+
+	opts.MinLevel = policy.MinLevel
+	opts.MinLevelReviewers = policy.MinLevelReviewers
+	opts.AssignedLevels = map[string]userdomain.Level{oldReviewer.ID: oldReviewer.Level}
+
+	for _, id := range pr.AssignedReviewers {
+		if id == oldReviewer.ID {
+			continue
+		}
+
+		reviewer, err := u.userReader.GetUser(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("get reviewer from provider: %w", err)
+		}
+		opts.AssignedLevels[id] = reviewer.Level
+	}
+
+	return nil
+}
+
+// checkRemovableLevel проверяет, что снятие ревьювера без замены не нарушит требование
+// политики команды автора к грейду. Ревьювер без грейда или неизвестный ему не засчитывается.
+func (u *PRUsecase) checkRemovableLevel(ctx context.Context, pr *prdomain.PullRequest, reviewerID string) error {
+	reviewer, err := u.userReader.GetUser(ctx, reviewerID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get reviewer from provider: %w", err)
+	}
+	if reviewer.Level == "" {
+		return nil
+	}
+
+	policy, err := u.authorPolicy(ctx, pr.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	var opts prdomain.SelectOptions
+	if err := u.applyLevelPolicy(ctx, pr, reviewer, policy, &opts); err != nil {
+		return err
+	}
+
+	return prdomain.CheckRemovableLevel(pr, reviewerID, opts)
+}
+
+// checkLevelChange проверяет, что новый список ревьюверов выполняет требование политики команды
+// автора к грейду не хуже прежнего. Неизвестный ревьювер требованию не засчитывается.
+func (u *PRUsecase) checkLevelChange(ctx context.Context, policy *teamdomain.ReviewerPolicy,
+	before, after []string) error {
+
+	if policy == nil || policy.MinLevelReviewers == 0 {
+		return nil
+	}
+
+	opts := prdomain.SelectOptions{
+		MinLevel:          policy.MinLevel,
+		MinLevelReviewers: policy.MinLevelReviewers,
+		AssignedLevels:    make(map[string]userdomain.Level, len(before)+len(after)),
+	}
+	for _, id := range append(slices.Clone(before), after...) {
+		if _, ok := opts.AssignedLevels[id]; ok {
+			continue
+		}
+
+		reviewer, err := u.userReader.GetUser(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("get reviewer from provider: %w", err)
+		}
+		opts.AssignedLevels[id] = reviewer.Level
+	}
+
+	return prdomain.CheckLevelChange(before, after, opts)
+}
@@ -5,19 +5,52 @@ import (
 	"fmt"
 	"log/slog"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 )
 
 func (u *PRUsecase) MergePR(ctx context.Context,
 	request *dto.MergePRRequest) (*dto.MergePRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.MergePR")
+	defer span.End()
 
-	merged, err := u.prProvider.MergePR(ctx, request.PrID)
+	var merged *prdomain.PullRequest
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := u.prProvider.LockPR(ctx, request.PrID)
+		if err != nil {
+			return fmt.Errorf("lock pull request in provider: %w", err)
+		}
+
+		// повторный merge возвращает текущее состояние и не порождает событие
+		if pr.IsMerged() {
+			merged = pr
+			return nil
+		}
+
+		merged, err = u.prProvider.MergePR(ctx, request.PrID)
+		if err != nil {
+			return fmt.Errorf("merge pull request in provider: %w", err)
+		}
+
+		event, err := outboxdomain.NewEvent(outboxdomain.EventPRMerged, merged.ID,
+			outboxdomain.PRPayload{PullRequest: *merged})
+		if err != nil {
+			return fmt.Errorf("build pr merged event: %w", err)
+		}
+
+		if err := u.eventWriter.Append(ctx, event); err != nil {
+			return fmt.Errorf("append pr merged event: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		slog.Error("PRUsecase.MergePR: provider error",
 			slog.String("pr_id", request.PrID),
 			slog.Any("error", err),
 		)
-		return nil, fmt.Errorf("merge pull request in provider: %w", err)
+		return nil, err
 	}
 
 	slog.Info("PRUsecase.MergePR: pull request merged",
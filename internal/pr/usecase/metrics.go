@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+// observeSelectionError учитывает выбор ревьювера, не нашедший ни одного кандидата.
+func observeSelectionError(err error) {
+	if errors.Is(err, apperr.ErrNoCandidate) {
+		metrics.NoCandidate.Inc()
+	}
+}
+
+// observeCreated учитывает созданный PR и места политики, которые остались свободными.
+func observeCreated(pr *prdomain.PullRequest, opts prdomain.SelectOptions) {
+	metrics.PRsCreated.Inc()
+
+	slots, pools := prdomain.MissingSlots(pr, opts)
+	for _, pool := range pools {
+		slots += pool.Reviewers
+	}
+	metrics.EmptySlots.Add(float64(slots))
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// applyPairingRules переносит действующие правила пар автора в параметры выбора: исключённых
+// ревьюверов и обязательных наставников. Наставники читаются вместе с командой, чтобы учесть
+// их активность и отсутствие; удалённый наставник попадает в предупреждения.
+func (u *PRUsecase) applyPairingRules(ctx context.Context, authorID string, authorTeam *teamdomain.Team,
+	opts *prdomain.SelectOptions) ([]string, error) {
+
+	rules, err := u.pairingReader.ListAuthorRules(ctx, authorID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("get pairing rules from provider: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	getTeam := u.teamGetter(ctx, authorTeam)
+
+	var warnings []string
+	for _, rule := range rules {
+		switch rule.Kind {
+		case pairingdomain.KindExclude:
+			opts.Excluded = append(opts.Excluded, rule.ReviewerID)
+		case pairingdomain.KindRequire:
+			mentor, err := u.userMember(ctx, rule.ReviewerID, getTeam)
+			if err != nil {
+				if errors.Is(err, apperr.ErrNotFound) {
+					warnings = append(warnings, fmt.Sprintf("required reviewer %s not found, skipped", rule.ReviewerID))
+					continue
+				}
+				return nil, err
+			}
+			opts.Required = append(opts.Required, *mentor)
+		}
+	}
+
+	return warnings, nil
+}
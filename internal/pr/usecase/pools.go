@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// applyReviewerPolicy переносит политику команды автора в параметры выбора и читает
+// участников её пулов. Удалённый пул не ломает создание PR, а попадает в предупреждения.
+func (u *PRUsecase) applyReviewerPolicy(ctx context.Context, team *teamdomain.Team,
+	opts *prdomain.SelectOptions) ([]string, error) {
+
+	policy := team.ReviewerPolicy
+	if policy == nil {
+		return nil, nil
+	}
+
+	opts.TeamReviewers = policy.TeamReviewers
+	opts.MinLevel = policy.MinLevel
+	opts.MinLevelReviewers = policy.MinLevelReviewers
+
+	var warnings []string
+	for _, requirement := range policy.Pools {
+		pool, err := u.teamReader.GetPool(ctx, requirement.PoolName)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				warnings = append(warnings, fmt.Sprintf("pool %q not found", requirement.PoolName))
+				continue
+			}
+			return nil, fmt.Errorf("get reviewer pool from provider: %w", err)
+		}
+
+		opts.Pools = append(opts.Pools, prdomain.PoolSlot{
+			Pool:      pool.Name,
+			Members:   pool.Members,
+			Reviewers: requirement.Reviewers,
+		})
+	}
+
+	return warnings, nil
+}
+
+// reviewerPool возвращает пул, из которого был назначен reviewerID, в виде команды.
+// nil без ошибки - ревьювер из своей команды или его пул удалён; во втором случае
+// отметка о пуле снимается и замена ищется как обычно.
+func (u *PRUsecase) reviewerPool(ctx context.Context, pr *prdomain.PullRequest,
+	reviewerID string) (*teamdomain.Team, error) {
+
+	poolName := pr.ReviewerPools[reviewerID]
+	if poolName == "" {
+		return nil, nil
+	}
+
+	pool, err := u.teamReader.GetPool(ctx, poolName)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Warn("PRUsecase.reviewerPool: pool deleted, replacing from reviewer's team",
+				slog.String("pr_id", pr.ID),
+				slog.String("pool_name", poolName),
+			)
+			delete(pr.ReviewerPools, reviewerID)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get reviewer pool from provider: %w", err)
+	}
+
+	return pool.AsTeam(), nil
+}
@@ -6,15 +6,39 @@ import (
 	"log/slog"
 
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 )
 
 func (u *PRUsecase) ReassignPR(ctx context.Context,
 	request *dto.ReassignPRRequest) (*dto.ReassignPRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.ReassignPR")
+	defer span.End()
+
+	var resp *dto.ReassignPRResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.reassignPR(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Reassignments.Inc()
+	return resp, nil
+}
+
+// reassignPR выполняется в транзакции: PR читается под блокировкой, чтобы параллельный
+// merge или автозакрытие не были затёрты старой копией.
+func (u *PRUsecase) reassignPR(ctx context.Context,
+	request *dto.ReassignPRRequest) (*dto.ReassignPRResponse, error) {
 
 	// загружаем PR и сразу проверяем, можно ли его переназначать
-	pr, err := u.prProvider.GetPR(ctx, request.PrID)
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
 	if err != nil {
 		if err == apperr.ErrNotFound {
 			slog.Info("PRUsecase.ReassignPR: PR not found",
@@ -26,7 +50,7 @@ func (u *PRUsecase) ReassignPR(ctx context.Context,
 			slog.String("pr_id", request.PrID),
 			slog.Any("error", err),
 		)
-		return nil, fmt.Errorf("get pull request from provider: %w", err)
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
 	}
 
 	// ранняя проверка, чтобы не ходить за пользователем/командой при уже слитом PR
@@ -55,25 +79,59 @@ func (u *PRUsecase) ReassignPR(ctx context.Context,
 		return nil, fmt.Errorf("get old reviewer from provider: %w", err)
 	}
 
-	team, err := u.teamReader.GetTeam(ctx, oldReviewer.TeamName)
+	// ревьювера из пула заменяет участник того же пула, остальных - участник его команды
+	team, err := u.reviewerPool(ctx, pr, request.OldReviewerId)
 	if err != nil {
-		if err == apperr.ErrNotFound {
-			slog.Info("PRUsecase.ReassignPR: team not found",
+		slog.Error("PRUsecase.ReassignPR: failed to get reviewer pool",
+			slog.String("pr_id", request.PrID),
+			slog.String("old_reviewer_id", request.OldReviewerId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if team == nil {
+		team, err = u.teamReader.GetTeam(ctx, oldReviewer.TeamName)
+		if err != nil {
+			if err == apperr.ErrNotFound {
+				slog.Info("PRUsecase.ReassignPR: team not found",
+					slog.String("pr_id", request.PrID),
+					slog.String("team_name", oldReviewer.TeamName),
+				)
+				return nil, apperr.ErrNotFound
+			}
+			slog.Error("PRUsecase.ReassignPR: failed to get team",
 				slog.String("pr_id", request.PrID),
 				slog.String("team_name", oldReviewer.TeamName),
+				slog.Any("error", err),
 			)
-			return nil, apperr.ErrNotFound
+			return nil, fmt.Errorf("get team from provider: %w", err)
 		}
-		slog.Error("PRUsecase.ReassignPR: failed to get team",
+	}
+
+	opts, seed := u.selectOptions(request.AssignmentMode)
+	if err := u.applyAuthorPolicy(ctx, pr, oldReviewer, &opts); err != nil {
+		slog.Error("PRUsecase.ReassignPR: failed to apply author team policy",
 			slog.String("pr_id", request.PrID),
-			slog.String("team_name", oldReviewer.TeamName),
+			slog.String("old_reviewer_id", request.OldReviewerId),
 			slog.Any("error", err),
 		)
-		return nil, fmt.Errorf("get team from provider: %w", err)
+		return nil, err
 	}
 
-	newReviewerID, err := prdomain.ReassignReviewer(pr, team, request.OldReviewerId)
+	if _, err := u.applyPairingRules(ctx, pr.AuthorId, nil, &opts); err != nil {
+		slog.Error("PRUsecase.ReassignPR: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	before := prdomain.ClonePR(pr)
+	selection, err := prdomain.ReassignReviewerWith(pr, team, request.OldReviewerId, opts)
 	if err != nil {
+		observeSelectionError(err)
 		slog.Info("PRUsecase.ReassignPR: cannot find replacement",
 			slog.String("pr_id", request.PrID),
 			slog.String("old_reviewer_id", request.OldReviewerId),
@@ -81,6 +139,7 @@ func (u *PRUsecase) ReassignPR(ctx context.Context,
 		)
 		return nil, err
 	}
+	newReviewerID := selection.Reviewers[0]
 
 	updated, err := u.prProvider.UpdatePR(ctx, pr)
 	if err != nil {
@@ -92,14 +151,36 @@ func (u *PRUsecase) ReassignPR(ctx context.Context,
 		return nil, fmt.Errorf("update pull request in provider: %w", err)
 	}
 
+	decision := prdomain.NewReassignDecision(before, team, request.OldReviewerId, seed, opts, selection)
+	if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+		return nil, fmt.Errorf("save assignment decision in provider: %w", err)
+	}
+
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, updated.ID,
+		outboxdomain.ReviewerAssignedPayload{
+			PullRequest:        *updated,
+			ReviewerID:         newReviewerID,
+			ReplacedReviewerID: request.OldReviewerId,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("build reviewer assigned event: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, event); err != nil {
+		return nil, fmt.Errorf("append reviewer assigned event: %w", err)
+	}
+
 	slog.Info("PRUsecase.ReassignPR: reviewer reassigned",
 		slog.String("pr_id", updated.ID),
 		slog.String("old_reviewer_id", request.OldReviewerId),
 		slog.String("new_reviewer_id", newReviewerID),
+		slog.Int64("seed", seed),
 	)
 
 	return &dto.ReassignPRResponse{
-		PullRequest: *updated,
-		ReplacedBy:  newReviewerID,
+		PullRequest:           *updated,
+		ReplacedBy:            newReviewerID,
+		WorkingHoursReviewers: selection.InWorkingHours,
+		TagMatchedReviewers:   selection.TagMatched,
 	}, nil
 }
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/metrics"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// RebalancePR за один вызов заменяет всех ревьюверов открытого PR, которые больше не могут
+// его ревьюить: деактивированных, отсутствующих и тех, кого нет в команде автора или в пуле,
+// из которого они назначены. Владельцы кода и запрошенные ревьюверы не проверяются. Замены
+// выбираются как в ReassignPR; если замены нет, ревьювер всё равно снимается, а место остаётся
+// свободным до доназначения. Отсутствующий ревьювер без замены, доступный наставник из правил
+// пар и ревьювер, без которого нарушится требование к грейду, остаются на месте.
+// PR, решения и события пишутся в одной транзакции.
+func (u *PRUsecase) RebalancePR(ctx context.Context,
+	request *dto.RebalancePRRequest) (*dto.RebalancePRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.RebalancePR")
+	defer span.End()
+
+	var resp *dto.RebalancePRResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.rebalancePR(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := 0
+	for _, change := range resp.Changes {
+		if change.ReplacedBy != "" {
+			replaced++
+		}
+	}
+	metrics.Reassignments.Add(float64(replaced))
+	return resp, nil
+}
+
+// rebalancePR выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) rebalancePR(ctx context.Context,
+	request *dto.RebalancePRRequest) (*dto.RebalancePRResponse, error) {
+
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.RebalancePR: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if err := pr.CanReassign(); err != nil {
+		slog.Info("PRUsecase.RebalancePR: cannot rebalance",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	author, err := u.userReader.GetUser(ctx, pr.AuthorId)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get author from provider: %w", err)
+	}
+
+	team, err := u.teamReader.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	var pairing prdomain.SelectOptions
+	warnings, err := u.applyPairingRules(ctx, pr.AuthorId, team, &pairing)
+	if err != nil {
+		slog.Error("PRUsecase.RebalancePR: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	before := slices.Clone(pr.AssignedReviewers)
+	changes := make([]prdomain.ReviewerChange, 0)
+	var decisions []*prdomain.Decision
+	// mutated - PR изменён и должен быть записан, даже если снятых нет:
+	// reviewerPool снимает отметку удалённого пула
+	mutated := false
+	for _, reviewerID := range before {
+		// ревьювер из пула проверяется по пулу, остальные - по команде автора
+		poolName := pr.ReviewerPools[reviewerID]
+		group, err := u.reviewerPool(ctx, pr, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+		if poolName != pr.ReviewerPools[reviewerID] {
+			mutated = true
+		}
+		if group == nil {
+			group = team
+		}
+
+		reason, stale := prdomain.ReviewerStaleReason(pr, group, reviewerID)
+		if !stale {
+			continue
+		}
+		change := prdomain.ReviewerChange{
+			ReviewerID: reviewerID,
+			Reason:     reason,
+			Pool:       pr.ReviewerPools[reviewerID],
+		}
+
+		opts, seed, err := u.replacementOptions(ctx, pr, reviewerID, team.ReviewerPolicy, pairing,
+			request.AssignmentMode)
+		if err != nil {
+			slog.Error("PRUsecase.RebalancePR: failed to prepare replacement",
+				slog.String("pr_id", request.PrID),
+				slog.String("reviewer_id", reviewerID),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+
+		prBefore := prdomain.ClonePR(pr)
+		selection, err := prdomain.ReassignReviewerWith(pr, group, reviewerID, opts)
+		switch {
+		case err == nil:
+			change.ReplacedBy = selection.Reviewers[0]
+			decisions = append(decisions,
+				prdomain.NewReassignDecision(prBefore, group, reviewerID, seed, opts, selection))
+		case errors.Is(err, apperr.ErrNoCandidate) && reason == prdomain.StaleOutOfOffice:
+			// отсутствие временное: без замены ревью остаётся на ревьювере, как в задаче OOO
+			observeSelectionError(err)
+			warnings = append(warnings, fmt.Sprintf("%s kept: %v", reviewerID, err))
+			continue
+		case errors.Is(err, apperr.ErrNoCandidate):
+			observeSelectionError(err)
+			if err := pr.RemoveReviewer(reviewerID); err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf("no replacement for %s: %v", reviewerID, err))
+		case errors.Is(err, apperr.ErrLevelPolicy), errors.Is(err, apperr.ErrPairingRule):
+			observeSelectionError(err)
+			warnings = append(warnings, fmt.Sprintf("%s kept: %v", reviewerID, err))
+			continue
+		default:
+			return nil, err
+		}
+		changes = append(changes, change)
+		mutated = true
+	}
+
+	if !mutated {
+		return &dto.RebalancePRResponse{
+			PullRequest: *pr,
+			Before:      before,
+			After:       pr.AssignedReviewers,
+			Changes:     changes,
+			Warnings:    warnings,
+		}, nil
+	}
+
+	updated, err := u.prProvider.UpdatePR(ctx, pr)
+	if err != nil {
+		slog.Error("PRUsecase.RebalancePR: failed to update PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	for _, decision := range decisions {
+		if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+			return nil, fmt.Errorf("save assignment decision in provider: %w", err)
+		}
+	}
+
+	events := make([]outboxdomain.Event, 0, len(changes))
+	for _, change := range changes {
+		if change.ReplacedBy == "" {
+			unassigned, err := outboxdomain.ReviewersUnassignedEvents(*updated, change.ReviewerID)
+			if err != nil {
+				return nil, fmt.Errorf("build reviewer unassigned event: %w", err)
+			}
+			events = append(events, unassigned...)
+			continue
+		}
+		event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewerAssigned, updated.ID,
+			outboxdomain.ReviewerAssignedPayload{
+				PullRequest:        *updated,
+				ReviewerID:         change.ReplacedBy,
+				ReplacedReviewerID: change.ReviewerID,
+			})
+		if err != nil {
+			return nil, fmt.Errorf("build reviewer assigned event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) > 0 {
+		if err := u.eventWriter.Append(ctx, events...); err != nil {
+			return nil, fmt.Errorf("append reviewer events: %w", err)
+		}
+	}
+	slog.Info("PRUsecase.RebalancePR: pull request rebalanced",
+		slog.String("pr_id", updated.ID),
+		slog.Any("before", before),
+		slog.Any("after", updated.AssignedReviewers),
+	)
+
+	return &dto.RebalancePRResponse{
+		PullRequest: *updated,
+		Before:      before,
+		After:       updated.AssignedReviewers,
+		Changes:     changes,
+		Warnings:    warnings,
+	}, nil
+}
+
+// replacementOptions - параметры выбора замены для reviewerID: новое зерно, правила пар
+// автора, требование политики к грейду и история для режима rotation.
+func (u *PRUsecase) replacementOptions(ctx context.Context, pr *prdomain.PullRequest, reviewerID string,
+	policy *teamdomain.ReviewerPolicy, pairing prdomain.SelectOptions, mode string) (prdomain.SelectOptions, int64, error) {
+
+	opts, seed := u.selectOptions(mode)
+	opts.Excluded = pairing.Excluded
+	opts.Required = pairing.Required
+
+	reviewer, err := u.userReader.GetUser(ctx, reviewerID)
+	if err != nil {
+		if !errors.Is(err, apperr.ErrNotFound) {
+			return opts, seed, fmt.Errorf("get reviewer from provider: %w", err)
+		}
+		reviewer = &userdomain.User{ID: reviewerID}
+	}
+
+	if err := u.applyLevelPolicy(ctx, pr, reviewer, policy, &opts); err != nil {
+		return opts, seed, err
+	}
+	if err := u.applyRotation(ctx, pr.AuthorId, pr.ID, policy, &opts); err != nil {
+		return opts, seed, err
+	}
+	return opts, seed, nil
+}
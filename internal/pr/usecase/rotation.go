@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// applyRotation загружает ревьюверов последних PR автора для режима rotation; окно истории
+// и затухание берутся из политики команды автора. PR excludeID в историю не попадает.
+func (u *PRUsecase) applyRotation(ctx context.Context, authorID, excludeID string,
+	policy *teamdomain.ReviewerPolicy, opts *prdomain.SelectOptions) error {
+
+	if opts.Mode != prdomain.ModeRotation {
+		return nil
+	}
+
+	window, decay := policy.Rotation()
+	history, err := u.prProvider.RecentReviewers(ctx, authorID, excludeID, window)
+	if err != nil {
+		return fmt.Errorf("get recent reviewers from provider: %w", err)
+	}
+
+	opts.RecentReviewers = history
+	opts.RotationDecay = decay
+	return nil
+}
+
+// applyAuthorPolicy применяет к замене ревьювера политику команды автора PR: требование
+// к грейду и историю пар для режима rotation. Политика читается, только если может повлиять
+// на выбор; если автора или его команды уже нет, действуют правила по умолчанию.
+func (u *PRUsecase) applyAuthorPolicy(ctx context.Context, pr *prdomain.PullRequest,
+	oldReviewer *userdomain.User, opts *prdomain.SelectOptions) error {
+
+	if oldReviewer.Level == "" && opts.Mode != prdomain.ModeRotation {
+		return nil
+	}
+
+	policy, err := u.authorPolicy(ctx, pr.AuthorId)
+	if err != nil {
+		return err
+	}
+
+	if err := u.applyLevelPolicy(ctx, pr, oldReviewer, policy, opts); err != nil {
+		return err
+	}
+	return u.applyRotation(ctx, pr.AuthorId, pr.ID, policy, opts)
+}
+
+func (u *PRUsecase) authorPolicy(ctx context.Context, authorID string) (*teamdomain.ReviewerPolicy, error) {
+	author, err := u.userReader.GetUser(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get author from provider: %w", err)
+	}
+
+	team, err := u.teamReader.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get author team from provider: %w", err)
+	}
+
+	return team.ReviewerPolicy, nil
+}
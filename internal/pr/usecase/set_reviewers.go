@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// SetReviewers вручную заменяет список ревьюверов открытого PR. Новые ревьюверы проверяются
+// так же, как указанные автором при создании; оставшиеся из прежнего списка (в том числе из
+// пулов) не перепроверяются. Доступного наставника из правил пар снять нельзя, а новый список
+// должен выполнять требование политики к грейду не хуже прежнего. Новые ревьюверы
+// по порядку считаются заменой снятых, снятые без замены получают событие снятия.
+func (u *PRUsecase) SetReviewers(ctx context.Context,
+	request *dto.SetReviewersRequest) (*dto.SetReviewersResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.SetReviewers")
+	defer span.End()
+
+	var resp *dto.SetReviewersResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.setReviewers(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// setReviewers выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) setReviewers(ctx context.Context,
+	request *dto.SetReviewersRequest) (*dto.SetReviewersResponse, error) {
+
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.SetReviewers: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		slog.Error("PRUsecase.SetReviewers: failed to get PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if err := pr.CanReassign(); err != nil {
+		slog.Info("PRUsecase.SetReviewers: cannot change reviewers",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	author, err := u.userReader.GetUser(ctx, pr.AuthorId)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get author from provider: %w", err)
+	}
+
+	team, err := u.teamReader.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	var opts prdomain.SelectOptions
+	if _, err := u.applyPairingRules(ctx, pr.AuthorId, team, &opts); err != nil {
+		slog.Error("PRUsecase.SetReviewers: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	before := slices.Clone(pr.AssignedReviewers)
+	added, removed, err := pr.SetReviewers(request.Reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prdomain.CheckRequested(team, pr.AuthorId, added, opts.Excluded); err != nil {
+		slog.Info("PRUsecase.SetReviewers: invalid reviewers",
+			slog.String("pr_id", request.PrID),
+			slog.Any("reviewers", request.Reviewers),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if err := prdomain.CheckRemovable(pr.AuthorId, opts, removed...); err != nil {
+		slog.Info("PRUsecase.SetReviewers: reviewer required by pairing rule",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	if err := u.checkLevelChange(ctx, team.ReviewerPolicy, before, pr.AssignedReviewers); err != nil {
+		slog.Info("PRUsecase.SetReviewers: reviewers required by level policy",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	updated, err := u.prProvider.UpdatePR(ctx, pr)
+	if err != nil {
+		slog.Error("PRUsecase.SetReviewers: failed to update PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	events, err := outboxdomain.ReviewersReplacedEvents(*updated, added, removed)
+	if err != nil {
+		return nil, fmt.Errorf("build reviewers replaced events: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, events...); err != nil {
+		return nil, fmt.Errorf("append reviewers replaced events: %w", err)
+	}
+
+	slog.Info("PRUsecase.SetReviewers: reviewers replaced",
+		slog.String("pr_id", updated.ID),
+		slog.Any("added", added),
+		slog.Any("removed", removed),
+	)
+
+	return &dto.SetReviewersResponse{
+		PullRequest: *updated,
+		Added:       nonNil(added),
+		Removed:     nonNil(removed),
+	}, nil
+}
+
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+// TopUpPR доназначает ревьюверов на свободные места открытого PR: места команды автора
+// и пулов из политики команды, которые не удалось занять при создании. Выбор идёт по обычным
+// правилам и записывается в историю решений. Если свободных мест нет, PR не меняется;
+// если для свободных мест нет кандидатов, возвращается ErrNoCandidate.
+func (u *PRUsecase) TopUpPR(ctx context.Context, request *dto.TopUpPRRequest) (*dto.TopUpPRResponse, error) {
+	ctx, span := tracing.Start(ctx, "PRUsecase.TopUpPR")
+	defer span.End()
+
+	var resp *dto.TopUpPRResponse
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = u.topUpPR(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// topUpPR выполняется в транзакции с PR, заблокированным до записи.
+func (u *PRUsecase) topUpPR(ctx context.Context, request *dto.TopUpPRRequest) (*dto.TopUpPRResponse, error) {
+	pr, err := u.prProvider.LockPR(ctx, request.PrID)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			slog.Info("PRUsecase.TopUpPR: PR not found",
+				slog.String("pr_id", request.PrID),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("lock pull request in provider: %w", err)
+	}
+
+	if err := pr.CanReassign(); err != nil {
+		slog.Info("PRUsecase.TopUpPR: cannot top up PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	author, err := u.userReader.GetUser(ctx, pr.AuthorId)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get author from provider: %w", err)
+	}
+
+	team, err := u.teamReader.GetTeam(ctx, author.TeamName)
+	if err != nil {
+		if err == apperr.ErrNotFound {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	opts, seed := u.selectOptions(request.AssignmentMode)
+
+	warnings, err := u.applyReviewerPolicy(ctx, team, &opts)
+	if err != nil {
+		slog.Error("PRUsecase.TopUpPR: failed to resolve reviewer pools",
+			slog.String("pr_id", request.PrID),
+			slog.String("team_name", team.Name),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	pairingWarnings, err := u.applyPairingRules(ctx, pr.AuthorId, team, &opts)
+	if err != nil {
+		slog.Error("PRUsecase.TopUpPR: failed to load pairing rules",
+			slog.String("pr_id", request.PrID),
+			slog.String("author_id", pr.AuthorId),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	warnings = append(warnings, pairingWarnings...)
+
+	slots, pools := prdomain.MissingSlots(pr, opts)
+	if slots == 0 && len(pools) == 0 {
+		return &dto.TopUpPRResponse{PullRequest: *pr, Added: []string{}, Warnings: warnings}, nil
+	}
+	opts.Pools = pools
+
+	if err := u.applyRotation(ctx, pr.AuthorId, pr.ID, team.ReviewerPolicy, &opts); err != nil {
+		return nil, err
+	}
+
+	before := prdomain.ClonePR(pr)
+	selection, err := prdomain.PickAdditional(pr, team, slots, opts)
+	if err != nil {
+		observeSelectionError(err)
+		slog.Info("PRUsecase.TopUpPR: no candidate",
+			slog.String("pr_id", request.PrID),
+			slog.Int("team_slots", slots),
+			slog.Int("pool_slots", len(pools)),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+	warnings = append(warnings, selection.Warnings...)
+
+	if err := pr.AddSelected(selection); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.prProvider.UpdatePR(ctx, pr)
+	if err != nil {
+		slog.Error("PRUsecase.TopUpPR: failed to update PR",
+			slog.String("pr_id", request.PrID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	decision := prdomain.NewAddDecision(before, team, slots, seed, opts, selection)
+	if err := u.decisions.SaveDecision(ctx, decision); err != nil {
+		return nil, fmt.Errorf("save assignment decision in provider: %w", err)
+	}
+
+	events, err := outboxdomain.ReviewersAssignedEvents(*updated, selection.Reviewers...)
+	if err != nil {
+		return nil, fmt.Errorf("build reviewer assigned events: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, events...); err != nil {
+		return nil, fmt.Errorf("append reviewer assigned events: %w", err)
+	}
+
+	slog.Info("PRUsecase.TopUpPR: reviewers added",
+		slog.String("pr_id", updated.ID),
+		slog.Any("added", selection.Reviewers),
+		slog.Int64("seed", seed),
+	)
+
+	return &dto.TopUpPRResponse{
+		PullRequest: *updated,
+		Added:       selection.Reviewers,
+		Warnings:    warnings,
+	}, nil
+}
@@ -2,7 +2,10 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
 	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
@@ -14,26 +17,94 @@ type UserReader interface {
 
 type TeamReader interface {
 	GetTeam(ctx context.Context, teamName string) (*teamdomain.Team, error)
+	GetCodeOwners(ctx context.Context, teamName string) (*teamdomain.CodeOwners, error)
+	GetPool(ctx context.Context, name string) (*teamdomain.ReviewerPool, error)
+}
+
+type PairingReader interface {
+	// ListAuthorRules возвращает действующие на момент now правила пар для PR автора.
+	ListAuthorRules(ctx context.Context, authorID string, now time.Time) ([]pairingdomain.Rule, error)
 }
 
 type PRProvider interface {
 	GetPR(ctx context.Context, id string) (*domain.PullRequest, error)
+	// LockPR читает PR с блокировкой строки до конца транзакции; изменения ревьюверов
+	// читают PR только так.
+	LockPR(ctx context.Context, id string) (*domain.PullRequest, error)
 	UpdatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
 	CreatePR(ctx context.Context, pullRequest *domain.PullRequest) (*domain.PullRequest, error)
 	MergePR(ctx context.Context, id string) (*domain.PullRequest, error)
+	// ClosePR закрывает открытый PR и снимает с него ревьюверов.
+	ClosePR(ctx context.Context, id string, at time.Time) (*domain.PullRequest, error)
+	// ReopenPR снова открывает закрытый PR.
+	ReopenPR(ctx context.Context, id string) (*domain.PullRequest, error)
 	GetReview(ctx context.Context, userId string) (*[]domain.PullRequest, error)
+	RecentReviewers(ctx context.Context, authorID, excludeID string, limit int) ([][]string, error)
+}
+
+// DecisionStore хранит записи о выборе ревьюверов для воспроизведения и объяснения.
+type DecisionStore interface {
+	SaveDecision(ctx context.Context, decision *domain.Decision) error
+	ListDecisions(ctx context.Context, prID string) ([]domain.Decision, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
+}
+
+// Config - настройки выбора ревьюверов. DefaultMode применяется, если режим не указан в запросе.
+// Seed фиксирует последовательность зёрен выбора; 0 - зерно от времени запуска.
+type Config struct {
+	DefaultMode domain.AssignmentMode
+	Seed        int64
 }
 
 type PRUsecase struct {
-	prProvider PRProvider
-	userReader UserReader
-	teamReader TeamReader
+	prProvider    PRProvider
+	userReader    UserReader
+	teamReader    TeamReader
+	pairingReader PairingReader
+	decisions     DecisionStore
+	txManager     Transactor
+	eventWriter   EventWriter
+	cfg           Config
+	seeds         *domain.SeedSource
 }
 
-func NewPRUsecase(repo PRProvider, userReader UserReader, teamReader TeamReader) *PRUsecase {
+func NewPRUsecase(
+	repo PRProvider,
+	userReader UserReader,
+	teamReader TeamReader,
+	pairingReader PairingReader,
+	decisions DecisionStore,
+	txManager Transactor,
+	eventWriter EventWriter,
+	cfg Config,
+) *PRUsecase {
 	return &PRUsecase{
-		prProvider: repo,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    repo,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: pairingReader,
+		decisions:     decisions,
+		txManager:     txManager,
+		eventWriter:   eventWriter,
+		cfg:           cfg,
+		seeds:         domain.NewSeedSource(cfg.Seed),
+	}
+}
+
+// selectOptions - параметры выбора для запроса: режим из запроса или по умолчанию
+// и генератор с новым зерном, которое возвращается для записи решения.
+func (u *PRUsecase) selectOptions(mode string) (domain.SelectOptions, int64) {
+	seed := u.seeds.Next()
+	opts := domain.SelectOptions{Mode: u.cfg.DefaultMode, Now: time.Now(), Rand: domain.NewRand(seed)}
+	if mode != "" {
+		opts.Mode = domain.AssignmentMode(mode)
 	}
+	return opts, seed
 }
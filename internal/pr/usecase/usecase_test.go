@@ -2,20 +2,47 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	pairingdomain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/pr/dto"
 	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
 	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// noPairingRules - PairingReader без правил пар для тестов, которые их не проверяют.
+type noPairingRules struct{}
+
+func (noPairingRules) ListAuthorRules(context.Context, string, time.Time) ([]pairingdomain.Rule, error) {
+	return nil, nil
+}
+
+// discardDecisions - DecisionStore для тестов, которые не проверяют запись решений.
+type discardDecisions struct{}
+
+func (discardDecisions) SaveDecision(context.Context, *domain.Decision) error {
+	return nil
+}
+
+func (discardDecisions) ListDecisions(context.Context, string) ([]domain.Decision, error) {
+	return nil, nil
+}
+
 func TestPRUsecase_CreatePR(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +150,7 @@ func TestPRUsecase_CreatePR(t *testing.T) {
 			userReader := mocks.NewMockUserReader(ctrl)
 			teamReader := mocks.NewMockTeamReader(ctrl)
 			prProvider := mocks.NewMockPRProvider(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
 
 			if tt.stubUser != nil || tt.stubUserErr != nil {
 				userReader.EXPECT().
@@ -164,10 +192,25 @@ func TestPRUsecase_CreatePR(t *testing.T) {
 					})
 			}
 
+			if tt.stubCreated != nil {
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.NotEmpty(t, events)
+						assert.Equal(t, outboxdomain.EventPRCreated, events[0].Type)
+						assert.Equal(t, tt.stubCreated.ID, events[0].AggregateID)
+						return nil
+					})
+			}
+
 			uc := &PRUsecase{
-				prProvider: prProvider,
-				userReader: userReader,
-				teamReader: teamReader,
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     discardDecisions{},
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
 			}
 
 			resp, err := uc.CreatePR(context.Background(), tt.req)
@@ -190,11 +233,14 @@ func TestPRUsecase_MergePR(t *testing.T) {
 	t.Parallel()
 
 	type tc struct {
-		name    string
-		req     *dto.MergePRRequest
-		stubPR  *domain.PullRequest
-		stubErr error
-		wantErr bool
+		name       string
+		req        *dto.MergePRRequest
+		stubGetPR  *domain.PullRequest
+		stubGetErr error
+		stubPR     *domain.PullRequest
+		stubErr    error
+		wantEvent  bool
+		wantErr    bool
 	}
 
 	tests := []tc{
@@ -203,16 +249,43 @@ func TestPRUsecase_MergePR(t *testing.T) {
 			req: &dto.MergePRRequest{
 				PrID: "pr-1",
 			},
+			stubGetPR: &domain.PullRequest{
+				ID:     "pr-1",
+				Status: domain.StatusOpen,
+			},
 			stubPR: &domain.PullRequest{
 				ID:     "pr-1",
 				Status: domain.StatusMerged,
 			},
+			wantEvent: true,
+		},
+		{
+			name: "already_merged_is_idempotent",
+			req: &dto.MergePRRequest{
+				PrID: "pr-1",
+			},
+			stubGetPR: &domain.PullRequest{
+				ID:     "pr-1",
+				Status: domain.StatusMerged,
+			},
+		},
+		{
+			name: "not_found",
+			req: &dto.MergePRRequest{
+				PrID: "pr-404",
+			},
+			stubGetErr: apperr.ErrNotFound,
+			wantErr:    true,
 		},
 		{
 			name: "provider_error",
 			req: &dto.MergePRRequest{
 				PrID: "pr-2",
 			},
+			stubGetPR: &domain.PullRequest{
+				ID:     "pr-2",
+				Status: domain.StatusOpen,
+			},
 			stubErr: errors.New("db error"),
 			wantErr: true,
 		},
@@ -226,24 +299,48 @@ func TestPRUsecase_MergePR(t *testing.T) {
 			defer ctrl.Finish()
 
 			prProvider := mocks.NewMockPRProvider(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
 
 			prProvider.EXPECT().
-				MergePR(gomock.Any(), tt.req.PrID).
-				Return(tt.stubPR, tt.stubErr)
+				LockPR(gomock.Any(), tt.req.PrID).
+				Return(tt.stubGetPR, tt.stubGetErr)
 
-			uc := &PRUsecase{prProvider: prProvider}
+			if tt.stubGetPR != nil && !tt.stubGetPR.IsMerged() {
+				prProvider.EXPECT().
+					MergePR(gomock.Any(), tt.req.PrID).
+					Return(tt.stubPR, tt.stubErr)
+			}
+
+			if tt.wantEvent {
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventPRMerged, events[0].Type)
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:  prProvider,
+				txManager:   testutils.InlineTx{},
+				eventWriter: eventWriter,
+			}
 
 			resp, err := uc.MergePR(context.Background(), tt.req)
 			if tt.wantErr {
 				require.Error(t, err)
 				require.Nil(t, resp)
+				if tt.stubGetErr != nil {
+					assert.ErrorIs(t, err, tt.stubGetErr)
+				}
 				return
 			}
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
-			assert.Equal(t, tt.stubPR.ID, resp.PullRequest.ID)
-			assert.Equal(t, tt.stubPR.Status, resp.PullRequest.Status)
+			assert.Equal(t, tt.req.PrID, resp.PullRequest.ID)
+			assert.Equal(t, domain.StatusMerged, resp.PullRequest.Status)
 		})
 	}
 }
@@ -270,7 +367,7 @@ func TestPRUsecase_ReassignPR_Success(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(stubPR, nil)
 
 	userReader.EXPECT().
@@ -306,10 +403,24 @@ func TestPRUsecase_ReassignPR_Success(t *testing.T) {
 			return &updated, nil
 		})
 
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+	eventWriter.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, outboxdomain.EventPRReviewerAssigned, events[0].Type)
+			assert.Contains(t, string(events[0].Payload), `"replaced_reviewer_id":"u2"`)
+			return nil
+		})
+
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -338,13 +449,16 @@ func TestPRUsecase_ReassignPR_PRNotFound(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(nil, apperr.ErrNotFound)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -369,13 +483,16 @@ func TestPRUsecase_ReassignPR_PRProviderError(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(nil, errors.New("db error"))
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -399,7 +516,7 @@ func TestPRUsecase_ReassignPR_PRIsMerged(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-merged",
 			Status:            domain.StatusMerged,
@@ -407,9 +524,12 @@ func TestPRUsecase_ReassignPR_PRIsMerged(t *testing.T) {
 		}, nil)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -434,7 +554,7 @@ func TestPRUsecase_ReassignPR_OldReviewerNotFound(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-1",
 			Status:            domain.StatusOpen,
@@ -446,9 +566,12 @@ func TestPRUsecase_ReassignPR_OldReviewerNotFound(t *testing.T) {
 		Return(nil, apperr.ErrNotFound)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -473,7 +596,7 @@ func TestPRUsecase_ReassignPR_TeamNotFound(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-1",
 			Status:            domain.StatusOpen,
@@ -493,9 +616,12 @@ func TestPRUsecase_ReassignPR_TeamNotFound(t *testing.T) {
 		Return(nil, apperr.ErrNotFound)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -520,7 +646,7 @@ func TestPRUsecase_ReassignPR_NoCandidates(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-1",
 			Status:            domain.StatusOpen,
@@ -546,9 +672,12 @@ func TestPRUsecase_ReassignPR_NoCandidates(t *testing.T) {
 		}, nil)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -573,7 +702,7 @@ func TestPRUsecase_ReassignPR_OldReviewerNotAssigned(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-1",
 			Status:            domain.StatusOpen,
@@ -599,9 +728,12 @@ func TestPRUsecase_ReassignPR_OldReviewerNotAssigned(t *testing.T) {
 		}, nil)
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -626,7 +758,7 @@ func TestPRUsecase_ReassignPR_UpdateError(t *testing.T) {
 	teamReader := mocks.NewMockTeamReader(ctrl)
 
 	prProvider.EXPECT().
-		GetPR(gomock.Any(), req.PrID).
+		LockPR(gomock.Any(), req.PrID).
 		Return(&domain.PullRequest{
 			ID:                "pr-1",
 			Status:            domain.StatusOpen,
@@ -657,9 +789,12 @@ func TestPRUsecase_ReassignPR_UpdateError(t *testing.T) {
 		Return(nil, errors.New("update error"))
 
 	uc := &PRUsecase{
-		prProvider: prProvider,
-		userReader: userReader,
-		teamReader: teamReader,
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
 	}
 
 	resp, err := uc.ReassignPR(context.Background(), req)
@@ -759,3 +894,1806 @@ func TestPRUsecase_GetReview(t *testing.T) {
 		})
 	}
 }
+
+func TestPRUsecase_CreatePR_CodeOwners(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	backend := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+		},
+	}
+	platform := &teamdomain.Team{
+		Name: "platform",
+		Members: []teamdomain.TeamMember{
+			{ID: "dba", IsActive: false},
+			{ID: "p1", IsActive: true},
+		},
+	}
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "dba").
+		Return(&userdomain.User{ID: "dba", TeamName: "platform"}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(backend, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "platform").Return(platform, nil)
+	teamReader.EXPECT().GetCodeOwners(gomock.Any(), "backend").Return(&teamdomain.CodeOwners{
+		TeamName: "backend",
+		Rules: []teamdomain.CodeOwnerRule{
+			{Pattern: "/migrator/", Users: []string{"dba"}, Teams: []string{"platform"}},
+		},
+	}, nil)
+
+	prProvider.EXPECT().
+		CreatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			// неактивный dba пропущен, правило закрывает другой участник platform
+			assert.Equal(t, []string{"p1", "u2"}, pr.AssignedReviewers)
+			pr.Status = domain.StatusOpen
+			return pr, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:         "pr-1",
+		Name:         "Add index",
+		AuthorId:     "u1",
+		ChangedPaths: []string{"migrator/migrations/001.sql"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p1"}, resp.CodeOwners)
+	require.Len(t, resp.Warnings, 1)
+	assert.Contains(t, resp.Warnings[0], "dba")
+}
+
+func TestPRUsecase_CreatePR_Tags(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true, Tags: []string{"frontend"}},
+			{ID: "u3", IsActive: true, Tags: []string{"postgres"}},
+			{ID: "u4", IsActive: true, Tags: []string{"go", "postgres"}},
+		},
+	}
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+
+	prProvider.EXPECT().
+		CreatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			assert.Equal(t, []string{"go", "postgres"}, pr.Tags)
+			assert.Equal(t, []string{"u4", "u3"}, pr.AssignedReviewers)
+			pr.Status = domain.StatusOpen
+			return pr, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:     "pr-1",
+		Name:     "Add index",
+		AuthorId: "u1",
+		Tags:     []string{"Postgres", "go"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4", "u3"}, resp.TagMatchedReviewers)
+
+	// некорректные теги отклоняются до чтения автора
+	resp, err = uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:     "pr-2",
+		Name:     "Add index",
+		AuthorId: "u1",
+		Tags:     []string{"front end"},
+	})
+	require.ErrorIs(t, err, apperr.ErrInvalidTags)
+	require.Nil(t, resp)
+}
+
+func TestPRUsecase_CreatePR_ReviewerPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+		ReviewerPolicy: &teamdomain.ReviewerPolicy{
+			TeamReviewers: 1,
+			Pools: []teamdomain.PoolRequirement{
+				{PoolName: "security", Reviewers: 1},
+				{PoolName: "deleted", Reviewers: 1},
+			},
+		},
+	}
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+	teamReader.EXPECT().GetPool(gomock.Any(), "security").Return(&teamdomain.ReviewerPool{
+		Name:    "security",
+		Members: []teamdomain.TeamMember{{ID: "s1", IsActive: true, TeamName: "platform"}},
+	}, nil)
+	teamReader.EXPECT().GetPool(gomock.Any(), "deleted").Return(nil, apperr.ErrNotFound)
+
+	prProvider.EXPECT().
+		CreatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			require.Len(t, pr.AssignedReviewers, 2)
+			assert.Equal(t, "s1", pr.AssignedReviewers[0])
+			assert.Equal(t, map[string]string{"s1": "security"}, pr.ReviewerPools)
+			pr.Status = domain.StatusOpen
+			return pr, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:     "pr-1",
+		Name:     "Add auth",
+		AuthorId: "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`pool "deleted" not found`}, resp.Warnings)
+}
+
+func TestPRUsecase_ReassignPR_PoolReviewer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+		ID:                "pr-1",
+		AuthorId:          "u1",
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []string{"s1", "u2"},
+		ReviewerPools:     map[string]string{"s1": "security"},
+	}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "s1").
+		Return(&userdomain.User{ID: "s1", TeamName: "platform", IsActive: true}, nil)
+	// замена ищется в пуле, а не в команде platform
+	teamReader.EXPECT().GetPool(gomock.Any(), "security").Return(&teamdomain.ReviewerPool{
+		Name: "security",
+		Members: []teamdomain.TeamMember{
+			{ID: "s1", IsActive: true, TeamName: "platform"},
+			{ID: "s2", IsActive: true, TeamName: "infra"},
+		},
+	}, nil)
+
+	prProvider.EXPECT().
+		UpdatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			assert.Equal(t, []string{"s2", "u2"}, pr.AssignedReviewers)
+			assert.Equal(t, map[string]string{"s2": "security"}, pr.ReviewerPools)
+			updated := *pr
+			return &updated, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	resp, err := uc.ReassignPR(context.Background(), &dto.ReassignPRRequest{PrID: "pr-1", OldReviewerId: "s1"})
+	require.NoError(t, err)
+	assert.Equal(t, "s2", resp.ReplacedBy)
+}
+
+func TestPRUsecase_ReassignPR_LevelPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+
+	prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+		ID:                "pr-1",
+		AuthorId:          "u1",
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u3").
+		Return(&userdomain.User{ID: "u3", TeamName: "backend", IsActive: true, Level: userdomain.LevelSenior}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u2").
+		Return(&userdomain.User{ID: "u2", TeamName: "backend", IsActive: true, Level: userdomain.LevelJunior}, nil)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+			{ID: "u3", IsActive: true, Level: userdomain.LevelSenior},
+			{ID: "u4", IsActive: true, Level: userdomain.LevelMiddle},
+		},
+		ReviewerPolicy: &teamdomain.ReviewerPolicy{
+			TeamReviewers:     2,
+			MinLevel:          userdomain.LevelSenior,
+			MinLevelReviewers: 1,
+		},
+	}
+	// команда ревьювера и команда автора совпадают
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil).Times(2)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+	}
+
+	// единственного сеньора некем заменить: u4 - middle
+	_, err := uc.ReassignPR(context.Background(), &dto.ReassignPRRequest{PrID: "pr-1", OldReviewerId: "u3"})
+	require.ErrorIs(t, err, apperr.ErrLevelPolicy)
+}
+
+func TestPRUsecase_CreatePR_Rotation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+		ReviewerPolicy: &teamdomain.ReviewerPolicy{TeamReviewers: 2, RotationWindow: 3},
+	}
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+	// окно истории берётся из политики команды
+	prProvider.EXPECT().RecentReviewers(gomock.Any(), "u1", "pr-1", 3).
+		Return([][]string{{"u2", "u3"}, {"u2"}}, nil)
+
+	prProvider.EXPECT().
+		CreatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+			pr.Status = domain.StatusOpen
+			return pr, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	_, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:           "pr-1",
+		Name:           "Add auth",
+		AuthorId:       "u1",
+		AssignmentMode: string(domain.ModeRotation),
+	})
+	require.NoError(t, err)
+}
+
+func TestPRUsecase_ReassignPR_RotationHistoryError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+
+	prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+		ID:                "pr-1",
+		AuthorId:          "u1",
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u2").
+		Return(&userdomain.User{ID: "u2", TeamName: "backend", IsActive: true}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil).Times(2)
+	// без политики действует окно по умолчанию, текущий PR в историю не входит
+	prProvider.EXPECT().RecentReviewers(gomock.Any(), "u1", "pr-1", teamdomain.DefaultRotationWindow).
+		Return(nil, errors.New("db down"))
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: noPairingRules{},
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+	}
+
+	_, err := uc.ReassignPR(context.Background(), &dto.ReassignPRRequest{
+		PrID:           "pr-1",
+		OldReviewerId:  "u2",
+		AssignmentMode: string(domain.ModeRotation),
+	})
+	require.Error(t, err)
+}
+
+func TestPRUsecase_CreatePR_PairingRules(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	pairingReader := mocks.NewMockPairingReader(ctrl)
+	eventWriter := mocks.NewMockEventWriter(ctrl)
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}
+
+	userReader.EXPECT().GetUser(gomock.Any(), "u1").
+		Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+	pairingReader.EXPECT().ListAuthorRules(gomock.Any(), "u1", gomock.Any()).Return([]pairingdomain.Rule{
+		{Kind: pairingdomain.KindExclude, AuthorID: "u1", ReviewerID: "u2"},
+		{Kind: pairingdomain.KindRequire, AuthorID: "u1", ReviewerID: "m1"},
+		{Kind: pairingdomain.KindRequire, AuthorID: "u1", ReviewerID: "gone"},
+	}, nil)
+	// наставник из другой команды читается вместе с ней
+	userReader.EXPECT().GetUser(gomock.Any(), "m1").
+		Return(&userdomain.User{ID: "m1", TeamName: "platform", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "platform").Return(&teamdomain.Team{
+		Name:    "platform",
+		Members: []teamdomain.TeamMember{{ID: "m1", IsActive: true}},
+	}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "gone").Return(nil, apperr.ErrNotFound)
+
+	prProvider.EXPECT().
+		CreatePR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+			assert.Equal(t, []string{"m1", "u3"}, pr.AssignedReviewers)
+			pr.Status = domain.StatusOpen
+			return pr, nil
+		})
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: pairingReader,
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+		eventWriter:   eventWriter,
+	}
+
+	resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+		PrID:     "pr-1",
+		Name:     "Add auth",
+		AuthorId: "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"required reviewer gone not found, skipped"}, resp.Warnings)
+}
+
+func TestPRUsecase_ReassignPR_PairingRuleExplained(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	userReader := mocks.NewMockUserReader(ctrl)
+	teamReader := mocks.NewMockTeamReader(ctrl)
+	pairingReader := mocks.NewMockPairingReader(ctrl)
+
+	prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+		ID:                "pr-1",
+		AuthorId:          "u1",
+		Status:            domain.StatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}, nil)
+	userReader.EXPECT().GetUser(gomock.Any(), "u2").
+		Return(&userdomain.User{ID: "u2", TeamName: "backend", IsActive: true}, nil)
+	teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}, nil)
+	pairingReader.EXPECT().ListAuthorRules(gomock.Any(), "u1", gomock.Any()).Return([]pairingdomain.Rule{
+		{Kind: pairingdomain.KindExclude, AuthorID: "u1", ReviewerID: "u4"},
+	}, nil)
+
+	uc := &PRUsecase{
+		prProvider:    prProvider,
+		userReader:    userReader,
+		teamReader:    teamReader,
+		pairingReader: pairingReader,
+		decisions:     discardDecisions{},
+		txManager:     testutils.InlineTx{},
+	}
+
+	_, err := uc.ReassignPR(context.Background(), &dto.ReassignPRRequest{PrID: "pr-1", OldReviewerId: "u2"})
+	require.ErrorIs(t, err, apperr.ErrNoCandidate)
+	assert.Contains(t, err.Error(), "u4 excluded by pairing rules for author u1")
+}
+
+func TestPRUsecase_CreatePR_SeededDecision(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{Name: "backend"}
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5", "u6", "u7", "u8"} {
+		team.Members = append(team.Members, teamdomain.TeamMember{ID: id, IsActive: true})
+	}
+
+	// два сервиса с одним зерном конфигурации выбирают одинаково
+	create := func() *domain.Decision {
+		ctrl := gomock.NewController(t)
+
+		prProvider := mocks.NewMockPRProvider(ctrl)
+		userReader := mocks.NewMockUserReader(ctrl)
+		teamReader := mocks.NewMockTeamReader(ctrl)
+		decisions := mocks.NewMockDecisionStore(ctrl)
+		eventWriter := mocks.NewMockEventWriter(ctrl)
+
+		userReader.EXPECT().GetUser(gomock.Any(), "u1").
+			Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+		teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+		prProvider.EXPECT().
+			CreatePR(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+				pr.Status = domain.StatusOpen
+				return pr, nil
+			})
+
+		var saved *domain.Decision
+		decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, decision *domain.Decision) error {
+				saved = decision
+				return nil
+			})
+		eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := NewPRUsecase(prProvider, userReader, teamReader, noPairingRules{}, decisions,
+			testutils.InlineTx{}, eventWriter, Config{DefaultMode: domain.ModeRandom, Seed: 42})
+
+		resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{PrID: "pr-1", Name: "Add auth", AuthorId: "u1"})
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, resp.PullRequest.AssignedReviewers, saved.Reviewers)
+		return saved
+	}
+
+	first, second := create(), create()
+	assert.Equal(t, first.Seed, second.Seed)
+	assert.Equal(t, first.Reviewers, second.Reviewers)
+	assert.Equal(t, domain.DecisionCreate, first.Kind)
+
+	replayed, err := first.Replay()
+	require.NoError(t, err)
+	assert.Equal(t, first.Reviewers, replayed)
+}
+
+func TestPRUsecase_GetAssignments(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}
+	opts := domain.SelectOptions{Mode: domain.ModeRandom, Rand: domain.NewRand(7)}
+	selection := domain.SelectReviewers(team, "u1", opts)
+	recorded := *domain.NewCreateDecision("pr-1", "u1", team, 7, opts, selection)
+	tampered := recorded
+	tampered.Reviewers = []string{"u1"}
+
+	tests := []struct {
+		name      string
+		prErr     error
+		decisions []domain.Decision
+		matches   []bool
+		wantErr   error
+	}{
+		{
+			name:      "replay matches recorded choice",
+			decisions: []domain.Decision{recorded},
+			matches:   []bool{true},
+		},
+		{
+			name:      "replay differs from stored reviewers",
+			decisions: []domain.Decision{tampered},
+			matches:   []bool{false},
+		},
+		{
+			name:    "pull request not found",
+			prErr:   apperr.ErrNotFound,
+			wantErr: apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			decisions := mocks.NewMockDecisionStore(ctrl)
+
+			prProvider.EXPECT().GetPR(gomock.Any(), "pr-1").
+				Return(&domain.PullRequest{ID: "pr-1"}, tt.prErr)
+			if tt.prErr == nil {
+				decisions.EXPECT().ListDecisions(gomock.Any(), "pr-1").Return(tt.decisions, nil)
+			}
+
+			uc := &PRUsecase{prProvider: prProvider, decisions: decisions}
+
+			resp, err := uc.GetAssignments(context.Background(), &dto.GetAssignmentsRequest{PrID: "pr-1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Decisions, len(tt.matches))
+			for i, match := range tt.matches {
+				assert.Equal(t, match, resp.Decisions[i].ReplayMatches)
+			}
+		})
+	}
+}
+
+func TestPRUsecase_CreatePR_RequestedReviewers(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: false},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		requested []string
+		wantErr   error
+	}{
+		{name: "requested reviewer takes a slot", requested: []string{"u3"}},
+		{name: "inactive reviewer rejected", requested: []string{"u4"}, wantErr: apperr.ErrInvalidReviewers},
+		{name: "reviewer from another team rejected", requested: []string{"x1"}, wantErr: apperr.ErrInvalidReviewers},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			userReader.EXPECT().GetUser(gomock.Any(), "u1").
+				Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+			teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(team, nil)
+
+			if tt.wantErr == nil {
+				prProvider.EXPECT().
+					CreatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Equal(t, []string{"u3", "u2"}, pr.AssignedReviewers)
+						pr.Status = domain.StatusOpen
+						return pr, nil
+					})
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     discardDecisions{},
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.CreatePR(context.Background(), &dto.CreatePRRequest{
+				PrID:               "pr-1",
+				Name:               "Add auth",
+				AuthorId:           "u1",
+				RequestedReviewers: tt.requested,
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.requested, resp.RequestedReviewers)
+		})
+	}
+}
+
+func TestPRUsecase_SetReviewers(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: false},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		status    domain.PrStatus
+		reviewers []string
+		rules     []pairingdomain.Rule
+		policy    *teamdomain.ReviewerPolicy
+		// levels - грейды ревьюверов для проверки политики
+		levels      map[string]userdomain.Level
+		wantAdded   []string
+		wantRemoved []string
+		wantEvents  []outboxdomain.EventType
+		wantErr     error
+	}{
+		{
+			name:        "replace reviewers, pool reviewer kept",
+			status:      domain.StatusOpen,
+			reviewers:   []string{"p1", "u3"},
+			wantAdded:   []string{"u3"},
+			wantRemoved: []string{"u2"},
+			wantEvents:  []outboxdomain.EventType{outboxdomain.EventPRReviewerAssigned},
+		},
+		{
+			name:        "reviewer removed without replacement",
+			status:      domain.StatusOpen,
+			reviewers:   []string{"p1"},
+			wantAdded:   []string{},
+			wantRemoved: []string{"u2"},
+			wantEvents:  []outboxdomain.EventType{outboxdomain.EventPRReviewerUnassigned},
+		},
+		{
+			name:      "merged PR",
+			status:    domain.StatusMerged,
+			reviewers: []string{"u3"},
+			wantErr:   apperr.ErrPRMerged,
+		},
+		{
+			name:      "inactive new reviewer",
+			status:    domain.StatusOpen,
+			reviewers: []string{"u2", "u4"},
+			wantErr:   apperr.ErrInvalidReviewers,
+		},
+		{
+			name:      "senior required by level policy cannot be replaced",
+			status:    domain.StatusOpen,
+			reviewers: []string{"p1", "u3"},
+			policy:    &teamdomain.ReviewerPolicy{MinLevel: userdomain.LevelSenior, MinLevelReviewers: 1},
+			levels: map[string]userdomain.Level{
+				"u2": userdomain.LevelSenior,
+				"p1": userdomain.LevelJunior,
+				"u3": userdomain.LevelMiddle,
+			},
+			wantErr: apperr.ErrLevelPolicy,
+		},
+		{
+			name:        "senior replaced by senior",
+			status:      domain.StatusOpen,
+			reviewers:   []string{"p1", "u3"},
+			policy:      &teamdomain.ReviewerPolicy{MinLevel: userdomain.LevelSenior, MinLevelReviewers: 1},
+			levels:      map[string]userdomain.Level{"u2": userdomain.LevelSenior, "u3": userdomain.LevelLead},
+			wantAdded:   []string{"u3"},
+			wantRemoved: []string{"u2"},
+			wantEvents:  []outboxdomain.EventType{outboxdomain.EventPRReviewerAssigned},
+		},
+		{
+			name:      "available mentor cannot be removed",
+			status:    domain.StatusOpen,
+			reviewers: []string{"u3"},
+			rules:     []pairingdomain.Rule{{Kind: pairingdomain.KindRequire, AuthorID: "u1", ReviewerID: "u2"}},
+			wantErr:   apperr.ErrPairingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			pairingReader := mocks.NewMockPairingReader(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: []string{"u2", "p1"},
+				ReviewerPools:     map[string]string{"p1": "security"},
+			}, nil)
+
+			if tt.status == domain.StatusOpen {
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").
+					Return(&teamdomain.Team{Name: team.Name, Members: team.Members, ReviewerPolicy: tt.policy}, nil)
+				pairingReader.EXPECT().ListAuthorRules(gomock.Any(), "u1", gomock.Any()).Return(tt.rules, nil)
+			}
+			for _, rule := range tt.rules {
+				if rule.Kind == pairingdomain.KindRequire {
+					userReader.EXPECT().GetUser(gomock.Any(), rule.ReviewerID).
+						Return(&userdomain.User{ID: rule.ReviewerID, TeamName: "backend", IsActive: true}, nil)
+				}
+			}
+
+			if tt.policy != nil {
+				userReader.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+					DoAndReturn(func(_ context.Context, id string) (*userdomain.User, error) {
+						level, ok := tt.levels[id]
+						if !ok {
+							return nil, apperr.ErrNotFound
+						}
+						return &userdomain.User{ID: id, TeamName: "backend", IsActive: true, Level: level}, nil
+					})
+			}
+
+			if tt.wantErr == nil {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Equal(t, tt.reviewers, pr.AssignedReviewers)
+						assert.Equal(t, map[string]string{"p1": "security"}, pr.ReviewerPools)
+						return pr, nil
+					})
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						// по событию на каждого нового и каждого снятого без замены ревьювера
+						require.Len(t, events, len(tt.wantEvents))
+						for i, event := range events {
+							assert.Equal(t, tt.wantEvents[i], event.Type)
+						}
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: pairingReader,
+				decisions:     discardDecisions{},
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.SetReviewers(context.Background(), &dto.SetReviewersRequest{PrID: "pr-1", Reviewers: tt.reviewers})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, resp.Added)
+			assert.Equal(t, tt.wantRemoved, resp.Removed)
+		})
+	}
+}
+
+func TestPRUsecase_AddReviewer(t *testing.T) {
+	t.Parallel()
+
+	team := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}
+	leveled := &teamdomain.Team{
+		Name: "backend",
+		Members: []teamdomain.TeamMember{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true, Level: userdomain.LevelJunior},
+			{ID: "u3", IsActive: true, Level: userdomain.LevelJunior},
+			{ID: "u4", IsActive: true},
+			{ID: "u5", IsActive: true, Level: userdomain.LevelSenior},
+		},
+	}
+	withPool := &teamdomain.ReviewerPolicy{
+		TeamReviewers: 2,
+		Pools:         []teamdomain.PoolRequirement{{PoolName: "security", Reviewers: 1}},
+	}
+
+	tests := []struct {
+		name         string
+		status       domain.PrStatus
+		assigned     []string
+		assignedPool map[string]string
+		reviewerID   string
+		team         *teamdomain.Team
+		policy       *teamdomain.ReviewerPolicy
+		pool         *teamdomain.ReviewerPool
+		wantAdded    string
+		wantPool     string
+		wantAuto     bool
+		wantWarnings []string
+		wantErr      error
+	}{
+		{
+			name:       "explicit reviewer",
+			status:     domain.StatusOpen,
+			assigned:   []string{"u2"},
+			reviewerID: "u4",
+			wantAdded:  "u4",
+		},
+		{
+			name:      "auto-picked reviewer",
+			status:    domain.StatusOpen,
+			assigned:  []string{"u2", "u3"},
+			wantAdded: "u4",
+			wantAuto:  true,
+		},
+		{
+			name:      "auto-picked reviewer takes free pool slot",
+			status:    domain.StatusOpen,
+			assigned:  []string{"u2", "u3"},
+			policy:    withPool,
+			pool:      &teamdomain.ReviewerPool{Name: "security", Members: []teamdomain.TeamMember{{ID: "s1", IsActive: true}}},
+			wantAdded: "s1",
+			wantPool:  "security",
+			wantAuto:  true,
+		},
+		{
+			name:         "pool slot taken, reviewer picked from team",
+			status:       domain.StatusOpen,
+			assigned:     []string{"u2", "u3", "s1"},
+			assignedPool: map[string]string{"s1": "security"},
+			policy:       withPool,
+			pool: &teamdomain.ReviewerPool{Name: "security", Members: []teamdomain.TeamMember{
+				{ID: "s1", IsActive: true}, {ID: "s2", IsActive: true},
+			}},
+			wantAdded: "u4",
+			wantAuto:  true,
+		},
+		{
+			name:         "pool without candidates falls back to team",
+			status:       domain.StatusOpen,
+			assigned:     []string{"u2", "u3"},
+			policy:       withPool,
+			pool:         &teamdomain.ReviewerPool{Name: "security", Members: []teamdomain.TeamMember{{ID: "s1"}}},
+			wantAdded:    "u4",
+			wantAuto:     true,
+			wantWarnings: []string{`pool "security" has 0 of 1 available reviewers`},
+		},
+		{
+			name:     "auto-picked reviewer satisfies min level",
+			status:   domain.StatusOpen,
+			assigned: []string{"u2"},
+			team:     leveled,
+			policy: &teamdomain.ReviewerPolicy{
+				TeamReviewers:     2,
+				MinLevel:          userdomain.LevelSenior,
+				MinLevelReviewers: 1,
+			},
+			wantAdded: "u5",
+			wantAuto:  true,
+		},
+		{
+			name:       "already assigned",
+			status:     domain.StatusOpen,
+			assigned:   []string{"u2"},
+			reviewerID: "u2",
+			wantErr:    apperr.ErrAssigned,
+		},
+		{
+			name:     "no candidate left",
+			status:   domain.StatusOpen,
+			assigned: []string{"u2", "u3", "u4"},
+			wantErr:  apperr.ErrNoCandidate,
+		},
+		{
+			name:       "merged PR",
+			status:     domain.StatusMerged,
+			assigned:   []string{"u2"},
+			reviewerID: "u4",
+			wantErr:    apperr.ErrPRMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			decisions := mocks.NewMockDecisionStore(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: slices.Clone(tt.assigned),
+				ReviewerPools:     maps.Clone(tt.assignedPool),
+			}, nil)
+
+			if tt.status == domain.StatusOpen {
+				authorTeam := *team
+				if tt.team != nil {
+					authorTeam = *tt.team
+				}
+				authorTeam.ReviewerPolicy = tt.policy
+
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(&authorTeam, nil)
+			}
+			if tt.pool != nil {
+				teamReader.EXPECT().GetPool(gomock.Any(), tt.pool.Name).Return(tt.pool, nil)
+			}
+
+			if tt.wantErr == nil {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Equal(t, append(slices.Clone(tt.assigned), tt.wantAdded), pr.AssignedReviewers)
+						assert.Equal(t, tt.wantPool, pr.ReviewerPools[tt.wantAdded])
+						return pr, nil
+					})
+				if tt.wantAuto {
+					decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, decision *domain.Decision) error {
+							assert.Equal(t, domain.DecisionAdd, decision.Kind)
+							assert.Equal(t, tt.assigned, decision.Input.PullRequest.AssignedReviewers)
+							return nil
+						})
+				}
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     decisions,
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.AddReviewer(context.Background(), &dto.AddReviewerRequest{PrID: "pr-1", ReviewerID: tt.reviewerID})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, resp.Added)
+			assert.Equal(t, tt.wantAuto, resp.AutoPicked)
+			assert.Equal(t, tt.wantWarnings, resp.Warnings)
+		})
+	}
+}
+
+func TestPRUsecase_RemoveReviewer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		status     domain.PrStatus
+		reviewerID string
+		rules      []pairingdomain.Rule
+		level      userdomain.Level
+		wantErr    error
+	}{
+		{name: "removed without replacement", status: domain.StatusOpen, reviewerID: "u3"},
+		{name: "senior kept by level policy", status: domain.StatusOpen, reviewerID: "u3", level: userdomain.LevelSenior,
+			wantErr: apperr.ErrLevelPolicy},
+		{name: "merged PR", status: domain.StatusMerged, reviewerID: "u3", wantErr: apperr.ErrPRMerged},
+		{name: "not assigned", status: domain.StatusOpen, reviewerID: "u9", wantErr: apperr.ErrNotAssigned},
+		{
+			name:       "available mentor stays",
+			status:     domain.StatusOpen,
+			reviewerID: "u3",
+			rules:      []pairingdomain.Rule{{Kind: pairingdomain.KindRequire, AuthorID: "u1", ReviewerID: "u3"}},
+			wantErr:    apperr.ErrPairingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			pairingReader := mocks.NewMockPairingReader(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: []string{"u2", "u3"},
+			}, nil)
+
+			if tt.status == domain.StatusOpen && tt.wantErr != apperr.ErrNotAssigned {
+				pairingReader.EXPECT().ListAuthorRules(gomock.Any(), "u1", gomock.Any()).Return(tt.rules, nil)
+			}
+			if len(tt.rules) > 0 {
+				userReader.EXPECT().GetUser(gomock.Any(), "u3").
+					Return(&userdomain.User{ID: "u3", TeamName: "backend", IsActive: true}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamdomain.Team{
+					Name:    "backend",
+					Members: []teamdomain.TeamMember{{ID: "u3", IsActive: true}},
+				}, nil)
+			}
+			if tt.wantErr == nil || tt.level != "" {
+				userReader.EXPECT().GetUser(gomock.Any(), "u3").
+					Return(&userdomain.User{ID: "u3", TeamName: "backend", IsActive: true, Level: tt.level}, nil)
+			}
+			if tt.level != "" {
+				// политика команды автора требует одного senior, а u2 - junior
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				userReader.EXPECT().GetUser(gomock.Any(), "u2").
+					Return(&userdomain.User{ID: "u2", TeamName: "backend", IsActive: true, Level: userdomain.LevelJunior}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").Return(&teamdomain.Team{
+					Name: "backend",
+					ReviewerPolicy: &teamdomain.ReviewerPolicy{
+						MinLevel:          userdomain.LevelSenior,
+						MinLevelReviewers: 1,
+					},
+				}, nil)
+			}
+			if tt.wantErr == nil {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+						return pr, nil
+					})
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventPRReviewerUnassigned, events[0].Type)
+
+						var payload outboxdomain.ReviewerUnassignedPayload
+						require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+						assert.Equal(t, "u3", payload.ReviewerID)
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: pairingReader,
+				eventWriter:   eventWriter,
+				decisions:     discardDecisions{},
+				txManager:     testutils.InlineTx{},
+			}
+
+			resp, err := uc.RemoveReviewer(context.Background(), &dto.RemoveReviewerRequest{PrID: "pr-1", ReviewerID: tt.reviewerID})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "u3", resp.Removed)
+		})
+	}
+}
+
+func TestPRUsecase_TopUpPR(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    domain.PrStatus
+		members   []teamdomain.TeamMember
+		assigned  []string
+		wantAdded []string
+		wantErr   error
+	}{
+		{
+			name:   "fills free slot",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true},
+				{ID: "u3", IsActive: true},
+			},
+			assigned:  []string{"u2"},
+			wantAdded: []string{"u3"},
+		},
+		{
+			name:   "fully staffed",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true},
+				{ID: "u3", IsActive: true},
+			},
+			assigned:  []string{"u2", "u3"},
+			wantAdded: []string{},
+		},
+		{
+			name:   "no candidate",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: true},
+				{ID: "u3", IsActive: false},
+			},
+			assigned: []string{"u2"},
+			wantErr:  apperr.ErrNoCandidate,
+		},
+		{
+			name:     "closed PR",
+			status:   domain.StatusClosed,
+			assigned: []string{},
+			wantErr:  apperr.ErrPRClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			decisions := mocks.NewMockDecisionStore(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: tt.assigned,
+			}, nil)
+
+			if tt.status == domain.StatusOpen {
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").
+					Return(&teamdomain.Team{Name: "backend", Members: tt.members}, nil)
+			}
+
+			if len(tt.wantAdded) > 0 {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Equal(t, append(slices.Clone(tt.assigned), tt.wantAdded...), pr.AssignedReviewers)
+						return pr, nil
+					})
+				decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, decision *domain.Decision) error {
+						assert.Equal(t, domain.DecisionAdd, decision.Kind)
+						assert.Equal(t, len(tt.wantAdded), decision.Input.Slots)
+						return nil
+					})
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						assert.Len(t, events, len(tt.wantAdded))
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     decisions,
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.TopUpPR(context.Background(), &dto.TopUpPRRequest{PrID: "pr-1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, resp.Added)
+		})
+	}
+}
+
+func TestPRUsecase_RebalancePR(t *testing.T) {
+	t.Parallel()
+
+	users := map[string]*userdomain.User{
+		"u1": {ID: "u1", TeamName: "backend", IsActive: true},
+		"u2": {ID: "u2", TeamName: "backend", IsActive: false},
+		"u5": {ID: "u5", TeamName: "payments", IsActive: true},
+		"u6": {ID: "u6", TeamName: "backend", IsActive: false, Level: userdomain.LevelSenior},
+	}
+
+	tests := []struct {
+		name     string
+		status   domain.PrStatus
+		members  []teamdomain.TeamMember
+		policy   *teamdomain.ReviewerPolicy
+		assigned []string
+		sources  map[string]domain.ReviewerSource
+		pools    map[string]string
+		// wantUpdate - PR записывается, даже если снятых нет
+		wantUpdate  bool
+		wantAfter   []string
+		wantChanges []domain.ReviewerChange
+		// wantReplaced - сколько снятых получили замену
+		wantReplaced int
+		wantWarning  bool
+		wantErr      error
+	}{
+		{
+			name:   "replaces inactive and departed reviewers",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: false},
+				{ID: "u3", IsActive: true},
+				{ID: "u4", IsActive: true},
+			},
+			assigned:  []string{"u2", "u5"},
+			wantAfter: []string{"u3", "u4"},
+			wantChanges: []domain.ReviewerChange{
+				{ReviewerID: "u2", Reason: domain.StaleInactive},
+				{ReviewerID: "u5", Reason: domain.StaleNotInTeam},
+			},
+			wantReplaced: 2,
+		},
+		{
+			name:   "removes reviewer without replacement",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: false},
+				{ID: "u3", IsActive: true},
+			},
+			assigned:    []string{"u2", "u3"},
+			wantAfter:   []string{"u3"},
+			wantChanges: []domain.ReviewerChange{{ReviewerID: "u2", Reason: domain.StaleInactive}},
+			wantWarning: true,
+		},
+		{
+			name:   "replaces out of office reviewer",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u3", IsActive: true, OutOfOffice: true},
+				{ID: "u4", IsActive: true},
+			},
+			assigned:     []string{"u3"},
+			wantAfter:    []string{"u4"},
+			wantChanges:  []domain.ReviewerChange{{ReviewerID: "u3", Reason: domain.StaleOutOfOffice}},
+			wantReplaced: 1,
+		},
+		{
+			name:   "keeps out of office reviewer without replacement",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u3", IsActive: true, OutOfOffice: true},
+			},
+			assigned:    []string{"u3"},
+			wantAfter:   []string{"u3"},
+			wantChanges: []domain.ReviewerChange{},
+			wantWarning: true,
+		},
+		{
+			name:   "keeps code owners and requested reviewers",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u2", IsActive: false},
+				{ID: "u3", IsActive: true},
+			},
+			assigned: []string{"u5", "u2"},
+			sources: map[string]domain.ReviewerSource{
+				"u5": domain.SourceOwner,
+				"u2": domain.SourceRequested,
+			},
+			wantAfter:   []string{"u5", "u2"},
+			wantChanges: []domain.ReviewerChange{},
+		},
+		{
+			name:   "keeps reviewer needed by level policy",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u6", IsActive: false, Level: userdomain.LevelSenior},
+				{ID: "u3", IsActive: true, Level: userdomain.LevelJunior},
+			},
+			policy: &teamdomain.ReviewerPolicy{
+				TeamReviewers:     1,
+				MinLevel:          userdomain.LevelSenior,
+				MinLevelReviewers: 1,
+			},
+			assigned:    []string{"u6"},
+			wantAfter:   []string{"u6"},
+			wantChanges: []domain.ReviewerChange{},
+			wantWarning: true,
+		},
+		{
+			name:   "persists cleared tag of deleted pool",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u3", IsActive: true},
+			},
+			assigned:    []string{"u3"},
+			pools:       map[string]string{"u3": "deleted"},
+			wantUpdate:  true,
+			wantAfter:   []string{"u3"},
+			wantChanges: []domain.ReviewerChange{},
+		},
+		{
+			name:   "nothing to rebalance",
+			status: domain.StatusOpen,
+			members: []teamdomain.TeamMember{
+				{ID: "u1", IsActive: true},
+				{ID: "u3", IsActive: true},
+				{ID: "u4", IsActive: true},
+			},
+			assigned:    []string{"u3", "u4"},
+			wantAfter:   []string{"u3", "u4"},
+			wantChanges: []domain.ReviewerChange{},
+		},
+		{
+			name:     "merged PR",
+			status:   domain.StatusMerged,
+			assigned: []string{"u2"},
+			wantErr:  apperr.ErrPRMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			decisions := mocks.NewMockDecisionStore(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: slices.Clone(tt.assigned),
+				ReviewerSources:   tt.sources,
+				ReviewerPools:     maps.Clone(tt.pools),
+			}, nil)
+
+			userReader.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ context.Context, id string) (*userdomain.User, error) {
+					user, ok := users[id]
+					if !ok {
+						return nil, apperr.ErrNotFound
+					}
+					return user, nil
+				})
+			teamReader.EXPECT().GetTeam(gomock.Any(), "backend").AnyTimes().
+				Return(&teamdomain.Team{Name: "backend", Members: tt.members, ReviewerPolicy: tt.policy}, nil)
+			teamReader.EXPECT().GetPool(gomock.Any(), gomock.Any()).AnyTimes().
+				Return(nil, apperr.ErrNotFound)
+
+			if tt.wantUpdate {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						assert.Empty(t, pr.ReviewerPools)
+						return pr, nil
+					})
+			}
+			if len(tt.wantChanges) > 0 {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						return pr, nil
+					})
+				decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).Times(tt.wantReplaced).
+					DoAndReturn(func(_ context.Context, decision *domain.Decision) error {
+						assert.Equal(t, domain.DecisionReassign, decision.Kind)
+						return nil
+					})
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						// снятые без замены получают событие о снятии
+						require.Len(t, events, len(tt.wantChanges))
+						assigned := 0
+						for _, event := range events {
+							if event.Type == outboxdomain.EventPRReviewerAssigned {
+								assigned++
+								continue
+							}
+							assert.Equal(t, outboxdomain.EventPRReviewerUnassigned, event.Type)
+						}
+						assert.Equal(t, tt.wantReplaced, assigned)
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     decisions,
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.RebalancePR(context.Background(), &dto.RebalancePRRequest{PrID: "pr-1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.assigned, resp.Before)
+			assert.ElementsMatch(t, tt.wantAfter, resp.After)
+			require.Len(t, resp.Changes, len(tt.wantChanges))
+			for i, change := range resp.Changes {
+				assert.Equal(t, tt.wantChanges[i].ReviewerID, change.ReviewerID)
+				assert.Equal(t, tt.wantChanges[i].Reason, change.Reason)
+				if tt.wantReplaced > 0 {
+					assert.Contains(t, tt.wantAfter, change.ReplacedBy)
+				} else {
+					assert.Empty(t, change.ReplacedBy)
+				}
+			}
+			assert.Equal(t, tt.wantWarning, len(resp.Warnings) > 0)
+		})
+	}
+}
+
+func TestPRUsecase_ClosePR(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		status    domain.PrStatus
+		assigned  []string
+		lockErr   error
+		wantFreed []string
+		wantErr   error
+	}{
+		{
+			name:      "frees reviewers of open PR",
+			status:    domain.StatusOpen,
+			assigned:  []string{"u2", "u3"},
+			wantFreed: []string{"u2", "u3"},
+		},
+		{
+			name:      "closed PR is returned as is",
+			status:    domain.StatusClosed,
+			wantFreed: []string{},
+		},
+		{
+			name:      "merged PR is returned as is",
+			status:    domain.StatusMerged,
+			assigned:  []string{"u2"},
+			wantFreed: []string{},
+		},
+		{
+			name:    "unknown PR",
+			lockErr: apperr.ErrNotFound,
+			wantErr: apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			var locked *domain.PullRequest
+			if tt.lockErr == nil {
+				locked = &domain.PullRequest{
+					ID:                "pr-1",
+					AuthorId:          "u1",
+					Status:            tt.status,
+					AssignedReviewers: slices.Clone(tt.assigned),
+				}
+			}
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(locked, tt.lockErr)
+
+			if tt.status == domain.StatusOpen {
+				prProvider.EXPECT().ClosePR(gomock.Any(), "pr-1", gomock.Any()).
+					Return(&domain.PullRequest{ID: "pr-1", AuthorId: "u1", Status: domain.StatusClosed}, nil)
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, len(tt.wantFreed))
+						for _, event := range events {
+							assert.Equal(t, outboxdomain.EventPRReviewerUnassigned, event.Type)
+						}
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:  prProvider,
+				txManager:   testutils.InlineTx{},
+				eventWriter: eventWriter,
+			}
+
+			resp, err := uc.ClosePR(context.Background(), &dto.ClosePRRequest{PrID: "pr-1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFreed, resp.FreedReviewers)
+		})
+	}
+}
+
+func TestPRUsecase_ReopenPR(t *testing.T) {
+	t.Parallel()
+
+	members := []teamdomain.TeamMember{
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: true},
+	}
+
+	tests := []struct {
+		name         string
+		status       domain.PrStatus
+		members      []teamdomain.TeamMember
+		wantReopened bool
+		wantAdded    int
+		wantWarning  bool
+		wantErr      error
+	}{
+		{
+			name:         "reopens closed PR and assigns reviewers",
+			status:       domain.StatusClosed,
+			members:      members,
+			wantReopened: true,
+			wantAdded:    2,
+		},
+		{
+			name:         "reopens closed PR without candidates",
+			status:       domain.StatusClosed,
+			members:      members[:1],
+			wantReopened: true,
+			wantWarning:  true,
+		},
+		{
+			name:   "open PR is returned as is",
+			status: domain.StatusOpen,
+		},
+		{
+			name:    "merged PR",
+			status:  domain.StatusMerged,
+			wantErr: apperr.ErrPRMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prProvider := mocks.NewMockPRProvider(ctrl)
+			userReader := mocks.NewMockUserReader(ctrl)
+			teamReader := mocks.NewMockTeamReader(ctrl)
+			decisions := mocks.NewMockDecisionStore(ctrl)
+			eventWriter := mocks.NewMockEventWriter(ctrl)
+
+			prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&domain.PullRequest{
+				ID:                "pr-1",
+				AuthorId:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: []string{},
+			}, nil)
+
+			if tt.wantReopened {
+				reopened := &domain.PullRequest{
+					ID:                "pr-1",
+					AuthorId:          "u1",
+					Status:            domain.StatusOpen,
+					AssignedReviewers: []string{},
+				}
+				prProvider.EXPECT().ReopenPR(gomock.Any(), "pr-1").Return(reopened, nil)
+				// доназначение читает переоткрытый PR заново
+				prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(reopened, nil)
+				userReader.EXPECT().GetUser(gomock.Any(), "u1").
+					Return(&userdomain.User{ID: "u1", TeamName: "backend", IsActive: true}, nil)
+				teamReader.EXPECT().GetTeam(gomock.Any(), "backend").
+					Return(&teamdomain.Team{Name: "backend", Members: tt.members}, nil)
+			}
+
+			if tt.wantAdded > 0 {
+				prProvider.EXPECT().UpdatePR(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+						return pr, nil
+					})
+				decisions.EXPECT().SaveDecision(gomock.Any(), gomock.Any()).Return(nil)
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						assert.Len(t, events, tt.wantAdded)
+						return nil
+					})
+			}
+
+			uc := &PRUsecase{
+				prProvider:    prProvider,
+				userReader:    userReader,
+				teamReader:    teamReader,
+				pairingReader: noPairingRules{},
+				decisions:     decisions,
+				txManager:     testutils.InlineTx{},
+				eventWriter:   eventWriter,
+			}
+
+			resp, err := uc.ReopenPR(context.Background(), &dto.ReopenPRRequest{PrID: "pr-1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReopened, resp.Reopened)
+			assert.Equal(t, domain.StatusOpen, resp.PullRequest.Status)
+			assert.Len(t, resp.Added, tt.wantAdded)
+			assert.Equal(t, tt.wantWarning, len(resp.Warnings) > 0)
+		})
+	}
+}
+
+func TestPRUsecase_Tracing(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, spans := testutils.StartTrace(t)
+
+	prProvider := mocks.NewMockPRProvider(ctrl)
+	prProvider.EXPECT().
+		GetReview(gomock.Any(), "u1").
+		DoAndReturn(func(ctx context.Context, _ string) (*[]domain.PullRequest, error) {
+			// запросы репозитория должны попадать в спан метода usecase
+			_, query := tracing.Start(ctx, "SELECT")
+			query.End()
+			return &[]domain.PullRequest{}, nil
+		})
+
+	uc := &PRUsecase{prProvider: prProvider}
+
+	_, err := uc.GetReview(ctx, &dto.GetReviewRequest{UserId: "u1"})
+	require.NoError(t, err)
+
+	ended := spans.Ended()
+	require.Equal(t, []string{"SELECT", "PRUsecase.GetReview"}, spans.Names())
+	assert.Equal(t, ended[1].SpanContext.SpanID(), ended[0].Parent.SpanID())
+	assert.Equal(t, trace.SpanFromContext(ctx).SpanContext().SpanID(), ended[1].Parent.SpanID())
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/sla/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+type AssignmentRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewAssignmentRepository(conn *pgxpool.Pool) *AssignmentRepository {
+	return &AssignmentRepository{conn: conn}
+}
+
+// ListDue выбирает назначения, у которых прошёл срок напоминания (и оно ещё не отправлено)
+// или срок эскалации. SLA берётся по команде автора PR, иначе - значения по умолчанию.
+// Назначение с неудачной эскалацией снова попадает в выборку по эскалации только через
+// escalationRetry и идёт после ни разу не эскалированных, поэтому не вытесняет их из пачки.
+func (a *AssignmentRepository) ListDue(
+	ctx context.Context,
+	defaults teamdomain.ReviewSLA,
+	escalationRetry time.Duration,
+	now time.Time,
+	limit int,
+) ([]domain.Assignment, error) {
+	query := `
+		WITH assignments AS (
+			SELECT r.pull_request_id, r.reviewer_id, r.assigned_at, r.reminded_at,
+			       r.escalation_attempted_at, author.team_name,
+			       COALESCE(s.remind_after, make_interval(secs => $1)) AS remind_after,
+			       COALESCE(s.escalate_after, make_interval(secs => $2)) AS escalate_after
+			FROM pull_request_reviewers r
+			JOIN pull_requests p ON p.id = r.pull_request_id
+			JOIN users author ON author.id = p.author_id
+			LEFT JOIN team_review_sla s ON s.team_name = author.team_name
+			WHERE p.status = $4
+		)
+		SELECT pull_request_id, reviewer_id, assigned_at, reminded_at, escalation_attempted_at, team_name,
+		       EXTRACT(EPOCH FROM remind_after)::BIGINT,
+		       EXTRACT(EPOCH FROM escalate_after)::BIGINT
+		FROM assignments
+		WHERE (escalate_after > INTERVAL '0' AND assigned_at <= $3 - escalate_after
+		       AND (escalation_attempted_at IS NULL
+		            OR escalation_attempted_at <= $3 - make_interval(secs => $6)))
+		   OR (remind_after > INTERVAL '0' AND reminded_at IS NULL AND assigned_at <= $3 - remind_after)
+		ORDER BY escalation_attempted_at NULLS FIRST, assigned_at
+		LIMIT $5
+	`
+
+	rows, err := storage.QuerierFrom(ctx, a.conn).Query(
+		ctx,
+		query,
+		defaults.RemindAfter.Seconds(),
+		defaults.EscalateAfter.Seconds(),
+		now,
+		prdomain.StatusOpen,
+		limit,
+		escalationRetry.Seconds(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list due review assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := make([]domain.Assignment, 0)
+	for rows.Next() {
+		var assignment domain.Assignment
+		var remindSec, escalateSec int64
+
+		if err := rows.Scan(
+			&assignment.PullRequestID,
+			&assignment.ReviewerID,
+			&assignment.AssignedAt,
+			&assignment.RemindedAt,
+			&assignment.EscalationAttemptedAt,
+			&assignment.SLA.TeamName,
+			&remindSec,
+			&escalateSec,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan review assignment: %w", err)
+		}
+
+		assignment.SLA.RemindAfter = time.Duration(remindSec) * time.Second
+		assignment.SLA.EscalateAfter = time.Duration(escalateSec) * time.Second
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (a *AssignmentRepository) MarkReminded(ctx context.Context, prID, reviewerID string, at time.Time) error {
+	query := `
+		UPDATE pull_request_reviewers
+		SET reminded_at = $3
+		WHERE pull_request_id = $1 AND reviewer_id = $2 AND reminded_at IS NULL
+	`
+
+	if _, err := storage.QuerierFrom(ctx, a.conn).Exec(ctx, query, prID, reviewerID, at); err != nil {
+		return fmt.Errorf("db: failed to mark review reminded: %w", err)
+	}
+
+	return nil
+}
+
+// MarkEscalationAttempted запоминает неудачную эскалацию, чтобы отложить следующую попытку.
+func (a *AssignmentRepository) MarkEscalationAttempted(ctx context.Context, prID, reviewerID string, at time.Time) error {
+	query := `
+		UPDATE pull_request_reviewers
+		SET escalation_attempted_at = $3
+		WHERE pull_request_id = $1 AND reviewer_id = $2
+	`
+
+	if _, err := storage.QuerierFrom(ctx, a.conn).Exec(ctx, query, prID, reviewerID, at); err != nil {
+		return fmt.Errorf("db: failed to mark escalation attempt: %w", err)
+	}
+
+	return nil
+}
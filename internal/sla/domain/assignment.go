@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+type Action string
+
+const (
+	ActionNone     Action = ""
+	ActionRemind   Action = "remind"
+	ActionEscalate Action = "escalate"
+)
+
+// Assignment - назначение ревьювера на OPEN PR вместе с SLA команды автора.
+type Assignment struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+	RemindedAt    *time.Time
+	// EscalationAttemptedAt - последняя эскалация, которую не удалось выполнить.
+	EscalationAttemptedAt *time.Time
+	SLA                   teamdomain.ReviewSLA
+}
+
+// Due определяет, что делать с назначением в момент now. Эскалация важнее напоминания:
+// если ревьювер пропустил оба срока, ревью сразу передаётся другому. После неудачной
+// эскалации следующая попытка откладывается на retry.
+func (a *Assignment) Due(now time.Time, retry time.Duration) Action {
+	waiting := now.Sub(a.AssignedAt)
+
+	if a.SLA.EscalateAfter > 0 && waiting >= a.SLA.EscalateAfter && a.canRetryEscalation(now, retry) {
+		return ActionEscalate
+	}
+
+	if a.CanRemind() && waiting >= a.SLA.RemindAfter {
+		return ActionRemind
+	}
+
+	return ActionNone
+}
+
+func (a *Assignment) canRetryEscalation(now time.Time, retry time.Duration) bool {
+	return a.EscalationAttemptedAt == nil || now.Sub(*a.EscalationAttemptedAt) >= retry
+}
+
+// CanRemind - напоминание включено и ещё не отправлялось для этого назначения.
+func (a *Assignment) CanRemind() bool {
+	return a.SLA.RemindAfter > 0 && a.RemindedAt == nil
+}
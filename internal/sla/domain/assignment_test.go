@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignment_Due(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 2, 12, 0, 0, 0, time.UTC)
+	sla := teamdomain.ReviewSLA{RemindAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}
+	reminded := now.Add(-time.Hour)
+	attempted := now.Add(-time.Hour)
+	attemptedLongAgo := now.Add(-7 * time.Hour)
+
+	tests := []struct {
+		name       string
+		assignedAt time.Time
+		remindedAt *time.Time
+		attempted  *time.Time
+		sla        teamdomain.ReviewSLA
+		want       Action
+	}{
+		{
+			name:       "within_sla",
+			assignedAt: now.Add(-time.Hour),
+			sla:        sla,
+			want:       ActionNone,
+		},
+		{
+			name:       "remind_after_threshold",
+			assignedAt: now.Add(-24 * time.Hour),
+			sla:        sla,
+			want:       ActionRemind,
+		},
+		{
+			name:       "already_reminded",
+			assignedAt: now.Add(-48 * time.Hour),
+			remindedAt: &reminded,
+			sla:        sla,
+			want:       ActionNone,
+		},
+		{
+			name:       "escalate_after_threshold",
+			assignedAt: now.Add(-72 * time.Hour),
+			remindedAt: &reminded,
+			sla:        sla,
+			want:       ActionEscalate,
+		},
+		{
+			name:       "escalate_wins_over_missed_reminder",
+			assignedAt: now.Add(-100 * time.Hour),
+			sla:        sla,
+			want:       ActionEscalate,
+		},
+		{
+			name:       "escalation_backs_off_after_failed_attempt",
+			assignedAt: now.Add(-100 * time.Hour),
+			remindedAt: &reminded,
+			attempted:  &attempted,
+			sla:        sla,
+			want:       ActionNone,
+		},
+		{
+			name:       "reminder_sent_while_escalation_backs_off",
+			assignedAt: now.Add(-100 * time.Hour),
+			attempted:  &attempted,
+			sla:        sla,
+			want:       ActionRemind,
+		},
+		{
+			name:       "escalation_retried_after_backoff",
+			assignedAt: now.Add(-100 * time.Hour),
+			remindedAt: &reminded,
+			attempted:  &attemptedLongAgo,
+			sla:        sla,
+			want:       ActionEscalate,
+		},
+		{
+			name:       "reminders_disabled",
+			assignedAt: now.Add(-48 * time.Hour),
+			sla:        teamdomain.ReviewSLA{EscalateAfter: 72 * time.Hour},
+			want:       ActionNone,
+		},
+		{
+			name:       "escalation_disabled",
+			assignedAt: now.Add(-1000 * time.Hour),
+			remindedAt: &reminded,
+			sla:        teamdomain.ReviewSLA{RemindAfter: 24 * time.Hour},
+			want:       ActionNone,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &Assignment{
+				PullRequestID:         "pr-1",
+				ReviewerID:            "u2",
+				AssignedAt:            tt.assignedAt,
+				RemindedAt:            tt.remindedAt,
+				SLA:                   tt.sla,
+				EscalationAttemptedAt: tt.attempted,
+			}
+			assert.Equal(t, tt.want, a.Due(now, 6*time.Hour))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/sla/domain"
+)
+
+// CheckResult - итог одного прохода проверки SLA.
+type CheckResult struct {
+	Reminded  int
+	Escalated int
+	Failed    int
+}
+
+// Run периодически проверяет SLA, пока не отменён контекст. Проход выполняет только
+// реплика, получившая advisory-блокировку, остальные пропускают тик.
+func (u *SLAUsecase) Run(ctx context.Context) {
+	slog.Info("SLAUsecase.Run: review sla scheduler started",
+		slog.Duration("check_interval", u.cfg.CheckInterval),
+	)
+
+	ticker := time.NewTicker(u.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("SLAUsecase.Run: review sla scheduler stopped")
+			return
+		case <-ticker.C:
+			var result CheckResult
+			leader, err := u.locker.TryRun(ctx, func(ctx context.Context) error {
+				var err error
+				result, err = u.Check(ctx, time.Now())
+				return err
+			})
+			if err != nil {
+				slog.Error("SLAUsecase.Run: check failed", slog.Any("error", err))
+				continue
+			}
+			if !leader {
+				continue
+			}
+			if result.Reminded > 0 || result.Escalated > 0 || result.Failed > 0 {
+				slog.Info("SLAUsecase.Run: check finished",
+					slog.Int("reminded", result.Reminded),
+					slog.Int("escalated", result.Escalated),
+					slog.Int("failed", result.Failed),
+				)
+			}
+		}
+	}
+}
+
+// Check отправляет напоминания и эскалирует просроченные назначения на момент now.
+// Ошибка по одному назначению не прерывает проход: оно будет обработано на следующем тике.
+func (u *SLAUsecase) Check(ctx context.Context, now time.Time) (CheckResult, error) {
+	var result CheckResult
+
+	assignments, err := u.assignmentProvider.ListDue(ctx, u.cfg.Defaults, u.cfg.EscalationRetry, now, u.cfg.BatchSize)
+	if err != nil {
+		return result, fmt.Errorf("list due assignments in provider: %w", err)
+	}
+
+	for i := range assignments {
+		assignment := &assignments[i]
+
+		switch assignment.Due(now, u.cfg.EscalationRetry) {
+		case domain.ActionEscalate:
+			escalated, err := u.escalate(ctx, assignment, now)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+			if escalated {
+				result.Escalated++
+				continue
+			}
+			// заменить некем - хотя бы напоминаем, эскалация повторится через EscalationRetry
+			if !assignment.CanRemind() {
+				continue
+			}
+			fallthrough
+		case domain.ActionRemind:
+			reminded, err := u.remind(ctx, assignment, now)
+			if err != nil {
+				slog.Error("SLAUsecase.Check: failed to send reminder",
+					slog.String("pr_id", assignment.PullRequestID),
+					slog.String("reviewer_id", assignment.ReviewerID),
+					slog.Any("error", err),
+				)
+				result.Failed++
+				continue
+			}
+			if reminded {
+				result.Reminded++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// escalate передаёт ревью другому участнику. false без ошибки - замену выполнить нельзя
+// (нет кандидатов, PR уже слит или закрыт, ревьювер снят), это не сбой планировщика;
+// попытка запоминается, чтобы назначение не попадало в каждую следующую выборку.
+func (u *SLAUsecase) escalate(ctx context.Context, assignment *domain.Assignment, now time.Time) (bool, error) {
+	resp, err := u.reassigner.ReassignPR(ctx, &prdto.ReassignPRRequest{
+		PrID:          assignment.PullRequestID,
+		OldReviewerId: assignment.ReviewerID,
+	})
+	if err != nil {
+		if errors.Is(err, apperr.ErrNoCandidate) ||
+			errors.Is(err, apperr.ErrLevelPolicy) ||
+			errors.Is(err, apperr.ErrPairingRule) ||
+			errors.Is(err, apperr.ErrPRMerged) ||
+			errors.Is(err, apperr.ErrPRClosed) ||
+			errors.Is(err, apperr.ErrNotAssigned) ||
+			errors.Is(err, apperr.ErrNotFound) {
+			slog.Info("SLAUsecase.Check: escalation skipped",
+				slog.String("pr_id", assignment.PullRequestID),
+				slog.String("reviewer_id", assignment.ReviewerID),
+				slog.Any("reason", err),
+			)
+			if err := u.assignmentProvider.MarkEscalationAttempted(ctx, assignment.PullRequestID,
+				assignment.ReviewerID, now); err != nil {
+				slog.Error("SLAUsecase.Check: failed to record escalation attempt",
+					slog.String("pr_id", assignment.PullRequestID),
+					slog.String("reviewer_id", assignment.ReviewerID),
+					slog.Any("error", err),
+				)
+				return false, fmt.Errorf("mark escalation attempt in provider: %w", err)
+			}
+			return false, nil
+		}
+		slog.Error("SLAUsecase.Check: failed to escalate",
+			slog.String("pr_id", assignment.PullRequestID),
+			slog.String("reviewer_id", assignment.ReviewerID),
+			slog.Any("error", err),
+		)
+		return false, err
+	}
+
+	slog.Info("SLAUsecase.Check: review escalated",
+		slog.String("pr_id", assignment.PullRequestID),
+		slog.String("old_reviewer_id", assignment.ReviewerID),
+		slog.String("new_reviewer_id", resp.ReplacedBy),
+		slog.Time("assigned_at", assignment.AssignedAt),
+	)
+
+	return true, nil
+}
+
+// remind пишет событие напоминания и отметку о нём в одной транзакции,
+// поэтому повторный проход не напомнит дважды.
+func (u *SLAUsecase) remind(ctx context.Context, assignment *domain.Assignment, now time.Time) (bool, error) {
+	pr, err := u.prReader.GetPR(ctx, assignment.PullRequestID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get pull request from provider: %w", err)
+	}
+
+	// между выборкой и напоминанием PR могли слить или переназначить
+	if pr.IsMerged() || !slices.Contains(pr.AssignedReviewers, assignment.ReviewerID) {
+		return false, nil
+	}
+
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		event, err := outboxdomain.NewEvent(outboxdomain.EventPRReviewReminder, pr.ID,
+			outboxdomain.ReviewReminderPayload{
+				PullRequest: *pr,
+				ReviewerID:  assignment.ReviewerID,
+				AssignedAt:  assignment.AssignedAt,
+			})
+		if err != nil {
+			return fmt.Errorf("build review reminder event: %w", err)
+		}
+
+		if err := u.eventWriter.Append(ctx, event); err != nil {
+			return fmt.Errorf("append review reminder event: %w", err)
+		}
+
+		if err := u.assignmentProvider.MarkReminded(ctx, pr.ID, assignment.ReviewerID, now); err != nil {
+			return fmt.Errorf("mark reminded in provider: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
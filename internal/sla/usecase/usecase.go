@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/sla/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+type AssignmentProvider interface {
+	// ListDue возвращает назначения на OPEN PR, по которым пора напомнить или эскалировать.
+	// Для команд без собственного SLA используется defaults; назначения с неудачной
+	// эскалацией возвращаются для повторной эскалации не раньше, чем через escalationRetry.
+	ListDue(ctx context.Context, defaults teamdomain.ReviewSLA, escalationRetry time.Duration,
+		now time.Time, limit int) ([]domain.Assignment, error)
+	MarkReminded(ctx context.Context, prID, reviewerID string, at time.Time) error
+	MarkEscalationAttempted(ctx context.Context, prID, reviewerID string, at time.Time) error
+}
+
+type PRReader interface {
+	GetPR(ctx context.Context, id string) (*prdomain.PullRequest, error)
+}
+
+// Reassigner - переназначение ревьювера той же логикой, что и /pullRequest/reassign.
+type Reassigner interface {
+	ReassignPR(ctx context.Context, request *prdto.ReassignPRRequest) (*prdto.ReassignPRResponse, error)
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker выбирает реплику, которая выполняет проверку SLA.
+type Locker interface {
+	TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+type Config struct {
+	CheckInterval time.Duration
+	BatchSize     int
+	Defaults      teamdomain.ReviewSLA
+	// EscalationRetry - через сколько повторять эскалацию, которую не удалось выполнить.
+	EscalationRetry time.Duration
+}
+
+type SLAUsecase struct {
+	assignmentProvider AssignmentProvider
+	prReader           PRReader
+	reassigner         Reassigner
+	eventWriter        EventWriter
+	txManager          Transactor
+	locker             Locker
+	cfg                Config
+}
+
+func NewSLAUsecase(
+	repo AssignmentProvider,
+	prReader PRReader,
+	reassigner Reassigner,
+	eventWriter EventWriter,
+	txManager Transactor,
+	locker Locker,
+	cfg Config,
+) *SLAUsecase {
+	return &SLAUsecase{
+		assignmentProvider: repo,
+		prReader:           prReader,
+		reassigner:         reassigner,
+		eventWriter:        eventWriter,
+		txManager:          txManager,
+		locker:             locker,
+		cfg:                cfg,
+	}
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/sla/domain"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAUsecase_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 2, 12, 0, 0, 0, time.UTC)
+	sla := teamdomain.ReviewSLA{TeamName: "backend", RemindAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}
+	openPR := &prdomain.PullRequest{ID: "pr-1", Name: "Fix", AuthorId: "u1", Status: prdomain.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	mergedPR := &prdomain.PullRequest{ID: "pr-1", Status: prdomain.StatusMerged, AssignedReviewers: []string{"u2"}}
+
+	overdueForReminder := domain.Assignment{PullRequestID: "pr-1", ReviewerID: "u2", AssignedAt: now.Add(-30 * time.Hour), SLA: sla}
+	overdueForEscalation := domain.Assignment{PullRequestID: "pr-1", ReviewerID: "u2", AssignedAt: now.Add(-80 * time.Hour), SLA: sla}
+	attemptedAt := now.Add(-time.Hour)
+	escalationBackingOff := overdueForEscalation
+	escalationBackingOff.EscalationAttemptedAt = &attemptedAt
+
+	tests := []struct {
+		name          string
+		assignments   []domain.Assignment
+		pr            *prdomain.PullRequest
+		reassignErr   error
+		wantReassign  bool
+		wantReminder  bool
+		wantAttempt   bool
+		attemptErr    error
+		wantResult    CheckResult
+		wantCheckFail bool
+		listErr       error
+	}{
+		{
+			name:         "reminds_reviewer",
+			assignments:  []domain.Assignment{overdueForReminder},
+			pr:           openPR,
+			wantReminder: true,
+			wantResult:   CheckResult{Reminded: 1},
+		},
+		{
+			name:        "reminder_skipped_for_merged_pr",
+			assignments: []domain.Assignment{overdueForReminder},
+			pr:          mergedPR,
+			wantResult:  CheckResult{},
+		},
+		{
+			name:         "escalates_reviewer",
+			assignments:  []domain.Assignment{overdueForEscalation},
+			wantReassign: true,
+			wantResult:   CheckResult{Escalated: 1},
+		},
+		{
+			name:         "no_candidate_falls_back_to_reminder",
+			assignments:  []domain.Assignment{overdueForEscalation},
+			pr:           openPR,
+			reassignErr:  apperr.ErrNoCandidate,
+			wantReassign: true,
+			wantReminder: true,
+			wantAttempt:  true,
+			wantResult:   CheckResult{Reminded: 1},
+		},
+		{
+			name:         "attempt_record_failure_is_counted",
+			assignments:  []domain.Assignment{overdueForEscalation},
+			reassignErr:  apperr.ErrNoCandidate,
+			wantReassign: true,
+			wantAttempt:  true,
+			attemptErr:   errors.New("db down"),
+			wantResult:   CheckResult{Failed: 1},
+		},
+		{
+			name:         "escalation_backs_off_after_failed_attempt",
+			assignments:  []domain.Assignment{escalationBackingOff},
+			pr:           openPR,
+			wantReminder: true,
+			wantResult:   CheckResult{Reminded: 1},
+		},
+		{
+			name:         "escalation_failure_is_counted",
+			assignments:  []domain.Assignment{overdueForEscalation},
+			reassignErr:  errors.New("db down"),
+			wantReassign: true,
+			wantResult:   CheckResult{Failed: 1},
+		},
+		{
+			name:          "list_error",
+			listErr:       errors.New("db down"),
+			wantCheckFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			assignmentProvider := mocks.NewMockAssignmentProvider(ctrl)
+			prReader := mocks.NewMockPRReader(ctrl)
+			reassigner := mocks.NewMockReassigner(ctrl)
+			eventWriter := mocks.NewMockSLAEventWriter(ctrl)
+
+			defaults := teamdomain.ReviewSLA{RemindAfter: time.Hour, EscalateAfter: 2 * time.Hour}
+			assignmentProvider.EXPECT().
+				ListDue(gomock.Any(), defaults, 6*time.Hour, now, 50).
+				Return(tt.assignments, tt.listErr)
+
+			if tt.wantReassign {
+				reassigner.EXPECT().
+					ReassignPR(gomock.Any(), &prdto.ReassignPRRequest{PrID: "pr-1", OldReviewerId: "u2"}).
+					DoAndReturn(func(context.Context, *prdto.ReassignPRRequest) (*prdto.ReassignPRResponse, error) {
+						if tt.reassignErr != nil {
+							return nil, tt.reassignErr
+						}
+						return &prdto.ReassignPRResponse{ReplacedBy: "u4"}, nil
+					})
+			}
+
+			if tt.wantAttempt {
+				assignmentProvider.EXPECT().
+					MarkEscalationAttempted(gomock.Any(), "pr-1", "u2", now).
+					Return(tt.attemptErr)
+			}
+
+			if tt.pr != nil {
+				prReader.EXPECT().GetPR(gomock.Any(), "pr-1").Return(tt.pr, nil)
+			}
+
+			if tt.wantReminder {
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventPRReviewReminder, events[0].Type)
+
+						var payload outboxdomain.ReviewReminderPayload
+						require.NoError(t, json.Unmarshal(events[0].Payload, &payload))
+						assert.Equal(t, "u2", payload.ReviewerID)
+						assert.Equal(t, "pr-1", payload.PullRequest.ID)
+						return nil
+					})
+				assignmentProvider.EXPECT().MarkReminded(gomock.Any(), "pr-1", "u2", now).Return(nil)
+			}
+
+			uc := &SLAUsecase{
+				assignmentProvider: assignmentProvider,
+				prReader:           prReader,
+				reassigner:         reassigner,
+				eventWriter:        eventWriter,
+				txManager:          testutils.InlineTx{},
+				cfg:                Config{BatchSize: 50, Defaults: defaults, EscalationRetry: 6 * time.Hour},
+			}
+
+			result, err := uc.Check(context.Background(), now)
+			if tt.wantCheckFail {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
+
+// fakeAssignments повторяет фильтр и порядок выборки ListDue из postgres-репозитория.
+type fakeAssignments struct {
+	rows []domain.Assignment
+}
+
+func (f *fakeAssignments) ListDue(
+	_ context.Context,
+	_ teamdomain.ReviewSLA,
+	escalationRetry time.Duration,
+	now time.Time,
+	limit int,
+) ([]domain.Assignment, error) {
+	var due []domain.Assignment
+	for _, row := range f.rows {
+		if row.Due(now, escalationRetry) != domain.ActionNone {
+			due = append(due, row)
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		ai, aj := due[i].EscalationAttemptedAt, due[j].EscalationAttemptedAt
+		if (ai == nil) != (aj == nil) {
+			return ai == nil
+		}
+		if ai != nil && !ai.Equal(*aj) {
+			return ai.Before(*aj)
+		}
+		return due[i].AssignedAt.Before(due[j].AssignedAt)
+	})
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (f *fakeAssignments) MarkReminded(_ context.Context, prID, reviewerID string, at time.Time) error {
+	f.row(prID, reviewerID).RemindedAt = &at
+	return nil
+}
+
+func (f *fakeAssignments) MarkEscalationAttempted(_ context.Context, prID, reviewerID string, at time.Time) error {
+	f.row(prID, reviewerID).EscalationAttemptedAt = &at
+	return nil
+}
+
+func (f *fakeAssignments) row(prID, reviewerID string) *domain.Assignment {
+	for i := range f.rows {
+		if f.rows[i].PullRequestID == prID && f.rows[i].ReviewerID == reviewerID {
+			return &f.rows[i]
+		}
+	}
+	return nil
+}
+
+func TestSLAUsecase_Check_SkippedEscalationsDoNotStarveBatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 2, 12, 0, 0, 0, time.UTC)
+	sla := teamdomain.ReviewSLA{TeamName: "backend", RemindAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour}
+	remindedAt := now.Add(-48 * time.Hour)
+
+	// три назначения, которые некем заменить, старше нового - больше, чем помещается в пачку
+	provider := &fakeAssignments{}
+	for i, prID := range []string{"pr-1", "pr-2", "pr-3", "pr-4"} {
+		provider.rows = append(provider.rows, domain.Assignment{
+			PullRequestID: prID,
+			ReviewerID:    "u2",
+			AssignedAt:    now.Add(-time.Duration(100-i) * time.Hour),
+			RemindedAt:    &remindedAt,
+			SLA:           sla,
+		})
+	}
+
+	ctrl := gomock.NewController(t)
+	reassigner := mocks.NewMockReassigner(ctrl)
+	reassigner.EXPECT().
+		ReassignPR(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, req *prdto.ReassignPRRequest) (*prdto.ReassignPRResponse, error) {
+			if req.PrID == "pr-4" {
+				return &prdto.ReassignPRResponse{ReplacedBy: "u5"}, nil
+			}
+			return nil, apperr.ErrNoCandidate
+		}).
+		Times(4)
+
+	uc := &SLAUsecase{
+		assignmentProvider: provider,
+		reassigner:         reassigner,
+		txManager:          testutils.InlineTx{},
+		cfg:                Config{BatchSize: 2, EscalationRetry: 6 * time.Hour},
+	}
+
+	result, err := uc.Check(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, CheckResult{}, result)
+
+	result, err = uc.Check(context.Background(), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, CheckResult{Escalated: 1}, result)
+
+	for _, row := range provider.rows[:3] {
+		require.NotNil(t, row.EscalationAttemptedAt, row.PullRequestID)
+	}
+}
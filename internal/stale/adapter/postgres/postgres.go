@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+)
+
+type ActionRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewActionRepository(conn *pgxpool.Pool) *ActionRepository {
+	return &ActionRepository{conn: conn}
+}
+
+func (a *ActionRepository) RecordAction(ctx context.Context, record *domain.Record) error {
+	query := `
+		INSERT INTO pull_request_actions (pull_request_id, action, details, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	if err := storage.QuerierFrom(ctx, a.conn).QueryRow(
+		ctx,
+		query,
+		record.PullRequestID,
+		record.Action,
+		record.Details,
+		record.CreatedAt,
+	).Scan(&record.ID); err != nil {
+		return fmt.Errorf("db: failed to record pull request action: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+)
+
+type Action string
+
+const (
+	ActionNone      Action = ""
+	ActionMarkStale Action = "stale"
+	ActionClose     Action = "closed"
+)
+
+// Policy - когда OPEN PR считается заброшенным. Сроки отсчитываются от created_at:
+// после StaleAfter PR помечается и автор получает предупреждение, после CloseAfter PR
+// закрывается. Закрывается только помеченный PR и не раньше, чем через CloseAfter-StaleAfter
+// после пометки, чтобы у автора всегда было время отреагировать. Нулевое значение отключает шаг.
+type Policy struct {
+	StaleAfter time.Duration
+	CloseAfter time.Duration
+}
+
+func (p Policy) Validate() error {
+	if p.StaleAfter < 0 || p.CloseAfter < 0 {
+		return errors.New("stale policy durations must not be negative")
+	}
+
+	if p.CloseAfter > 0 && (p.StaleAfter == 0 || p.CloseAfter <= p.StaleAfter) {
+		return errors.New("close_after requires stale_after and must be greater than it")
+	}
+
+	return nil
+}
+
+func (p Policy) Enabled() bool {
+	return p.StaleAfter > 0
+}
+
+// noticePeriod - сколько PR проводит помеченным до закрытия.
+func (p Policy) noticePeriod() time.Duration {
+	return p.CloseAfter - p.StaleAfter
+}
+
+// Due определяет, что сделать с PR в момент now.
+func (p Policy) Due(pr *prdomain.PullRequest, now time.Time) Action {
+	if !p.Enabled() || pr.Status != prdomain.StatusOpen {
+		return ActionNone
+	}
+
+	age := now.Sub(pr.CreatedAt)
+
+	if pr.StaleAt == nil {
+		if age >= p.StaleAfter {
+			return ActionMarkStale
+		}
+		return ActionNone
+	}
+
+	if p.CloseAfter > 0 && age >= p.CloseAfter && now.Sub(*pr.StaleAt) >= p.noticePeriod() {
+		return ActionClose
+	}
+
+	return ActionNone
+}
+
+// CloseAt - когда PR будет закрыт; nil, если закрытие выключено.
+// Для ещё не помеченного PR считается, что пометка произойдёт вовремя.
+func (p Policy) CloseAt(pr *prdomain.PullRequest) *time.Time {
+	if !p.Enabled() || p.CloseAfter == 0 {
+		return nil
+	}
+
+	closeAt := pr.CreatedAt.Add(p.CloseAfter)
+	if pr.StaleAt != nil {
+		if afterNotice := pr.StaleAt.Add(p.noticePeriod()); afterNotice.After(closeAt) {
+			closeAt = afterNotice
+		}
+	}
+
+	return &closeAt
+}
+
+// Thresholds - границы выборки кандидатов в момент now: созданные до staleBefore и ещё
+// не помеченные, либо созданные до closeBefore и помеченные до flaggedBefore.
+// При выключенном закрытии closeBefore - нулевое время, и под него ничего не попадает.
+func (p Policy) Thresholds(now time.Time) (staleBefore, closeBefore, flaggedBefore time.Time) {
+	staleBefore = now.Add(-p.StaleAfter)
+	if p.CloseAfter > 0 {
+		closeBefore = now.Add(-p.CloseAfter)
+		flaggedBefore = now.Add(-p.noticePeriod())
+	}
+	return staleBefore, closeBefore, flaggedBefore
+}
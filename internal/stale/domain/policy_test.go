@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const day = 24 * time.Hour
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr bool
+	}{
+		{name: "stale_and_close", policy: Policy{StaleAfter: 14 * day, CloseAfter: 30 * day}},
+		{name: "only_stale", policy: Policy{StaleAfter: 14 * day}},
+		{name: "disabled", policy: Policy{}},
+		{name: "close_without_stale", policy: Policy{CloseAfter: 30 * day}, wantErr: true},
+		{name: "close_before_stale", policy: Policy{StaleAfter: 30 * day, CloseAfter: 14 * day}, wantErr: true},
+		{name: "negative", policy: Policy{StaleAfter: -day}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicy_Due(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+	policy := Policy{StaleAfter: 14 * day, CloseAfter: 30 * day}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		status    prdomain.PrStatus
+		createdAt time.Time
+		staleAt   *time.Time
+		want      Action
+	}{
+		{
+			name:      "fresh_pr",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-3 * day),
+			want:      ActionNone,
+		},
+		{
+			name:      "old_pr_is_marked",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-14 * day),
+			want:      ActionMarkStale,
+		},
+		{
+			name:      "marked_pr_waits_for_close_after",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-20 * day),
+			staleAt:   ptr(now.Add(-6 * day)),
+			want:      ActionNone,
+		},
+		{
+			name:      "marked_pr_is_closed",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-30 * day),
+			staleAt:   ptr(now.Add(-16 * day)),
+			want:      ActionClose,
+		},
+		{
+			name:      "very_old_pr_is_marked_before_close",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-90 * day),
+			want:      ActionMarkStale,
+		},
+		{
+			name:      "late_mark_keeps_notice_period",
+			policy:    policy,
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-90 * day),
+			staleAt:   ptr(now.Add(-2 * day)),
+			want:      ActionNone,
+		},
+		{
+			name:      "close_disabled",
+			policy:    Policy{StaleAfter: 14 * day},
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-90 * day),
+			staleAt:   ptr(now.Add(-60 * day)),
+			want:      ActionNone,
+		},
+		{
+			name:      "merged_pr_is_ignored",
+			policy:    policy,
+			status:    prdomain.StatusMerged,
+			createdAt: now.Add(-90 * day),
+			want:      ActionNone,
+		},
+		{
+			name:      "policy_disabled",
+			policy:    Policy{},
+			status:    prdomain.StatusOpen,
+			createdAt: now.Add(-90 * day),
+			want:      ActionNone,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pr := &prdomain.PullRequest{Status: tt.status, CreatedAt: tt.createdAt, StaleAt: tt.staleAt}
+			assert.Equal(t, tt.want, tt.policy.Due(pr, now))
+		})
+	}
+}
+
+func TestPolicy_CloseAt(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	policy := Policy{StaleAfter: 14 * day, CloseAfter: 30 * day}
+
+	pr := &prdomain.PullRequest{Status: prdomain.StatusOpen, CreatedAt: created}
+	require.NotNil(t, policy.CloseAt(pr))
+	assert.Equal(t, created.Add(30*day), *policy.CloseAt(pr))
+
+	// помечен с опозданием - закрытие сдвигается на полный срок предупреждения
+	lateMark := created.Add(40 * day)
+	pr.StaleAt = &lateMark
+	assert.Equal(t, lateMark.Add(16*day), *policy.CloseAt(pr))
+
+	assert.Nil(t, Policy{StaleAfter: 14 * day}.CloseAt(pr))
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Record - запись журнала pull_request_actions об автоматическом действии над PR.
+type Record struct {
+	ID            int64           `json:"id"`
+	PullRequestID string          `json:"pull_request_id"`
+	Action        Action          `json:"action"`
+	Details       json.RawMessage `json:"details"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type StaleDetails struct {
+	CreatedAt time.Time  `json:"created_at"`
+	CloseAt   *time.Time `json:"close_at,omitempty"`
+}
+
+type ClosedDetails struct {
+	StaleAt        *time.Time `json:"stale_at,omitempty"`
+	FreedReviewers []string   `json:"freed_reviewers"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type StalePullRequest struct {
+	ID                string     `json:"pull_request_id"`
+	Name              string     `json:"pull_request_name"`
+	AuthorId          string     `json:"author_id"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AgeDays           int        `json:"age_days"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	StaleAt           *time.Time `json:"staleAt,omitempty"`
+	CloseAt           *time.Time `json:"closeAt,omitempty"`
+}
+
+type StaleReportResponse struct {
+	StaleAfterDays int                `json:"stale_after_days"`
+	CloseAfterDays int                `json:"close_after_days"`
+	PullRequests   []StalePullRequest `json:"pull_requests"`
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/stale/dto"
+)
+
+const day = 24 * time.Hour
+
+// Report возвращает OPEN PR старше срока stale_after - и уже помеченные, и ещё ожидающие
+// пометки - с датой предстоящего закрытия.
+func (u *StaleUsecase) Report(ctx context.Context, now time.Time) (*dto.StaleReportResponse, error) {
+	policy := u.cfg.Policy
+
+	resp := &dto.StaleReportResponse{
+		StaleAfterDays: int(policy.StaleAfter / day),
+		CloseAfterDays: int(policy.CloseAfter / day),
+		PullRequests:   make([]dto.StalePullRequest, 0),
+	}
+
+	if !policy.Enabled() {
+		return resp, nil
+	}
+
+	prs, err := u.prProvider.ListOpenCreatedBefore(ctx, now.Add(-policy.StaleAfter))
+	if err != nil {
+		slog.Error("StaleUsecase.Report: failed to list pull requests",
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("list open pull requests in provider: %w", err)
+	}
+
+	for i := range prs {
+		pr := &prs[i]
+		resp.PullRequests = append(resp.PullRequests, dto.StalePullRequest{
+			ID:                pr.ID,
+			Name:              pr.Name,
+			AuthorId:          pr.AuthorId,
+			AssignedReviewers: pr.AssignedReviewers,
+			AgeDays:           int(now.Sub(pr.CreatedAt) / day),
+			CreatedAt:         pr.CreatedAt,
+			StaleAt:           pr.StaleAt,
+			CloseAt:           policy.CloseAt(pr),
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+)
+
+// SweepResult - итог одного прохода по заброшенным PR.
+type SweepResult struct {
+	Marked int
+	Closed int
+	Failed int
+}
+
+// Run периодически помечает и закрывает заброшенные PR, пока не отменён контекст.
+// Проход выполняет только реплика, получившая advisory-блокировку.
+func (u *StaleUsecase) Run(ctx context.Context) {
+	slog.Info("StaleUsecase.Run: stale pull request sweeper started",
+		slog.Duration("check_interval", u.cfg.CheckInterval),
+		slog.Duration("stale_after", u.cfg.Policy.StaleAfter),
+		slog.Duration("close_after", u.cfg.Policy.CloseAfter),
+	)
+
+	ticker := time.NewTicker(u.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("StaleUsecase.Run: stale pull request sweeper stopped")
+			return
+		case <-ticker.C:
+			var result SweepResult
+			leader, err := u.locker.TryRun(ctx, func(ctx context.Context) error {
+				var err error
+				result, err = u.Sweep(ctx, time.Now())
+				return err
+			})
+			if err != nil {
+				slog.Error("StaleUsecase.Run: sweep failed", slog.Any("error", err))
+				continue
+			}
+			if !leader {
+				continue
+			}
+			if result.Marked > 0 || result.Closed > 0 || result.Failed > 0 {
+				slog.Info("StaleUsecase.Run: sweep finished",
+					slog.Int("marked", result.Marked),
+					slog.Int("closed", result.Closed),
+					slog.Int("failed", result.Failed),
+				)
+			}
+		}
+	}
+}
+
+// Sweep обрабатывает одну пачку кандидатов на момент now. Каждый PR обрабатывается в своей
+// транзакции: состояние PR, запись журнала и событие для автора пишутся атомарно.
+func (u *StaleUsecase) Sweep(ctx context.Context, now time.Time) (SweepResult, error) {
+	var result SweepResult
+
+	if !u.cfg.Policy.Enabled() {
+		return result, nil
+	}
+
+	staleBefore, closeBefore, flaggedBefore := u.cfg.Policy.Thresholds(now)
+	candidates, err := u.prProvider.ListStaleCandidates(ctx, staleBefore, closeBefore, flaggedBefore, u.cfg.BatchSize)
+	if err != nil {
+		return result, fmt.Errorf("list stale candidates in provider: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		action, err := u.apply(ctx, candidate.ID, now)
+		if err != nil {
+			slog.Error("StaleUsecase.Sweep: failed to process pull request",
+				slog.String("pr_id", candidate.ID),
+				slog.Any("error", err),
+			)
+			result.Failed++
+			continue
+		}
+
+		switch action {
+		case domain.ActionMarkStale:
+			result.Marked++
+		case domain.ActionClose:
+			result.Closed++
+		}
+	}
+
+	return result, nil
+}
+
+// apply перечитывает PR под блокировкой: между выборкой и обработкой его могли слить.
+func (u *StaleUsecase) apply(ctx context.Context, prID string, now time.Time) (domain.Action, error) {
+	var action domain.Action
+
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := u.prProvider.LockPR(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("lock pull request in provider: %w", err)
+		}
+
+		action = u.cfg.Policy.Due(pr, now)
+		switch action {
+		case domain.ActionMarkStale:
+			return u.markStale(ctx, pr, now)
+		case domain.ActionClose:
+			return u.close(ctx, pr, now)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.ActionNone, err
+	}
+
+	return action, nil
+}
+
+func (u *StaleUsecase) markStale(ctx context.Context, pr *prdomain.PullRequest, now time.Time) error {
+	updated, err := u.prProvider.MarkStale(ctx, pr.ID, now)
+	if err != nil {
+		return fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	closeAt := u.cfg.Policy.CloseAt(updated)
+	if err := u.record(ctx, updated.ID, domain.ActionMarkStale, now, domain.StaleDetails{
+		CreatedAt: updated.CreatedAt,
+		CloseAt:   closeAt,
+	}); err != nil {
+		return err
+	}
+
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRStale, updated.ID,
+		outboxdomain.PRStalePayload{PullRequest: *updated, CloseAt: closeAt})
+	if err != nil {
+		return fmt.Errorf("build pr stale event: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, event); err != nil {
+		return fmt.Errorf("append pr stale event: %w", err)
+	}
+
+	slog.Info("StaleUsecase.Sweep: pull request marked as stale",
+		slog.String("pr_id", updated.ID),
+		slog.String("author_id", updated.AuthorId),
+	)
+	return nil
+}
+
+func (u *StaleUsecase) close(ctx context.Context, pr *prdomain.PullRequest, now time.Time) error {
+	freed := pr.Close(now)
+
+	updated, err := u.prProvider.ClosePR(ctx, pr.ID, now)
+	if err != nil {
+		return fmt.Errorf("update pull request in provider: %w", err)
+	}
+
+	if err := u.record(ctx, updated.ID, domain.ActionClose, now, domain.ClosedDetails{
+		StaleAt:        updated.StaleAt,
+		FreedReviewers: freed,
+	}); err != nil {
+		return err
+	}
+
+	event, err := outboxdomain.NewEvent(outboxdomain.EventPRClosed, updated.ID,
+		outboxdomain.PRClosedPayload{PullRequest: *updated, FreedReviewers: freed})
+	if err != nil {
+		return fmt.Errorf("build pr closed event: %w", err)
+	}
+
+	if err := u.eventWriter.Append(ctx, event); err != nil {
+		return fmt.Errorf("append pr closed event: %w", err)
+	}
+
+	slog.Info("StaleUsecase.Sweep: stale pull request closed",
+		slog.String("pr_id", updated.ID),
+		slog.Any("freed_reviewers", freed),
+	)
+	return nil
+}
+
+func (u *StaleUsecase) record(ctx context.Context, prID string, action domain.Action, now time.Time, details any) error {
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("marshal %s action details: %w", action, err)
+	}
+
+	if err := u.actionRecorder.RecordAction(ctx, &domain.Record{
+		PullRequestID: prID,
+		Action:        action,
+		Details:       raw,
+		CreatedAt:     now,
+	}); err != nil {
+		return fmt.Errorf("record %s action in provider: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+)
+
+type PRProvider interface {
+	ListStaleCandidates(ctx context.Context, staleBefore, closeBefore, flaggedBefore time.Time, limit int) ([]prdomain.PullRequest, error)
+	ListOpenCreatedBefore(ctx context.Context, before time.Time) ([]prdomain.PullRequest, error)
+	// LockPR читает PR с блокировкой строки до конца транзакции.
+	LockPR(ctx context.Context, id string) (*prdomain.PullRequest, error)
+	MarkStale(ctx context.Context, id string, at time.Time) (*prdomain.PullRequest, error)
+	// ClosePR закрывает PR и снимает с него ревьюверов.
+	ClosePR(ctx context.Context, id string, at time.Time) (*prdomain.PullRequest, error)
+}
+
+type ActionRecorder interface {
+	RecordAction(ctx context.Context, record *domain.Record) error
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Locker выбирает реплику, которая выполняет проход по заброшенным PR.
+type Locker interface {
+	TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+type Config struct {
+	CheckInterval time.Duration
+	BatchSize     int
+	Policy        domain.Policy
+}
+
+type StaleUsecase struct {
+	prProvider     PRProvider
+	actionRecorder ActionRecorder
+	eventWriter    EventWriter
+	txManager      Transactor
+	locker         Locker
+	cfg            Config
+}
+
+func NewStaleUsecase(
+	prProvider PRProvider,
+	actionRecorder ActionRecorder,
+	eventWriter EventWriter,
+	txManager Transactor,
+	locker Locker,
+	cfg Config,
+) *StaleUsecase {
+	return &StaleUsecase{
+		prProvider:     prProvider,
+		actionRecorder: actionRecorder,
+		eventWriter:    eventWriter,
+		txManager:      txManager,
+		locker:         locker,
+		cfg:            cfg,
+	}
+}
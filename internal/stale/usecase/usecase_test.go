@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = domain.Policy{StaleAfter: 14 * day, CloseAfter: 30 * day}
+
+func TestStaleUsecase_Sweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+	flagged := now.Add(-20 * day)
+
+	tests := []struct {
+		name       string
+		pr         prdomain.PullRequest
+		lockErr    error
+		wantAction domain.Action
+		wantEvent  outboxdomain.EventType
+		wantResult SweepResult
+	}{
+		{
+			name: "marks_old_pr_as_stale",
+			pr: prdomain.PullRequest{
+				ID: "pr-1", AuthorId: "u1", Status: prdomain.StatusOpen,
+				AssignedReviewers: []string{"u2"}, CreatedAt: now.Add(-15 * day),
+			},
+			wantAction: domain.ActionMarkStale,
+			wantEvent:  outboxdomain.EventPRStale,
+			wantResult: SweepResult{Marked: 1},
+		},
+		{
+			name: "closes_stale_pr_and_frees_reviewers",
+			pr: prdomain.PullRequest{
+				ID: "pr-1", AuthorId: "u1", Status: prdomain.StatusOpen,
+				AssignedReviewers: []string{"u2", "u3"}, CreatedAt: now.Add(-35 * day), StaleAt: &flagged,
+			},
+			wantAction: domain.ActionClose,
+			wantEvent:  outboxdomain.EventPRClosed,
+			wantResult: SweepResult{Closed: 1},
+		},
+		{
+			name: "merged_meanwhile_is_skipped",
+			pr: prdomain.PullRequest{
+				ID: "pr-1", AuthorId: "u1", Status: prdomain.StatusMerged,
+				CreatedAt: now.Add(-35 * day), StaleAt: &flagged,
+			},
+			wantResult: SweepResult{},
+		},
+		{
+			name:       "lock_error_is_counted",
+			pr:         prdomain.PullRequest{ID: "pr-1"},
+			lockErr:    errors.New("db down"),
+			wantResult: SweepResult{Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			prProvider := mocks.NewMockStalePRProvider(ctrl)
+			recorder := mocks.NewMockActionRecorder(ctrl)
+			eventWriter := mocks.NewMockStaleEventWriter(ctrl)
+
+			prProvider.EXPECT().
+				ListStaleCandidates(gomock.Any(), now.Add(-14*day), now.Add(-30*day), now.Add(-16*day), 10).
+				Return([]prdomain.PullRequest{tt.pr}, nil)
+
+			pr := tt.pr
+			if tt.lockErr != nil {
+				prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(nil, tt.lockErr)
+			} else {
+				prProvider.EXPECT().LockPR(gomock.Any(), "pr-1").Return(&pr, nil)
+			}
+
+			if tt.wantAction != domain.ActionNone {
+				switch tt.wantAction {
+				case domain.ActionMarkStale:
+					prProvider.EXPECT().
+						MarkStale(gomock.Any(), "pr-1", now).
+						DoAndReturn(func(context.Context, string, time.Time) (*prdomain.PullRequest, error) {
+							updated := tt.pr
+							updated.StaleAt = &now
+							return &updated, nil
+						})
+				case domain.ActionClose:
+					prProvider.EXPECT().
+						ClosePR(gomock.Any(), "pr-1", now).
+						DoAndReturn(func(context.Context, string, time.Time) (*prdomain.PullRequest, error) {
+							updated := tt.pr
+							updated.Status = prdomain.StatusClosed
+							updated.ClosedAt = &now
+							updated.AssignedReviewers = []string{}
+							return &updated, nil
+						})
+				}
+
+				recorder.EXPECT().
+					RecordAction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, record *domain.Record) error {
+						assert.Equal(t, "pr-1", record.PullRequestID)
+						assert.Equal(t, tt.wantAction, record.Action)
+						if tt.wantAction == domain.ActionClose {
+							var details domain.ClosedDetails
+							require.NoError(t, json.Unmarshal(record.Details, &details))
+							assert.Equal(t, []string{"u2", "u3"}, details.FreedReviewers)
+						}
+						return nil
+					})
+
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, tt.wantEvent, events[0].Type)
+						return nil
+					})
+			}
+
+			uc := &StaleUsecase{
+				prProvider:     prProvider,
+				actionRecorder: recorder,
+				eventWriter:    eventWriter,
+				txManager:      testutils.InlineTx{},
+				cfg:            Config{BatchSize: 10, Policy: testPolicy},
+			}
+
+			result, err := uc.Sweep(context.Background(), now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, result)
+		})
+	}
+}
+
+func TestStaleUsecase_Report(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 12, 3, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-20 * day)
+
+	ctrl := gomock.NewController(t)
+	prProvider := mocks.NewMockStalePRProvider(ctrl)
+	prProvider.EXPECT().
+		ListOpenCreatedBefore(gomock.Any(), now.Add(-14*day)).
+		Return([]prdomain.PullRequest{{
+			ID: "pr-1", Name: "Old", AuthorId: "u1", Status: prdomain.StatusOpen,
+			AssignedReviewers: []string{"u2"}, CreatedAt: created,
+		}}, nil)
+
+	uc := &StaleUsecase{prProvider: prProvider, cfg: Config{Policy: testPolicy}}
+
+	resp, err := uc.Report(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 14, resp.StaleAfterDays)
+	assert.Equal(t, 30, resp.CloseAfterDays)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, 20, resp.PullRequests[0].AgeDays)
+	require.NotNil(t, resp.PullRequests[0].CloseAt)
+	assert.Equal(t, created.Add(30*day), *resp.PullRequests[0].CloseAt)
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Ключи advisory-блокировок фоновых задач. Значения должны быть уникальны в пределах БД.
+const (
+	LockReviewSLA int64 = 7_310_001
+	LockStalePRs  int64 = 7_310_002
+	LockOOO       int64 = 7_310_003
+)
+
+// AdvisoryLock - выбор лидера среди реплик через pg_try_advisory_lock:
+// задачу выполняет только та реплика, которой досталась блокировка.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+}
+
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{pool: pool, key: key}
+}
+
+// TryRun выполняет fn, удерживая блокировку на выделенном соединении. Если блокировку
+// держит другая реплика, fn не вызывается и возвращается false.
+func (l *AdvisoryLock) TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("db: failed to acquire connection for advisory lock: %w", err)
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&locked); err != nil {
+		return false, fmt.Errorf("db: failed to take advisory lock: %w", err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		// блокировка сессионная: без unlock она осталась бы на соединении, вернувшемся в пул
+		if _, err := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+			slog.Error("failed to release advisory lock",
+				slog.Int64("key", l.key),
+				slog.Any("error", err),
+			)
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	return true, fn(ctx)
+}
@@ -8,7 +8,13 @@ import (
 )
 
 func GetConnect(connStr string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(context.Background(), connStr)
+	poolConfig, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("pgxpool config: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("pgxpool conn: %w", err)
 	}
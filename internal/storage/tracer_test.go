@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+func TestQueryTracer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sql        string
+		err        error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name:     "select",
+			sql:      "\n\t\tselect id FROM users WHERE id = $1",
+			wantName: "SELECT",
+		},
+		{
+			name:     "no_rows_is_not_an_error",
+			sql:      "SELECT id FROM users WHERE id = $1",
+			err:      pgx.ErrNoRows,
+			wantName: "SELECT",
+		},
+		{
+			name:       "failed_insert",
+			sql:        "INSERT INTO users (id) VALUES ($1)",
+			err:        errors.New("duplicate key"),
+			wantName:   "INSERT",
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := testutils.StartTrace(t)
+
+			tracer := QueryTracer{}
+			queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: tt.sql, Args: []any{"u1"}})
+			tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{
+				CommandTag: pgconn.NewCommandTag("SELECT 1"),
+				Err:        tt.err,
+			})
+
+			ended := spans.Ended()
+			require.Len(t, ended, 1)
+			span := ended[0]
+			require.Equal(t, tt.wantName, span.Name)
+			require.Equal(t, tt.wantStatus, span.Status.Code)
+			require.Contains(t, span.Attributes, semconv.DBQueryText(tt.sql))
+			require.Contains(t, span.Attributes, semconv.DBSystemNamePostgreSQL)
+		})
+	}
+}
@@ -2,16 +2,34 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
 	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
+// memberColumns - колонки участника (users u) в порядке, который ожидает scanMember.
+// Отсутствие считается на момент чтения, поэтому его учитывает любой выбор ревьюверов.
+const memberColumns = `u.id, u.name, u.is_active,
+	EXISTS (
+	    SELECT 1 FROM user_ooo_periods o
+	    WHERE o.user_id = u.id AND o.starts_at <= NOW() AND o.ends_at > NOW()
+	),
+	COALESCE(u.timezone, ''),
+	COALESCE(to_char(u.work_start, 'HH24:MI'), ''),
+	COALESCE(to_char(u.work_end, 'HH24:MI'), ''),
+	u.tags,
+	COALESCE(u.level, '')`
+
 type TeamRepository struct {
 	conn *pgxpool.Pool
 }
@@ -20,6 +38,33 @@ func NewTeamRepository(conn *pgxpool.Pool) *TeamRepository {
 	return &TeamRepository{conn: conn}
 }
 
+// scanMember читает memberColumns; extra - дополнительные колонки после них.
+func scanMember(row pgx.Row, extra ...any) (*domain.TeamMember, error) {
+	var member domain.TeamMember
+	var hours userdomain.WorkingHours
+
+	dest := []any{
+		&member.ID,
+		&member.Name,
+		&member.IsActive,
+		&member.OutOfOffice,
+		&hours.Timezone,
+		&hours.Start,
+		&hours.End,
+		&member.Tags,
+		&member.Level,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if hours.Timezone != "" {
+		member.WorkingHours = &hours
+	}
+
+	return &member, nil
+}
+
 func (t *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
 	insertTeamQuery := `
 		INSERT INTO teams (name)
@@ -28,7 +73,7 @@ func (t *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (*do
 	`
 
 	var createdTeam domain.Team
-	if err := t.conn.QueryRow(ctx, insertTeamQuery, team.Name).Scan(&createdTeam.Name); err != nil {
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, insertTeamQuery, team.Name).Scan(&createdTeam.Name); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return nil, apperr.ErrTeamExists
@@ -46,7 +91,7 @@ func (t *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (*do
 	`
 
 	for _, member := range team.Members {
-		if _, err := t.conn.Exec(
+		if _, err := storage.QuerierFrom(ctx, t.conn).Exec(
 			ctx,
 			upsertUserQuery,
 			member.ID,
@@ -71,7 +116,7 @@ func (t *TeamRepository) GetTeam(ctx context.Context, teamName string) (*domain.
 	`
 
 	var name string
-	if err := t.conn.QueryRow(ctx, getTeamQuery, teamName).Scan(&name); err != nil {
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, getTeamQuery, teamName).Scan(&name); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrNotFound
 		}
@@ -79,12 +124,12 @@ func (t *TeamRepository) GetTeam(ctx context.Context, teamName string) (*domain.
 	}
 
 	getMembersQuery := `
-		SELECT id, name, is_active
-		FROM users
-		WHERE team_name = $1
+		SELECT ` + memberColumns + `
+		FROM users u
+		WHERE u.team_name = $1
 	`
 
-	rows, err := t.conn.Query(ctx, getMembersQuery, teamName)
+	rows, err := storage.QuerierFrom(ctx, t.conn).Query(ctx, getMembersQuery, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("db: failed to get team members: %w", err)
 	}
@@ -96,16 +141,377 @@ func (t *TeamRepository) GetTeam(ctx context.Context, teamName string) (*domain.
 	}
 
 	for rows.Next() {
-		var member domain.TeamMember
-		if err := rows.Scan(&member.ID, &member.Name, &member.IsActive); err != nil {
+		member, err := scanMember(rows)
+		if err != nil {
 			return nil, fmt.Errorf("db: failed to scan team member: %w", err)
 		}
-		team.Members = append(team.Members, member)
+		team.Members = append(team.Members, *member)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("db: rows error: %w", err)
 	}
 
+	team.ReviewerPolicy, err = t.getReviewerPolicy(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
 	return team, nil
 }
+
+func (t *TeamRepository) SetReviewSLA(ctx context.Context, sla *domain.ReviewSLA) (*domain.ReviewSLA, error) {
+	query := `
+		INSERT INTO team_review_sla (team_name, remind_after, escalate_after)
+		VALUES ($1, make_interval(secs => $2), make_interval(secs => $3))
+		ON CONFLICT (team_name) DO UPDATE
+		SET remind_after = EXCLUDED.remind_after,
+		    escalate_after = EXCLUDED.escalate_after,
+		    updated_at = NOW()
+		RETURNING team_name,
+		          EXTRACT(EPOCH FROM remind_after)::BIGINT,
+		          EXTRACT(EPOCH FROM escalate_after)::BIGINT
+	`
+
+	var saved domain.ReviewSLA
+	var remindSec, escalateSec int64
+
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(
+		ctx,
+		query,
+		sla.TeamName,
+		sla.RemindAfter.Seconds(),
+		sla.EscalateAfter.Seconds(),
+	).Scan(&saved.TeamName, &remindSec, &escalateSec); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to set team review sla: %w", err)
+	}
+
+	saved.RemindAfter = time.Duration(remindSec) * time.Second
+	saved.EscalateAfter = time.Duration(escalateSec) * time.Second
+
+	return &saved, nil
+}
+
+// SetCodeOwners заменяет набор правил команды целиком. Неизвестные пользователи и команды
+// в правилах отклоняются с apperr.ErrInvalidCodeOwners.
+func (t *TeamRepository) SetCodeOwners(ctx context.Context, owners *domain.CodeOwners) (*domain.CodeOwners, error) {
+	var users, teams []string
+	for _, rule := range owners.Rules {
+		users = append(users, rule.Users...)
+		teams = append(teams, rule.Teams...)
+	}
+
+	unknownQuery := `
+		SELECT
+			ARRAY(SELECT DISTINCT u FROM unnest($1::text[]) u
+			      WHERE NOT EXISTS (SELECT 1 FROM users WHERE id = u) ORDER BY u),
+			ARRAY(SELECT DISTINCT tm FROM unnest($2::text[]) tm
+			      WHERE NOT EXISTS (SELECT 1 FROM teams WHERE name = tm) ORDER BY tm)
+	`
+
+	var unknownUsers, unknownTeams []string
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, unknownQuery, users, teams).
+		Scan(&unknownUsers, &unknownTeams); err != nil {
+		return nil, fmt.Errorf("db: failed to check code owners: %w", err)
+	}
+	if len(unknownUsers) > 0 {
+		return nil, fmt.Errorf("%w: unknown users: %s", apperr.ErrInvalidCodeOwners, strings.Join(unknownUsers, ", "))
+	}
+	if len(unknownTeams) > 0 {
+		return nil, fmt.Errorf("%w: unknown teams: %s", apperr.ErrInvalidCodeOwners, strings.Join(unknownTeams, ", "))
+	}
+
+	rules, err := json.Marshal(owners.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("marshal code owner rules: %w", err)
+	}
+
+	query := `
+		INSERT INTO team_code_owners (team_name, rules)
+		VALUES ($1, $2)
+		ON CONFLICT (team_name) DO UPDATE
+		SET rules = EXCLUDED.rules,
+		    updated_at = NOW()
+		RETURNING team_name, rules
+	`
+
+	saved, err := scanCodeOwners(storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, query, owners.TeamName, rules))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to set code owners: %w", err)
+	}
+
+	return saved, nil
+}
+
+// GetCodeOwners возвращает правила команды; если правил нет, набор пустой.
+func (t *TeamRepository) GetCodeOwners(ctx context.Context, teamName string) (*domain.CodeOwners, error) {
+	query := `
+		SELECT team_name, rules
+		FROM team_code_owners
+		WHERE team_name = $1
+	`
+
+	owners, err := scanCodeOwners(storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, query, teamName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.CodeOwners{TeamName: teamName, Rules: make([]domain.CodeOwnerRule, 0)}, nil
+		}
+		return nil, fmt.Errorf("db: failed to get code owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+func scanCodeOwners(row pgx.Row) (*domain.CodeOwners, error) {
+	var owners domain.CodeOwners
+	var rules []byte
+
+	if err := row.Scan(&owners.TeamName, &rules); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rules, &owners.Rules); err != nil {
+		return nil, fmt.Errorf("unmarshal code owner rules: %w", err)
+	}
+
+	return &owners, nil
+}
+
+// SetPool создаёт пул или заменяет его состав. Неизвестные пользователи отклоняются
+// с apperr.ErrInvalidPool.
+func (t *TeamRepository) SetPool(ctx context.Context, name string, userIDs []string) (*domain.ReviewerPool, error) {
+	unknownQuery := `
+		SELECT ARRAY(SELECT DISTINCT u FROM unnest($1::text[]) u
+		             WHERE NOT EXISTS (SELECT 1 FROM users WHERE id = u) ORDER BY u)
+	`
+
+	var unknown []string
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, unknownQuery, userIDs).Scan(&unknown); err != nil {
+		return nil, fmt.Errorf("db: failed to check pool members: %w", err)
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown users: %s", apperr.ErrInvalidPool, strings.Join(unknown, ", "))
+	}
+
+	query := `
+		WITH pool AS (
+			INSERT INTO reviewer_pools (name)
+			VALUES ($1)
+			ON CONFLICT (name) DO UPDATE SET updated_at = NOW()
+			RETURNING name
+		), removed AS (
+			DELETE FROM reviewer_pool_members m
+			USING pool
+			WHERE m.pool_name = pool.name
+			  AND NOT (m.user_id = ANY($2::text[]))
+		), added AS (
+			INSERT INTO reviewer_pool_members (pool_name, user_id)
+			SELECT pool.name, u
+			FROM pool, unnest($2::text[]) u
+			ON CONFLICT DO NOTHING
+		)
+		SELECT name FROM pool
+	`
+
+	var saved string
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, query, name, userIDs).Scan(&saved); err != nil {
+		return nil, fmt.Errorf("db: failed to set reviewer pool: %w", err)
+	}
+
+	return t.GetPool(ctx, saved)
+}
+
+// GetPool возвращает пул с участниками; у каждого участника заполнена его команда.
+func (t *TeamRepository) GetPool(ctx context.Context, name string) (*domain.ReviewerPool, error) {
+	var exists bool
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM reviewer_pools WHERE name = $1)`, name,
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("db: failed to get reviewer pool: %w", err)
+	}
+	if !exists {
+		return nil, apperr.ErrNotFound
+	}
+
+	query := `
+		SELECT ` + memberColumns + `, u.team_name
+		FROM reviewer_pool_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.pool_name = $1
+		ORDER BY u.id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, t.conn).Query(ctx, query, name)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to get reviewer pool members: %w", err)
+	}
+	defer rows.Close()
+
+	pool := &domain.ReviewerPool{
+		Name:    name,
+		Members: make([]domain.TeamMember, 0),
+	}
+
+	for rows.Next() {
+		var teamName string
+		member, err := scanMember(rows, &teamName)
+		if err != nil {
+			return nil, fmt.Errorf("db: failed to scan pool member: %w", err)
+		}
+		member.TeamName = teamName
+		pool.Members = append(pool.Members, *member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return pool, nil
+}
+
+// ListPoolTeams возвращает команды, политика которых берёт ревьюверов из пула poolName.
+func (t *TeamRepository) ListPoolTeams(ctx context.Context, poolName string) ([]string, error) {
+	query := `
+		SELECT team_name
+		FROM team_reviewer_pools
+		WHERE pool_name = $1
+		ORDER BY team_name
+	`
+
+	rows, err := storage.QuerierFrom(ctx, t.conn).Query(ctx, query, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list pool teams: %w", err)
+	}
+
+	defer rows.Close()
+
+	teams := make([]string, 0)
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, fmt.Errorf("db: failed to scan pool team: %w", err)
+		}
+		teams = append(teams, teamName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return teams, nil
+}
+
+// SetReviewerPolicy сохраняет политику команды. Неизвестные пулы отклоняются
+// с apperr.ErrInvalidReviewerPolicy.
+func (t *TeamRepository) SetReviewerPolicy(ctx context.Context, teamName string,
+	policy *domain.ReviewerPolicy) (*domain.ReviewerPolicy, error) {
+
+	pools := make([]string, 0, len(policy.Pools))
+	reviewers := make([]int32, 0, len(policy.Pools))
+	for _, pool := range policy.Pools {
+		pools = append(pools, pool.PoolName)
+		reviewers = append(reviewers, int32(pool.Reviewers))
+	}
+
+	unknownQuery := `
+		SELECT ARRAY(SELECT p FROM unnest($1::text[]) p
+		             WHERE NOT EXISTS (SELECT 1 FROM reviewer_pools WHERE name = p) ORDER BY p)
+	`
+
+	var unknown []string
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, unknownQuery, pools).Scan(&unknown); err != nil {
+		return nil, fmt.Errorf("db: failed to check reviewer pools: %w", err)
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown pools: %s", apperr.ErrInvalidReviewerPolicy, strings.Join(unknown, ", "))
+	}
+
+	query := `
+		WITH policy AS (
+			INSERT INTO team_reviewer_policies (team_name, team_reviewers, min_level, min_level_reviewers,
+			                                    rotation_window, rotation_decay)
+			VALUES ($1, $2, NULLIF($5, ''), $6, $7, $8)
+			ON CONFLICT (team_name) DO UPDATE
+			SET team_reviewers = EXCLUDED.team_reviewers,
+			    min_level = EXCLUDED.min_level,
+			    min_level_reviewers = EXCLUDED.min_level_reviewers,
+			    rotation_window = EXCLUDED.rotation_window,
+			    rotation_decay = EXCLUDED.rotation_decay,
+			    updated_at = NOW()
+			RETURNING team_name
+		), removed AS (
+			DELETE FROM team_reviewer_pools p
+			USING policy
+			WHERE p.team_name = policy.team_name
+			  AND NOT (p.pool_name = ANY($3::text[]))
+		), upserted AS (
+			INSERT INTO team_reviewer_pools (team_name, pool_name, reviewers, position)
+			SELECT policy.team_name, r.pool_name, r.reviewers, r.ord - 1
+			FROM policy, unnest($3::text[], $4::int[]) WITH ORDINALITY AS r(pool_name, reviewers, ord)
+			ON CONFLICT (team_name, pool_name) DO UPDATE
+			SET reviewers = EXCLUDED.reviewers,
+			    position = EXCLUDED.position
+		)
+		SELECT team_name FROM policy
+	`
+
+	var saved string
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, query,
+		teamName, policy.TeamReviewers, pools, reviewers, string(policy.MinLevel), policy.MinLevelReviewers,
+		policy.RotationWindow, policy.RotationDecay,
+	).Scan(&saved); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to set reviewer policy: %w", err)
+	}
+
+	return t.getReviewerPolicy(ctx, saved)
+}
+
+// getReviewerPolicy возвращает политику команды или nil, если она не задана.
+func (t *TeamRepository) getReviewerPolicy(ctx context.Context, teamName string) (*domain.ReviewerPolicy, error) {
+	query := `
+		SELECT p.team_reviewers, COALESCE(p.min_level, ''), p.min_level_reviewers,
+		       p.rotation_window, p.rotation_decay,
+		       ARRAY(SELECT pool_name FROM team_reviewer_pools
+		             WHERE team_name = p.team_name ORDER BY position),
+		       ARRAY(SELECT reviewers FROM team_reviewer_pools
+		             WHERE team_name = p.team_name ORDER BY position)
+		FROM team_reviewer_policies p
+		WHERE p.team_name = $1
+	`
+
+	policy := &domain.ReviewerPolicy{}
+	var pools []string
+	var reviewers []int
+	if err := storage.QuerierFrom(ctx, t.conn).QueryRow(ctx, query, teamName).Scan(
+		&policy.TeamReviewers,
+		&policy.MinLevel,
+		&policy.MinLevelReviewers,
+		&policy.RotationWindow,
+		&policy.RotationDecay,
+		&pools,
+		&reviewers,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("db: failed to get reviewer policy: %w", err)
+	}
+
+	policy.Pools = make([]domain.PoolRequirement, 0, len(pools))
+	for i, pool := range pools {
+		policy.Pools = append(policy.Pools, domain.PoolRequirement{PoolName: pool, Reviewers: reviewers[i]})
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+// maxCodeOwnerRules - ограничение размера набора правил одной команды.
+const maxCodeOwnerRules = 500
+
+// CodeOwnerRule - правило владения в стиле CODEOWNERS: файлы, подходящие под Pattern,
+// должен посмотреть хотя бы один из Users или участник одной из Teams.
+type CodeOwnerRule struct {
+	Pattern string   `json:"pattern"`
+	Users   []string `json:"users,omitempty"`
+	Teams   []string `json:"teams,omitempty"`
+
+	re       *regexp.Regexp
+	dirMatch bool
+}
+
+// CodeOwners - набор правил команды. Как и в CODEOWNERS, для файла действует последнее
+// подходящее правило.
+type CodeOwners struct {
+	TeamName string          `json:"team_name" validate:"required"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+// Validate проверяет шаблоны и владельцев и компилирует шаблоны для Match.
+func (c *CodeOwners) Validate() error {
+	if len(c.Rules) > maxCodeOwnerRules {
+		return fmt.Errorf("%w: too many rules, max %d", apperr.ErrInvalidCodeOwners, maxCodeOwnerRules)
+	}
+
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if len(rule.Users) == 0 && len(rule.Teams) == 0 {
+			return fmt.Errorf("%w: rule %d (%q) has no owners", apperr.ErrInvalidCodeOwners, i+1, rule.Pattern)
+		}
+		if err := rule.compile(); err != nil {
+			return fmt.Errorf("%w: rule %d: %v", apperr.ErrInvalidCodeOwners, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// Match возвращает правила, которые определяют владельцев для переданных путей,
+// в порядке их следования в наборе. Правило попадает в результат один раз.
+func (c *CodeOwners) Match(paths []string) []CodeOwnerRule {
+	if c == nil || len(c.Rules) == 0 {
+		return nil
+	}
+
+	matched := make([]bool, len(c.Rules))
+	for _, p := range paths {
+		p = normalizePath(p)
+		if p == "" {
+			continue
+		}
+		for i := len(c.Rules) - 1; i >= 0; i-- {
+			if c.Rules[i].matches(p) {
+				matched[i] = true
+				break
+			}
+		}
+	}
+
+	rules := make([]CodeOwnerRule, 0)
+	for i, ok := range matched {
+		if ok {
+			rules = append(rules, c.Rules[i])
+		}
+	}
+	return rules
+}
+
+func (r *CodeOwnerRule) matches(p string) bool {
+	if r.re == nil && r.compile() != nil {
+		return false
+	}
+
+	if r.re.MatchString(p) {
+		return true
+	}
+
+	// шаблон без подстановок в последнем сегменте ("/db", "docs/api") владеет и всем содержимым каталога
+	if r.dirMatch {
+		for i := len(p) - 1; i > 0; i-- {
+			if p[i] == '/' && r.re.MatchString(p[:i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// compile переводит шаблон в регулярное выражение по правилам CODEOWNERS:
+// "*" - любые символы внутри сегмента, "**" - любое число сегментов, "?" - один символ;
+// "/" в начале привязывает шаблон к корню, "/" в конце означает каталог целиком;
+// шаблон без "/" совпадает на любой глубине.
+func (r *CodeOwnerRule) compile() error {
+	p := strings.TrimSpace(r.Pattern)
+	if p == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	if strings.ContainsAny(p, "[]!\\ ") {
+		return fmt.Errorf("pattern %q: character classes, negation, escapes and spaces are not supported", r.Pattern)
+	}
+
+	anchored := strings.HasPrefix(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return fmt.Errorf("pattern %q matches nothing", r.Pattern)
+	}
+
+	if strings.HasSuffix(p, "/") {
+		p += "**"
+	}
+	if !anchored && !strings.Contains(strings.TrimSuffix(p, "/**"), "/") {
+		p = "**/" + p
+	}
+
+	segments := strings.Split(p, "/")
+	last := segments[len(segments)-1]
+	r.dirMatch = !strings.ContainsAny(last, "*?")
+
+	var b strings.Builder
+	b.WriteString("^")
+	runes := []rune(p)
+	for i := 0; i < len(runes); {
+		switch {
+		case strings.HasPrefix(string(runes[i:]), "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(string(runes[i:]), "**"):
+			b.WriteString(".*")
+			i += 2
+		case runes[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case runes[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(string(runes[i])))
+			i++
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return fmt.Errorf("pattern %q: %v", r.Pattern, err)
+	}
+	r.re = re
+
+	return nil
+}
+
+func normalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	p = strings.TrimPrefix(p, "./")
+	return strings.TrimPrefix(p, "/")
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeOwnerRule_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.go", path: "main.go", want: true},
+		{pattern: "*.go", path: "internal/pr/domain/reviewer.go", want: true},
+		{pattern: "*.go", path: "README.md", want: false},
+		{pattern: "/migrator/", path: "migrator/migrations/001.sql", want: true},
+		{pattern: "/migrator/", path: "tools/migrator/main.go", want: false},
+		{pattern: "apps/", path: "web/apps/index.ts", want: true},
+		{pattern: "docs/*", path: "docs/intro.md", want: true},
+		{pattern: "docs/*", path: "docs/api/intro.md", want: false},
+		{pattern: "/internal/pr", path: "internal/pr/usecase/create_pr.go", want: true},
+		{pattern: "/internal/pr", path: "internal/prx/main.go", want: false},
+		{pattern: "internal/**/postgres/*.go", path: "internal/team/adapter/postgres/postgres.go", want: true},
+		{pattern: "config/config.y?ml", path: "config/config.yaml", want: true},
+		{pattern: "/README.md", path: "./README.md", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.pattern+"~"+tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			rule := CodeOwnerRule{Pattern: tt.pattern, Users: []string{"u1"}}
+			require.NoError(t, rule.compile())
+			assert.Equal(t, tt.want, rule.matches(normalizePath(tt.path)))
+		})
+	}
+}
+
+func TestCodeOwners_Match(t *testing.T) {
+	t.Parallel()
+
+	owners := &CodeOwners{
+		Rules: []CodeOwnerRule{
+			{Pattern: "*", Teams: []string{"backend"}},
+			{Pattern: "/migrator/", Users: []string{"dba"}},
+			{Pattern: "*.md", Users: []string{"writer"}},
+		},
+	}
+	require.NoError(t, owners.Validate())
+
+	// для файла действует последнее подходящее правило
+	got := owners.Match([]string{"migrator/migrations/001.sql", "migrator/README.md"})
+	require.Len(t, got, 2)
+	assert.Equal(t, "/migrator/", got[0].Pattern)
+	assert.Equal(t, "*.md", got[1].Pattern)
+
+	got = owners.Match([]string{"main.go", "internal/app/app.go"})
+	require.Len(t, got, 1)
+	assert.Equal(t, []string{"backend"}, got[0].Teams)
+
+	assert.Empty(t, owners.Match(nil))
+}
+
+func TestCodeOwners_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   []CodeOwnerRule
+		wantErr bool
+	}{
+		{name: "valid", rules: []CodeOwnerRule{{Pattern: "/db/", Users: []string{"u1"}}}},
+		{name: "empty_set", rules: nil},
+		{name: "no_owners", rules: []CodeOwnerRule{{Pattern: "*.go"}}, wantErr: true},
+		{name: "empty_pattern", rules: []CodeOwnerRule{{Pattern: " ", Users: []string{"u1"}}}, wantErr: true},
+		{name: "root_only", rules: []CodeOwnerRule{{Pattern: "/", Users: []string{"u1"}}}, wantErr: true},
+		{name: "negation", rules: []CodeOwnerRule{{Pattern: "!*.go", Users: []string{"u1"}}}, wantErr: true},
+		{name: "character_class", rules: []CodeOwnerRule{{Pattern: "*.[ch]", Users: []string{"u1"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			owners := &CodeOwners{TeamName: "backend", Rules: tt.rules}
+			err := owners.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, apperr.ErrInvalidCodeOwners))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
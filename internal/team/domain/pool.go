@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+const (
+	// DefaultTeamReviewers - сколько ревьюверов из своей команды получает PR без политики.
+	DefaultTeamReviewers = 2
+	// maxPolicyReviewers - ограничение на общее число ревьюверов по политике.
+	maxPolicyReviewers = 10
+)
+
+// ReviewerPool - именованный набор ревьюверов из разных команд (платформа, безопасность).
+// TeamName участников заполняется, чтобы было видно, откуда они.
+type ReviewerPool struct {
+	Name    string       `json:"pool_name"`
+	Members []TeamMember `json:"members"`
+}
+
+// AsTeam представляет пул как команду, чтобы выбирать из него теми же правилами.
+func (p *ReviewerPool) AsTeam() *Team {
+	return &Team{Name: p.Name, Members: p.Members}
+}
+
+// PoolRequirement - сколько ревьюверов брать из пула.
+type PoolRequirement struct {
+	PoolName  string `json:"pool_name"`
+	Reviewers int    `json:"reviewers"`
+}
+
+// ReviewerPolicy - откуда набираются ревьюверы PR авторов команды: TeamReviewers из своей
+// команды и Reviewers из каждого пула. Без политики действует DefaultTeamReviewers.
+// MinLevelReviewers > 0 требует, чтобы столько ревьюверов PR были уровня не ниже MinLevel.
+// RotationWindow и RotationDecay настраивают режим rotation (0 - значение по умолчанию).
+type ReviewerPolicy struct {
+	TeamReviewers     int               `json:"team_reviewers"`
+	Pools             []PoolRequirement `json:"pools"`
+	MinLevel          userdomain.Level  `json:"min_level,omitempty"`
+	MinLevelReviewers int               `json:"min_level_reviewers,omitempty"`
+	RotationWindow    int               `json:"rotation_window,omitempty"`
+	RotationDecay     float64           `json:"rotation_decay,omitempty"`
+}
+
+func (p *ReviewerPolicy) Validate() error {
+	if p.TeamReviewers < 0 {
+		return fmt.Errorf("%w: team_reviewers must not be negative", apperr.ErrInvalidReviewerPolicy)
+	}
+
+	total := p.TeamReviewers
+	seen := make(map[string]struct{}, len(p.Pools))
+	for _, pool := range p.Pools {
+		if pool.PoolName == "" {
+			return fmt.Errorf("%w: pool_name is required", apperr.ErrInvalidReviewerPolicy)
+		}
+		if _, dup := seen[pool.PoolName]; dup {
+			return fmt.Errorf("%w: pool %q listed twice", apperr.ErrInvalidReviewerPolicy, pool.PoolName)
+		}
+		seen[pool.PoolName] = struct{}{}
+
+		if pool.Reviewers < 1 {
+			return fmt.Errorf("%w: pool %q needs at least one reviewer", apperr.ErrInvalidReviewerPolicy, pool.PoolName)
+		}
+		total += pool.Reviewers
+	}
+
+	if total == 0 {
+		return fmt.Errorf("%w: policy assigns no reviewers", apperr.ErrInvalidReviewerPolicy)
+	}
+	if total > maxPolicyReviewers {
+		return fmt.Errorf("%w: at most %d reviewers per PR", apperr.ErrInvalidReviewerPolicy, maxPolicyReviewers)
+	}
+
+	if p.MinLevel != "" && !p.MinLevel.Valid() {
+		return fmt.Errorf("%w: unknown level %q", apperr.ErrInvalidReviewerPolicy, p.MinLevel)
+	}
+	if p.MinLevelReviewers < 0 || (p.MinLevelReviewers > 0) != (p.MinLevel != "") {
+		return fmt.Errorf("%w: min_level and min_level_reviewers must be set together", apperr.ErrInvalidReviewerPolicy)
+	}
+	if p.MinLevelReviewers > total {
+		return fmt.Errorf("%w: min_level_reviewers exceeds reviewers per PR", apperr.ErrInvalidReviewerPolicy)
+	}
+
+	if p.RotationWindow < 0 || p.RotationWindow > maxRotationWindow {
+		return fmt.Errorf("%w: rotation_window must be between 0 and %d", apperr.ErrInvalidReviewerPolicy, maxRotationWindow)
+	}
+	if p.RotationDecay < 0 || p.RotationDecay > 1 {
+		return fmt.Errorf("%w: rotation_decay must be between 0 and 1", apperr.ErrInvalidReviewerPolicy)
+	}
+
+	return nil
+}
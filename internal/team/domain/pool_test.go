@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  ReviewerPolicy
+		wantErr error
+	}{
+		{
+			name:   "team_plus_pool",
+			policy: ReviewerPolicy{TeamReviewers: 1, Pools: []PoolRequirement{{PoolName: "security", Reviewers: 1}}},
+		},
+		{
+			name:   "only_pools",
+			policy: ReviewerPolicy{Pools: []PoolRequirement{{PoolName: "platform", Reviewers: 2}}},
+		},
+		{
+			name:    "no_reviewers",
+			policy:  ReviewerPolicy{},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "negative_team_reviewers",
+			policy:  ReviewerPolicy{TeamReviewers: -1, Pools: []PoolRequirement{{PoolName: "security", Reviewers: 1}}},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name: "duplicate_pool",
+			policy: ReviewerPolicy{TeamReviewers: 1, Pools: []PoolRequirement{
+				{PoolName: "security", Reviewers: 1},
+				{PoolName: "security", Reviewers: 1},
+			}},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "empty_pool_slot",
+			policy:  ReviewerPolicy{TeamReviewers: 1, Pools: []PoolRequirement{{PoolName: "security"}}},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:   "at_least_one_senior",
+			policy: ReviewerPolicy{TeamReviewers: 2, MinLevel: userdomain.LevelSenior, MinLevelReviewers: 1},
+		},
+		{
+			name:    "unknown_level",
+			policy:  ReviewerPolicy{TeamReviewers: 2, MinLevel: "principal", MinLevelReviewers: 1},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "level_count_without_level",
+			policy:  ReviewerPolicy{TeamReviewers: 2, MinLevelReviewers: 1},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "more_seniors_than_reviewers",
+			policy:  ReviewerPolicy{TeamReviewers: 2, MinLevel: userdomain.LevelSenior, MinLevelReviewers: 3},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "too_many_reviewers",
+			policy:  ReviewerPolicy{TeamReviewers: 6, Pools: []PoolRequirement{{PoolName: "security", Reviewers: 5}}},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:   "rotation",
+			policy: ReviewerPolicy{TeamReviewers: 2, RotationWindow: 20, RotationDecay: 0.8},
+		},
+		{
+			name:    "rotation_window_too_large",
+			policy:  ReviewerPolicy{TeamReviewers: 2, RotationWindow: 101},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:    "rotation_decay_above_one",
+			policy:  ReviewerPolicy{TeamReviewers: 2, RotationDecay: 1.5},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.policy.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReviewerPolicy_Rotation(t *testing.T) {
+	t.Parallel()
+
+	var none *ReviewerPolicy
+	window, decay := none.Rotation()
+	assert.Equal(t, DefaultRotationWindow, window)
+	assert.Equal(t, DefaultRotationDecay, decay)
+
+	window, decay = (&ReviewerPolicy{RotationWindow: 5}).Rotation()
+	assert.Equal(t, 5, window)
+	assert.Equal(t, DefaultRotationDecay, decay)
+
+	window, decay = (&ReviewerPolicy{RotationDecay: 0.9}).Rotation()
+	assert.Equal(t, DefaultRotationWindow, window)
+	assert.Equal(t, 0.9, decay)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+// ReviewSLA - сроки реакции ревьювера для команды. Отсчёт идёт от назначения ревьювера:
+// после RemindAfter ему отправляется напоминание, после EscalateAfter ревью передаётся
+// другому участнику. Нулевое значение отключает соответствующий шаг.
+type ReviewSLA struct {
+	TeamName      string
+	RemindAfter   time.Duration
+	EscalateAfter time.Duration
+}
+
+func (s *ReviewSLA) Validate() error {
+	if s.RemindAfter < 0 || s.EscalateAfter < 0 {
+		return fmt.Errorf("%w: durations must not be negative", apperr.ErrInvalidReviewSLA)
+	}
+
+	if s.RemindAfter > 0 && s.EscalateAfter > 0 && s.EscalateAfter <= s.RemindAfter {
+		return fmt.Errorf("%w: escalate_after must be greater than remind_after", apperr.ErrInvalidReviewSLA)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewSLA_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sla     ReviewSLA
+		wantErr error
+	}{
+		{
+			name: "remind_and_escalate",
+			sla:  ReviewSLA{RemindAfter: 24 * time.Hour, EscalateAfter: 72 * time.Hour},
+		},
+		{
+			name: "both_disabled",
+			sla:  ReviewSLA{},
+		},
+		{
+			name: "only_escalate",
+			sla:  ReviewSLA{EscalateAfter: time.Hour},
+		},
+		{
+			name:    "negative_duration",
+			sla:     ReviewSLA{RemindAfter: -time.Hour},
+			wantErr: apperr.ErrInvalidReviewSLA,
+		},
+		{
+			name:    "escalate_before_remind",
+			sla:     ReviewSLA{RemindAfter: 48 * time.Hour, EscalateAfter: 24 * time.Hour},
+			wantErr: apperr.ErrInvalidReviewSLA,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.sla.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+const (
+	// DefaultRotationWindow - сколько последних PR автора учитывает режим rotation.
+	DefaultRotationWindow = 10
+	// DefaultRotationDecay - во сколько раз слабеет штраф за пару с каждым более старым PR.
+	DefaultRotationDecay = 0.5
+	// maxRotationWindow ограничивает историю, чтобы выбор укладывался в SLI.
+	maxRotationWindow = 100
+)
+
+// Rotation возвращает окно истории и затухание для режима rotation: значения политики
+// команды или значения по умолчанию, если политики нет или поле не задано.
+func (p *ReviewerPolicy) Rotation() (window int, decay float64) {
+	window, decay = DefaultRotationWindow, DefaultRotationDecay
+	if p == nil {
+		return window, decay
+	}
+
+	if p.RotationWindow > 0 {
+		window = p.RotationWindow
+	}
+	if p.RotationDecay > 0 {
+		decay = p.RotationDecay
+	}
+	return window, decay
+}
@@ -1,17 +1,39 @@
 package domain
 
+import (
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
 type Team struct {
 	Name    string       `json:"team_name" validate:"required"`
 	Members []TeamMember `json:"members" validate:"required"`
+	// ReviewerPolicy - состав ревьюверов для PR команды, nil - по умолчанию (задаётся через
+	// /team/setReviewerPolicy).
+	ReviewerPolicy *ReviewerPolicy `json:"reviewer_policy,omitempty"`
 }
 
 type TeamMember struct {
 	ID       string `json:"user_id" validate:"required"`
 	Name     string `json:"username" validate:"required"`
 	IsActive bool   `json:"is_active"`
+	// OutOfOffice - у участника сейчас идёт период отсутствия (вычисляется при чтении команды).
+	OutOfOffice bool `json:"out_of_office,omitempty"`
+	// WorkingHours - рабочее окно участника, nil - не задано.
+	WorkingHours *userdomain.WorkingHours `json:"working_hours,omitempty"`
+	// Tags - экспертиза участника, задаётся через /users/setTags.
+	Tags []string `json:"tags,omitempty"`
+	// Level - грейд участника, задаётся через /users/setLevel.
+	Level userdomain.Level `json:"level,omitempty"`
+	// TeamName - команда участника; заполняется только для участников пулов ревьюверов.
+	TeamName string `json:"team_name,omitempty"`
+}
+
+// Available - участник может получать ревью: активен и не в отпуске.
+func (m *TeamMember) Available() bool {
+	return m.IsActive && !m.OutOfOffice
 }
 
-//  возвращает активных участников, исключая переданные ID.
+//  возвращает активных участников, исключая переданные ID и тех, кто сейчас отсутствует.
 func (t *Team) ActiveMembersExcept(excludedIDs ...string) []TeamMember {
 	if t == nil {
 		return nil
@@ -24,7 +46,7 @@ func (t *Team) ActiveMembersExcept(excludedIDs ...string) []TeamMember {
 
 	members := make([]TeamMember, 0, len(t.Members))
 	for _, m := range t.Members {
-		if !m.IsActive {
+		if !m.Available() {
 			continue
 		}
 		if _, blocked := exclude[m.ID]; blocked {
@@ -55,6 +55,18 @@ func TestTeam_ActiveMembersExcept(t *testing.T) {
 				{ID: "u1", Name: "Alice", IsActive: true},
 			},
 		},
+		{
+			name: "filters_out_of_office",
+			fields: fields{
+				members: []TeamMember{
+					{ID: "u1", Name: "Alice", IsActive: true, OutOfOffice: true},
+					{ID: "u2", Name: "Bob", IsActive: true},
+				},
+			},
+			want: []TeamMember{
+				{ID: "u2", Name: "Bob", IsActive: true},
+			},
+		},
 	}
 
 	for _, tt := range tests {
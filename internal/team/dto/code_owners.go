@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+
+// SetCodeOwnersRequest - набор правил заменяется целиком, пустой список удаляет все правила.
+type SetCodeOwnersRequest struct {
+	domain.CodeOwners
+}
+
+type SetCodeOwnersResponse struct {
+	CodeOwners domain.CodeOwners `json:"code_owners"`
+}
+
+type GetCodeOwnersRequest struct {
+	TeamName string `query:"team_name" validate:"required"`
+}
+
+type GetCodeOwnersResponse struct {
+	CodeOwners domain.CodeOwners `json:"code_owners"`
+}
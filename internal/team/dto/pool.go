@@ -0,0 +1,32 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+
+// SetPoolRequest - создаёт пул или заменяет его состав целиком.
+type SetPoolRequest struct {
+	PoolName string   `json:"pool_name" validate:"required,max=64"`
+	Members  []string `json:"members" validate:"max=1000,dive,required"`
+}
+
+type SetPoolResponse struct {
+	Pool domain.ReviewerPool `json:"pool"`
+}
+
+type GetPoolRequest struct {
+	PoolName string `query:"pool_name" validate:"required"`
+}
+
+type GetPoolResponse struct {
+	Pool domain.ReviewerPool `json:"pool"`
+}
+
+// SetReviewerPolicyRequest - политика заменяется целиком.
+type SetReviewerPolicyRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+	domain.ReviewerPolicy
+}
+
+type SetReviewerPolicyResponse struct {
+	TeamName       string                `json:"team_name"`
+	ReviewerPolicy domain.ReviewerPolicy `json:"reviewer_policy"`
+}
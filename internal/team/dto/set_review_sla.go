@@ -0,0 +1,14 @@
+package dto
+
+// SetReviewSLARequest - сроки задаются строками time.ParseDuration ("24h", "90m"), "0s" отключает шаг.
+type SetReviewSLARequest struct {
+	TeamName      string `json:"team_name" validate:"required"`
+	RemindAfter   string `json:"remind_after" validate:"required"`
+	EscalateAfter string `json:"escalate_after" validate:"required"`
+}
+
+type SetReviewSLAResponse struct {
+	TeamName      string `json:"team_name"`
+	RemindAfter   string `json:"remind_after"`
+	EscalateAfter string `json:"escalate_after"`
+}
@@ -5,18 +5,41 @@ import (
 	"fmt"
 	"log/slog"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 )
 
 func (t *TeamUsecase) CreateTeam(ctx context.Context, addTeamRequest *dto.AddTeamRequest) (*dto.AddTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.CreateTeam")
+	defer span.End()
+
 	team := &domain.Team{
 		Name:    addTeamRequest.Name,
 		Members: addTeamRequest.Members,
 	}
 
-	createdTeam, err := t.teamProvider.CreateTeam(ctx, team)
+	// команда и событие об её составе пишутся атомарно: по событию доназначаются ревьюверы
+	var createdTeam *domain.Team
+	err := t.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		createdTeam, err = t.teamProvider.CreateTeam(ctx, team)
+		if err != nil {
+			return err
+		}
+
+		event, err := outboxdomain.NewEvent(outboxdomain.EventTeamMembersChanged, createdTeam.Name,
+			outboxdomain.TeamMembersPayload{Team: *createdTeam})
+		if err != nil {
+			return fmt.Errorf("build team members event: %w", err)
+		}
 
+		if err := t.eventWriter.Append(ctx, event); err != nil {
+			return fmt.Errorf("append team members event: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		slog.Error("TeamUsecase.CreateTeam: provider error",
 			slog.String("team_name", team.Name),
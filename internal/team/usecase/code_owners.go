@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+func (t *TeamUsecase) SetCodeOwners(ctx context.Context, request *dto.SetCodeOwnersRequest) (*dto.SetCodeOwnersResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.SetCodeOwners")
+	defer span.End()
+
+	owners := &domain.CodeOwners{
+		TeamName: request.TeamName,
+		Rules:    request.Rules,
+	}
+	if owners.Rules == nil {
+		owners.Rules = make([]domain.CodeOwnerRule, 0)
+	}
+
+	if err := owners.Validate(); err != nil {
+		slog.Info("TeamUsecase.SetCodeOwners: invalid rules",
+			slog.String("team_name", request.TeamName),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	saved, err := t.teamProvider.SetCodeOwners(ctx, owners)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidCodeOwners) {
+			slog.Info("TeamUsecase.SetCodeOwners: invalid owners",
+				slog.String("team_name", request.TeamName),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Info("TeamUsecase.SetCodeOwners: team not found",
+				slog.String("team_name", request.TeamName),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		slog.Error("TeamUsecase.SetCodeOwners: provider error",
+			slog.String("team_name", request.TeamName),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("set code owners in provider: %w", err)
+	}
+
+	slog.Info("TeamUsecase.SetCodeOwners: code owners updated",
+		slog.String("team_name", saved.TeamName),
+		slog.Int("rules", len(saved.Rules)),
+	)
+
+	return &dto.SetCodeOwnersResponse{
+		CodeOwners: *saved,
+	}, nil
+}
+
+func (t *TeamUsecase) GetCodeOwners(ctx context.Context, request *dto.GetCodeOwnersRequest) (*dto.GetCodeOwnersResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.GetCodeOwners")
+	defer span.End()
+
+	// пустой набор правил и несуществующая команда должны различаться
+	if _, err := t.teamProvider.GetTeam(ctx, request.TeamName); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get team from provider: %w", err)
+	}
+
+	owners, err := t.teamProvider.GetCodeOwners(ctx, request.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("get code owners from provider: %w", err)
+	}
+
+	return &dto.GetCodeOwnersResponse{
+		CodeOwners: *owners,
+	}, nil
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 
 	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 )
 
 func (t *TeamUsecase) GetTeam(ctx context.Context, getTeamRequest *dto.GetTeamRequest) (*dto.GetTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.GetTeam")
+	defer span.End()
+
 	teamName := getTeamRequest.TeamName
 
 	team, err := t.teamProvider.GetTeam(ctx, teamName)
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+func (t *TeamUsecase) SetPool(ctx context.Context, request *dto.SetPoolRequest) (*dto.SetPoolResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.SetPool")
+	defer span.End()
+
+	members := slices.Clone(request.Members)
+	slices.Sort(members)
+	members = slices.Compact(members)
+	if members == nil {
+		members = make([]string, 0)
+	}
+
+	// пул и событие о его составе пишутся атомарно: по событию доназначаются ревьюверы
+	// открытым PR команд, политика которых использует пул
+	var pool *domain.ReviewerPool
+	err := t.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		pool, err = t.teamProvider.SetPool(ctx, request.PoolName, members)
+		if err != nil {
+			return err
+		}
+
+		event, err := outboxdomain.NewEvent(outboxdomain.EventPoolMembersChanged, pool.Name,
+			outboxdomain.PoolMembersPayload{Pool: *pool})
+		if err != nil {
+			return fmt.Errorf("build pool members event: %w", err)
+		}
+
+		if err := t.eventWriter.Append(ctx, event); err != nil {
+			return fmt.Errorf("append pool members event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidPool) {
+			slog.Info("TeamUsecase.SetPool: invalid members",
+				slog.String("pool_name", request.PoolName),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+		slog.Error("TeamUsecase.SetPool: provider error",
+			slog.String("pool_name", request.PoolName),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("set reviewer pool in provider: %w", err)
+	}
+
+	slog.Info("TeamUsecase.SetPool: reviewer pool updated",
+		slog.String("pool_name", pool.Name),
+		slog.Int("members_count", len(pool.Members)),
+	)
+
+	return &dto.SetPoolResponse{
+		Pool: *pool,
+	}, nil
+}
+
+func (t *TeamUsecase) GetPool(ctx context.Context, request *dto.GetPoolRequest) (*dto.GetPoolResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.GetPool")
+	defer span.End()
+
+	pool, err := t.teamProvider.GetPool(ctx, request.PoolName)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("get reviewer pool from provider: %w", err)
+	}
+
+	return &dto.GetPoolResponse{
+		Pool: *pool,
+	}, nil
+}
+
+func (t *TeamUsecase) SetReviewerPolicy(ctx context.Context,
+	request *dto.SetReviewerPolicyRequest) (*dto.SetReviewerPolicyResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.SetReviewerPolicy")
+	defer span.End()
+
+	policy := &domain.ReviewerPolicy{
+		TeamReviewers:     request.TeamReviewers,
+		Pools:             request.Pools,
+		MinLevel:          request.MinLevel,
+		MinLevelReviewers: request.MinLevelReviewers,
+	}
+	if policy.Pools == nil {
+		policy.Pools = make([]domain.PoolRequirement, 0)
+	}
+	// "min_level": "senior" без числа означает "хотя бы один"
+	if policy.MinLevel != "" && policy.MinLevelReviewers == 0 {
+		policy.MinLevelReviewers = 1
+	}
+
+	if err := policy.Validate(); err != nil {
+		slog.Info("TeamUsecase.SetReviewerPolicy: invalid policy",
+			slog.String("team_name", request.TeamName),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	saved, err := t.teamProvider.SetReviewerPolicy(ctx, request.TeamName, policy)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidReviewerPolicy) {
+			slog.Info("TeamUsecase.SetReviewerPolicy: invalid pools",
+				slog.String("team_name", request.TeamName),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Info("TeamUsecase.SetReviewerPolicy: team not found",
+				slog.String("team_name", request.TeamName),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		slog.Error("TeamUsecase.SetReviewerPolicy: provider error",
+			slog.String("team_name", request.TeamName),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("set reviewer policy in provider: %w", err)
+	}
+
+	slog.Info("TeamUsecase.SetReviewerPolicy: reviewer policy updated",
+		slog.String("team_name", request.TeamName),
+		slog.Int("team_reviewers", saved.TeamReviewers),
+		slog.Int("pools", len(saved.Pools)),
+	)
+
+	return &dto.SetReviewerPolicyResponse{
+		TeamName:       request.TeamName,
+		ReviewerPolicy: *saved,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+)
+
+func (t *TeamUsecase) SetReviewSLA(ctx context.Context, request *dto.SetReviewSLARequest) (*dto.SetReviewSLAResponse, error) {
+	ctx, span := tracing.Start(ctx, "TeamUsecase.SetReviewSLA")
+	defer span.End()
+
+	remindAfter, err := time.ParseDuration(request.RemindAfter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: remind_after: %v", apperr.ErrInvalidReviewSLA, err)
+	}
+
+	escalateAfter, err := time.ParseDuration(request.EscalateAfter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: escalate_after: %v", apperr.ErrInvalidReviewSLA, err)
+	}
+
+	sla := &domain.ReviewSLA{
+		TeamName:      request.TeamName,
+		RemindAfter:   remindAfter,
+		EscalateAfter: escalateAfter,
+	}
+	if err := sla.Validate(); err != nil {
+		return nil, err
+	}
+
+	saved, err := t.teamProvider.SetReviewSLA(ctx, sla)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			slog.Info("TeamUsecase.SetReviewSLA: team not found",
+				slog.String("team_name", request.TeamName),
+			)
+			return nil, apperr.ErrNotFound
+		}
+		slog.Error("TeamUsecase.SetReviewSLA: provider error",
+			slog.String("team_name", request.TeamName),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("set review sla in provider: %w", err)
+	}
+
+	slog.Info("TeamUsecase.SetReviewSLA: review sla updated",
+		slog.String("team_name", saved.TeamName),
+		slog.Duration("remind_after", saved.RemindAfter),
+		slog.Duration("escalate_after", saved.EscalateAfter),
+	)
+
+	return &dto.SetReviewSLAResponse{
+		TeamName:      saved.TeamName,
+		RemindAfter:   saved.RemindAfter.String(),
+		EscalateAfter: saved.EscalateAfter.String(),
+	}, nil
+}
@@ -3,20 +3,39 @@ package usecase
 import (
 	"context"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
 )
 
 type TeamProvider interface {
 	CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	SetReviewSLA(ctx context.Context, sla *domain.ReviewSLA) (*domain.ReviewSLA, error)
+	SetCodeOwners(ctx context.Context, owners *domain.CodeOwners) (*domain.CodeOwners, error)
+	GetCodeOwners(ctx context.Context, teamName string) (*domain.CodeOwners, error)
+	SetPool(ctx context.Context, name string, userIDs []string) (*domain.ReviewerPool, error)
+	GetPool(ctx context.Context, name string) (*domain.ReviewerPool, error)
+	SetReviewerPolicy(ctx context.Context, teamName string, policy *domain.ReviewerPolicy) (*domain.ReviewerPolicy, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
 }
 
 type TeamUsecase struct {
 	teamProvider TeamProvider
+	txManager    Transactor
+	eventWriter  EventWriter
 }
 
-func NewTeamUsecase(repo TeamProvider) *TeamUsecase {
+func NewTeamUsecase(repo TeamProvider, txManager Transactor, eventWriter EventWriter) *TeamUsecase {
 	return &TeamUsecase{
 		teamProvider: repo,
+		txManager:    txManager,
+		eventWriter:  eventWriter,
 	}
 }
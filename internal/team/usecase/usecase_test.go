@@ -6,8 +6,11 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/team/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/team/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
 	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,7 +75,18 @@ func TestTeamUsecase_CreateTeam(t *testing.T) {
 					return tt.stubTeam, tt.stubErr
 				})
 
-			uc := &TeamUsecase{teamProvider: teamProvider}
+			eventWriter := mocks.NewMockTeamEventWriter(ctrl)
+			if !tt.wantErr {
+				eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventTeamMembersChanged, events[0].Type)
+						assert.Equal(t, tt.req.Name, events[0].AggregateID)
+						return nil
+					})
+			}
+
+			uc := &TeamUsecase{teamProvider: teamProvider, txManager: testutils.InlineTx{}, eventWriter: eventWriter}
 
 			resp, err := uc.CreateTeam(context.Background(), tt.req)
 			if tt.wantErr {
@@ -155,3 +169,261 @@ func TestTeamUsecase_GetTeam(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamUsecase_SetReviewSLA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		req          *dto.SetReviewSLARequest
+		callProvider bool
+		stubErr      error
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			req:          &dto.SetReviewSLARequest{TeamName: "backend", RemindAfter: "24h", EscalateAfter: "72h"},
+			callProvider: true,
+		},
+		{
+			name:    "bad_duration",
+			req:     &dto.SetReviewSLARequest{TeamName: "backend", RemindAfter: "day", EscalateAfter: "72h"},
+			wantErr: apperr.ErrInvalidReviewSLA,
+		},
+		{
+			name:    "escalate_not_after_remind",
+			req:     &dto.SetReviewSLARequest{TeamName: "backend", RemindAfter: "72h", EscalateAfter: "24h"},
+			wantErr: apperr.ErrInvalidReviewSLA,
+		},
+		{
+			name:         "team_not_found",
+			req:          &dto.SetReviewSLARequest{TeamName: "ghost", RemindAfter: "24h", EscalateAfter: "0s"},
+			callProvider: true,
+			stubErr:      apperr.ErrNotFound,
+			wantErr:      apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			teamProvider := mocks.NewMockTeamProvider(ctrl)
+
+			if tt.callProvider {
+				teamProvider.EXPECT().
+					SetReviewSLA(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, sla *domain.ReviewSLA) (*domain.ReviewSLA, error) {
+						if tt.stubErr != nil {
+							return nil, tt.stubErr
+						}
+						return sla, nil
+					})
+			}
+
+			uc := &TeamUsecase{teamProvider: teamProvider}
+
+			resp, err := uc.SetReviewSLA(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "backend", resp.TeamName)
+			assert.Equal(t, "24h0m0s", resp.RemindAfter)
+			assert.Equal(t, "72h0m0s", resp.EscalateAfter)
+		})
+	}
+}
+
+func TestTeamUsecase_SetCodeOwners(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		rules        []domain.CodeOwnerRule
+		callProvider bool
+		stubErr      error
+		wantErr      error
+	}{
+		{
+			name: "success",
+			rules: []domain.CodeOwnerRule{
+				{Pattern: "*.go", Teams: []string{"backend"}},
+				{Pattern: "/migrator/", Users: []string{"dba"}},
+			},
+			callProvider: true,
+		},
+		{
+			name:    "bad_pattern",
+			rules:   []domain.CodeOwnerRule{{Pattern: "!*.go", Users: []string{"u1"}}},
+			wantErr: apperr.ErrInvalidCodeOwners,
+		},
+		{
+			name:         "unknown_owner",
+			rules:        []domain.CodeOwnerRule{{Pattern: "*.go", Users: []string{"ghost"}}},
+			callProvider: true,
+			stubErr:      apperr.ErrInvalidCodeOwners,
+			wantErr:      apperr.ErrInvalidCodeOwners,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			teamProvider := mocks.NewMockTeamProvider(ctrl)
+
+			if tt.callProvider {
+				teamProvider.EXPECT().
+					SetCodeOwners(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, owners *domain.CodeOwners) (*domain.CodeOwners, error) {
+						if tt.stubErr != nil {
+							return nil, tt.stubErr
+						}
+						return owners, nil
+					})
+			}
+
+			uc := &TeamUsecase{teamProvider: teamProvider}
+
+			req := &dto.SetCodeOwnersRequest{}
+			req.TeamName = "backend"
+			req.Rules = tt.rules
+
+			resp, err := uc.SetCodeOwners(context.Background(), req)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "backend", resp.CodeOwners.TeamName)
+			assert.Len(t, resp.CodeOwners.Rules, len(tt.rules))
+		})
+	}
+}
+
+func TestTeamUsecase_SetPool(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	teamProvider := mocks.NewMockTeamProvider(ctrl)
+
+	teamProvider.EXPECT().
+		SetPool(gomock.Any(), "security", []string{"s1", "s2"}).
+		Return(&domain.ReviewerPool{
+			Name: "security",
+			Members: []domain.TeamMember{
+				{ID: "s1", IsActive: true, TeamName: "platform"},
+				{ID: "s2", IsActive: true, TeamName: "infra"},
+			},
+		}, nil)
+	teamProvider.EXPECT().
+		SetPool(gomock.Any(), "security", []string{"ghost"}).
+		Return(nil, apperr.ErrInvalidPool)
+
+	eventWriter := mocks.NewMockTeamEventWriter(ctrl)
+	eventWriter.EXPECT().Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+			require.Len(t, events, 1)
+			assert.Equal(t, outboxdomain.EventPoolMembersChanged, events[0].Type)
+			assert.Equal(t, "security", events[0].AggregateID)
+			return nil
+		})
+
+	uc := &TeamUsecase{teamProvider: teamProvider, txManager: testutils.InlineTx{}, eventWriter: eventWriter}
+
+	// дубликаты убираются до записи
+	resp, err := uc.SetPool(context.Background(), &dto.SetPoolRequest{
+		PoolName: "security",
+		Members:  []string{"s2", "s1", "s2"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, resp.Pool.Members, 2)
+
+	resp, err = uc.SetPool(context.Background(), &dto.SetPoolRequest{
+		PoolName: "security",
+		Members:  []string{"ghost"},
+	})
+	require.ErrorIs(t, err, apperr.ErrInvalidPool)
+	require.Nil(t, resp)
+}
+
+func TestTeamUsecase_SetReviewerPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		policy       domain.ReviewerPolicy
+		callProvider bool
+		stubErr      error
+		wantErr      error
+	}{
+		{
+			name:         "success",
+			policy:       domain.ReviewerPolicy{TeamReviewers: 1, Pools: []domain.PoolRequirement{{PoolName: "security", Reviewers: 1}}},
+			callProvider: true,
+		},
+		{
+			name:    "no_reviewers",
+			policy:  domain.ReviewerPolicy{},
+			wantErr: apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:         "unknown_pool",
+			policy:       domain.ReviewerPolicy{TeamReviewers: 1, Pools: []domain.PoolRequirement{{PoolName: "ghost", Reviewers: 1}}},
+			callProvider: true,
+			stubErr:      apperr.ErrInvalidReviewerPolicy,
+			wantErr:      apperr.ErrInvalidReviewerPolicy,
+		},
+		{
+			name:         "team_not_found",
+			policy:       domain.ReviewerPolicy{TeamReviewers: 2},
+			callProvider: true,
+			stubErr:      apperr.ErrNotFound,
+			wantErr:      apperr.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			teamProvider := mocks.NewMockTeamProvider(ctrl)
+
+			if tt.callProvider {
+				teamProvider.EXPECT().
+					SetReviewerPolicy(gomock.Any(), "backend", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, policy *domain.ReviewerPolicy) (*domain.ReviewerPolicy, error) {
+						if tt.stubErr != nil {
+							return nil, tt.stubErr
+						}
+						return policy, nil
+					})
+			}
+
+			uc := &TeamUsecase{teamProvider: teamProvider}
+
+			resp, err := uc.SetReviewerPolicy(context.Background(), &dto.SetReviewerPolicyRequest{
+				TeamName:       "backend",
+				ReviewerPolicy: tt.policy,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "backend", resp.TeamName)
+			assert.Equal(t, tt.policy, resp.ReviewerPolicy)
+		})
+	}
+}
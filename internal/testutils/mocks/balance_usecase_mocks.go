@@ -0,0 +1,292 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/balance/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	domain1 "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	domain2 "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// MockBalancePRProvider is a mock of PRProvider interface.
+type MockBalancePRProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalancePRProviderMockRecorder
+}
+
+// MockBalancePRProviderMockRecorder is the mock recorder for MockBalancePRProvider.
+type MockBalancePRProviderMockRecorder struct {
+	mock *MockBalancePRProvider
+}
+
+// NewMockBalancePRProvider creates a new mock instance.
+func NewMockBalancePRProvider(ctrl *gomock.Controller) *MockBalancePRProvider {
+	mock := &MockBalancePRProvider{ctrl: ctrl}
+	mock.recorder = &MockBalancePRProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalancePRProvider) EXPECT() *MockBalancePRProviderMockRecorder {
+	return m.recorder
+}
+
+// CountOpenReviews mocks base method.
+func (m *MockBalancePRProvider) CountOpenReviews(ctx context.Context, reviewerIDs []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenReviews", ctx, reviewerIDs)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenReviews indicates an expected call of CountOpenReviews.
+func (mr *MockBalancePRProviderMockRecorder) CountOpenReviews(ctx, reviewerIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenReviews", reflect.TypeOf((*MockBalancePRProvider)(nil).CountOpenReviews), ctx, reviewerIDs)
+}
+
+// ListOpenByAuthorTeam mocks base method.
+func (m *MockBalancePRProvider) ListOpenByAuthorTeam(ctx context.Context, teamName string) ([]domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByAuthorTeam", ctx, teamName)
+	ret0, _ := ret[0].([]domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByAuthorTeam indicates an expected call of ListOpenByAuthorTeam.
+func (mr *MockBalancePRProviderMockRecorder) ListOpenByAuthorTeam(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByAuthorTeam", reflect.TypeOf((*MockBalancePRProvider)(nil).ListOpenByAuthorTeam), ctx, teamName)
+}
+
+// LockPR mocks base method.
+func (m *MockBalancePRProvider) LockPR(ctx context.Context, id string) (*domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPR", ctx, id)
+	ret0, _ := ret[0].(*domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPR indicates an expected call of LockPR.
+func (mr *MockBalancePRProviderMockRecorder) LockPR(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPR", reflect.TypeOf((*MockBalancePRProvider)(nil).LockPR), ctx, id)
+}
+
+// UpdatePR mocks base method.
+func (m *MockBalancePRProvider) UpdatePR(ctx context.Context, pr *domain1.PullRequest) (*domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePR", ctx, pr)
+	ret0, _ := ret[0].(*domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePR indicates an expected call of UpdatePR.
+func (mr *MockBalancePRProviderMockRecorder) UpdatePR(ctx, pr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePR", reflect.TypeOf((*MockBalancePRProvider)(nil).UpdatePR), ctx, pr)
+}
+
+// MockBalanceTeamReader is a mock of TeamReader interface.
+type MockBalanceTeamReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceTeamReaderMockRecorder
+}
+
+// MockBalanceTeamReaderMockRecorder is the mock recorder for MockBalanceTeamReader.
+type MockBalanceTeamReaderMockRecorder struct {
+	mock *MockBalanceTeamReader
+}
+
+// NewMockBalanceTeamReader creates a new mock instance.
+func NewMockBalanceTeamReader(ctrl *gomock.Controller) *MockBalanceTeamReader {
+	mock := &MockBalanceTeamReader{ctrl: ctrl}
+	mock.recorder = &MockBalanceTeamReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceTeamReader) EXPECT() *MockBalanceTeamReaderMockRecorder {
+	return m.recorder
+}
+
+// GetTeam mocks base method.
+func (m *MockBalanceTeamReader) GetTeam(ctx context.Context, teamName string) (*domain2.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
+	ret0, _ := ret[0].(*domain2.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeam indicates an expected call of GetTeam.
+func (mr *MockBalanceTeamReaderMockRecorder) GetTeam(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockBalanceTeamReader)(nil).GetTeam), ctx, teamName)
+}
+
+// MockBalancePairingReader is a mock of PairingReader interface.
+type MockBalancePairingReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalancePairingReaderMockRecorder
+}
+
+// MockBalancePairingReaderMockRecorder is the mock recorder for MockBalancePairingReader.
+type MockBalancePairingReaderMockRecorder struct {
+	mock *MockBalancePairingReader
+}
+
+// NewMockBalancePairingReader creates a new mock instance.
+func NewMockBalancePairingReader(ctrl *gomock.Controller) *MockBalancePairingReader {
+	mock := &MockBalancePairingReader{ctrl: ctrl}
+	mock.recorder = &MockBalancePairingReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalancePairingReader) EXPECT() *MockBalancePairingReaderMockRecorder {
+	return m.recorder
+}
+
+// ListAuthorRules mocks base method.
+func (m *MockBalancePairingReader) ListAuthorRules(ctx context.Context, authorID string, now time.Time) ([]domain0.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorRules", ctx, authorID, now)
+	ret0, _ := ret[0].([]domain0.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthorRules indicates an expected call of ListAuthorRules.
+func (mr *MockBalancePairingReaderMockRecorder) ListAuthorRules(ctx, authorID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorRules", reflect.TypeOf((*MockBalancePairingReader)(nil).ListAuthorRules), ctx, authorID, now)
+}
+
+// MockBalanceEventWriter is a mock of EventWriter interface.
+type MockBalanceEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceEventWriterMockRecorder
+}
+
+// MockBalanceEventWriterMockRecorder is the mock recorder for MockBalanceEventWriter.
+type MockBalanceEventWriterMockRecorder struct {
+	mock *MockBalanceEventWriter
+}
+
+// NewMockBalanceEventWriter creates a new mock instance.
+func NewMockBalanceEventWriter(ctrl *gomock.Controller) *MockBalanceEventWriter {
+	mock := &MockBalanceEventWriter{ctrl: ctrl}
+	mock.recorder = &MockBalanceEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceEventWriter) EXPECT() *MockBalanceEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockBalanceEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockBalanceEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockBalanceEventWriter)(nil).Append), varargs...)
+}
+
+// MockBalanceDecisionStore is a mock of DecisionStore interface.
+type MockBalanceDecisionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceDecisionStoreMockRecorder
+}
+
+// MockBalanceDecisionStoreMockRecorder is the mock recorder for MockBalanceDecisionStore.
+type MockBalanceDecisionStoreMockRecorder struct {
+	mock *MockBalanceDecisionStore
+}
+
+// NewMockBalanceDecisionStore creates a new mock instance.
+func NewMockBalanceDecisionStore(ctrl *gomock.Controller) *MockBalanceDecisionStore {
+	mock := &MockBalanceDecisionStore{ctrl: ctrl}
+	mock.recorder = &MockBalanceDecisionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceDecisionStore) EXPECT() *MockBalanceDecisionStoreMockRecorder {
+	return m.recorder
+}
+
+// SaveDecision mocks base method.
+func (m *MockBalanceDecisionStore) SaveDecision(ctx context.Context, decision *domain1.Decision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDecision", ctx, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDecision indicates an expected call of SaveDecision.
+func (mr *MockBalanceDecisionStoreMockRecorder) SaveDecision(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDecision", reflect.TypeOf((*MockBalanceDecisionStore)(nil).SaveDecision), ctx, decision)
+}
+
+// MockBalanceTransactor is a mock of Transactor interface.
+type MockBalanceTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceTransactorMockRecorder
+}
+
+// MockBalanceTransactorMockRecorder is the mock recorder for MockBalanceTransactor.
+type MockBalanceTransactorMockRecorder struct {
+	mock *MockBalanceTransactor
+}
+
+// NewMockBalanceTransactor creates a new mock instance.
+func NewMockBalanceTransactor(ctrl *gomock.Controller) *MockBalanceTransactor {
+	mock := &MockBalanceTransactor{ctrl: ctrl}
+	mock.recorder = &MockBalanceTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceTransactor) EXPECT() *MockBalanceTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockBalanceTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockBalanceTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockBalanceTransactor)(nil).WithinTx), ctx, fn)
+}
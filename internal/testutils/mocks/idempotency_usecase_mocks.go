@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/idempotency/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/idempotency/domain"
+)
+
+// MockRecordProvider is a mock of RecordProvider interface.
+type MockRecordProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecordProviderMockRecorder
+}
+
+// MockRecordProviderMockRecorder is the mock recorder for MockRecordProvider.
+type MockRecordProviderMockRecorder struct {
+	mock *MockRecordProvider
+}
+
+// NewMockRecordProvider creates a new mock instance.
+func NewMockRecordProvider(ctrl *gomock.Controller) *MockRecordProvider {
+	mock := &MockRecordProvider{ctrl: ctrl}
+	mock.recorder = &MockRecordProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecordProvider) EXPECT() *MockRecordProviderMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockRecordProvider) Complete(ctx context.Context, key string, statusCode int, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, key, statusCode, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRecordProviderMockRecorder) Complete(ctx, key, statusCode, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRecordProvider)(nil).Complete), ctx, key, statusCode, body)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRecordProvider) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRecordProviderMockRecorder) DeleteExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRecordProvider)(nil).DeleteExpired), ctx, now)
+}
+
+// Release mocks base method.
+func (m *MockRecordProvider) Release(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockRecordProviderMockRecorder) Release(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockRecordProvider)(nil).Release), ctx, key)
+}
+
+// Reserve mocks base method.
+func (m *MockRecordProvider) Reserve(ctx context.Context, record *domain.Record) (*domain.Record, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, record)
+	ret0, _ := ret[0].(*domain.Record)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockRecordProviderMockRecorder) Reserve(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockRecordProvider)(nil).Reserve), ctx, record)
+}
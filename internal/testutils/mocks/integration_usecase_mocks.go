@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/integration/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/integration/domain"
+	dto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+// MockIdentityProvider is a mock of IdentityProvider interface.
+type MockIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProviderMockRecorder
+}
+
+// MockIdentityProviderMockRecorder is the mock recorder for MockIdentityProvider.
+type MockIdentityProviderMockRecorder struct {
+	mock *MockIdentityProvider
+}
+
+// NewMockIdentityProvider creates a new mock instance.
+func NewMockIdentityProvider(ctrl *gomock.Controller) *MockIdentityProvider {
+	mock := &MockIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProvider) EXPECT() *MockIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// AddIdentity mocks base method.
+func (m *MockIdentityProvider) AddIdentity(ctx context.Context, identity *domain.ExternalIdentity) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIdentity", ctx, identity)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIdentity indicates an expected call of AddIdentity.
+func (mr *MockIdentityProviderMockRecorder) AddIdentity(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentity", reflect.TypeOf((*MockIdentityProvider)(nil).AddIdentity), ctx, identity)
+}
+
+// ListIdentities mocks base method.
+func (m *MockIdentityProvider) ListIdentities(ctx context.Context, provider domain.Provider) ([]domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIdentities", ctx, provider)
+	ret0, _ := ret[0].([]domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIdentities indicates an expected call of ListIdentities.
+func (mr *MockIdentityProviderMockRecorder) ListIdentities(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIdentities", reflect.TypeOf((*MockIdentityProvider)(nil).ListIdentities), ctx, provider)
+}
+
+// ResolveUserID mocks base method.
+func (m *MockIdentityProvider) ResolveUserID(ctx context.Context, provider domain.Provider, login, externalID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveUserID", ctx, provider, login, externalID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveUserID indicates an expected call of ResolveUserID.
+func (mr *MockIdentityProviderMockRecorder) ResolveUserID(ctx, provider, login, externalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveUserID", reflect.TypeOf((*MockIdentityProvider)(nil).ResolveUserID), ctx, provider, login, externalID)
+}
+
+// MockPRService is a mock of PRService interface.
+type MockPRService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRServiceMockRecorder
+}
+
+// MockPRServiceMockRecorder is the mock recorder for MockPRService.
+type MockPRServiceMockRecorder struct {
+	mock *MockPRService
+}
+
+// NewMockPRService creates a new mock instance.
+func NewMockPRService(ctrl *gomock.Controller) *MockPRService {
+	mock := &MockPRService{ctrl: ctrl}
+	mock.recorder = &MockPRServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRService) EXPECT() *MockPRServiceMockRecorder {
+	return m.recorder
+}
+
+// ClosePR mocks base method.
+func (m *MockPRService) ClosePR(ctx context.Context, request *dto.ClosePRRequest) (*dto.ClosePRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePR", ctx, request)
+	ret0, _ := ret[0].(*dto.ClosePRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePR indicates an expected call of ClosePR.
+func (mr *MockPRServiceMockRecorder) ClosePR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePR", reflect.TypeOf((*MockPRService)(nil).ClosePR), ctx, request)
+}
+
+// CreatePR mocks base method.
+func (m *MockPRService) CreatePR(ctx context.Context, request *dto.CreatePRRequest) (*dto.CreatePRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePR", ctx, request)
+	ret0, _ := ret[0].(*dto.CreatePRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePR indicates an expected call of CreatePR.
+func (mr *MockPRServiceMockRecorder) CreatePR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePR", reflect.TypeOf((*MockPRService)(nil).CreatePR), ctx, request)
+}
+
+// MergePR mocks base method.
+func (m *MockPRService) MergePR(ctx context.Context, request *dto.MergePRRequest) (*dto.MergePRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePR", ctx, request)
+	ret0, _ := ret[0].(*dto.MergePRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergePR indicates an expected call of MergePR.
+func (mr *MockPRServiceMockRecorder) MergePR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePR", reflect.TypeOf((*MockPRService)(nil).MergePR), ctx, request)
+}
+
+// ReopenPR mocks base method.
+func (m *MockPRService) ReopenPR(ctx context.Context, request *dto.ReopenPRRequest) (*dto.ReopenPRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPR", ctx, request)
+	ret0, _ := ret[0].(*dto.ReopenPRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenPR indicates an expected call of ReopenPR.
+func (mr *MockPRServiceMockRecorder) ReopenPR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPR", reflect.TypeOf((*MockPRService)(nil).ReopenPR), ctx, request)
+}
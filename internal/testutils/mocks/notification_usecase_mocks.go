@@ -0,0 +1,296 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/notification/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/notification/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// MockNotificationUserReader is a mock of UserReader interface.
+type MockNotificationUserReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationUserReaderMockRecorder
+}
+
+// MockNotificationUserReaderMockRecorder is the mock recorder for MockNotificationUserReader.
+type MockNotificationUserReaderMockRecorder struct {
+	mock *MockNotificationUserReader
+}
+
+// NewMockNotificationUserReader creates a new mock instance.
+func NewMockNotificationUserReader(ctrl *gomock.Controller) *MockNotificationUserReader {
+	mock := &MockNotificationUserReader{ctrl: ctrl}
+	mock.recorder = &MockNotificationUserReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationUserReader) EXPECT() *MockNotificationUserReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockNotificationUserReader) GetUser(ctx context.Context, id string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockNotificationUserReaderMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockNotificationUserReader)(nil).GetUser), ctx, id)
+}
+
+// MockNotificationTransactor is a mock of Transactor interface.
+type MockNotificationTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationTransactorMockRecorder
+}
+
+// MockNotificationTransactorMockRecorder is the mock recorder for MockNotificationTransactor.
+type MockNotificationTransactorMockRecorder struct {
+	mock *MockNotificationTransactor
+}
+
+// NewMockNotificationTransactor creates a new mock instance.
+func NewMockNotificationTransactor(ctrl *gomock.Controller) *MockNotificationTransactor {
+	mock := &MockNotificationTransactor{ctrl: ctrl}
+	mock.recorder = &MockNotificationTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationTransactor) EXPECT() *MockNotificationTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockNotificationTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockNotificationTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockNotificationTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockDigestProvider is a mock of DigestProvider interface.
+type MockDigestProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockDigestProviderMockRecorder
+}
+
+// MockDigestProviderMockRecorder is the mock recorder for MockDigestProvider.
+type MockDigestProviderMockRecorder struct {
+	mock *MockDigestProvider
+}
+
+// NewMockDigestProvider creates a new mock instance.
+func NewMockDigestProvider(ctrl *gomock.Controller) *MockDigestProvider {
+	mock := &MockDigestProvider{ctrl: ctrl}
+	mock.recorder = &MockDigestProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDigestProvider) EXPECT() *MockDigestProviderMockRecorder {
+	return m.recorder
+}
+
+// AppendDigestItem mocks base method.
+func (m *MockDigestProvider) AppendDigestItem(ctx context.Context, userID string, item domain.DigestItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendDigestItem", ctx, userID, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendDigestItem indicates an expected call of AppendDigestItem.
+func (mr *MockDigestProviderMockRecorder) AppendDigestItem(ctx, userID, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendDigestItem", reflect.TypeOf((*MockDigestProvider)(nil).AppendDigestItem), ctx, userID, item)
+}
+
+// ClaimDigestItems mocks base method.
+func (m *MockDigestProvider) ClaimDigestItems(ctx context.Context, userID string) ([]domain.DigestItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDigestItems", ctx, userID)
+	ret0, _ := ret[0].([]domain.DigestItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDigestItems indicates an expected call of ClaimDigestItems.
+func (mr *MockDigestProviderMockRecorder) ClaimDigestItems(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDigestItems", reflect.TypeOf((*MockDigestProvider)(nil).ClaimDigestItems), ctx, userID)
+}
+
+// ListDigestRecipients mocks base method.
+func (m *MockDigestProvider) ListDigestRecipients(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDigestRecipients", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDigestRecipients indicates an expected call of ListDigestRecipients.
+func (mr *MockDigestProviderMockRecorder) ListDigestRecipients(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDigestRecipients", reflect.TypeOf((*MockDigestProvider)(nil).ListDigestRecipients), ctx)
+}
+
+// MarkDigestSent mocks base method.
+func (m *MockDigestProvider) MarkDigestSent(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDigestSent", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDigestSent indicates an expected call of MarkDigestSent.
+func (mr *MockDigestProviderMockRecorder) MarkDigestSent(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDigestSent", reflect.TypeOf((*MockDigestProvider)(nil).MarkDigestSent), ctx, ids)
+}
+
+// MockDeliveryLog is a mock of DeliveryLog interface.
+type MockDeliveryLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryLogMockRecorder
+}
+
+// MockDeliveryLogMockRecorder is the mock recorder for MockDeliveryLog.
+type MockDeliveryLogMockRecorder struct {
+	mock *MockDeliveryLog
+}
+
+// NewMockDeliveryLog creates a new mock instance.
+func NewMockDeliveryLog(ctrl *gomock.Controller) *MockDeliveryLog {
+	mock := &MockDeliveryLog{ctrl: ctrl}
+	mock.recorder = &MockDeliveryLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryLog) EXPECT() *MockDeliveryLogMockRecorder {
+	return m.recorder
+}
+
+// IsDelivered mocks base method.
+func (m *MockDeliveryLog) IsDelivered(ctx context.Context, delivery domain.Delivery) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDelivered", ctx, delivery)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDelivered indicates an expected call of IsDelivered.
+func (mr *MockDeliveryLogMockRecorder) IsDelivered(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDelivered", reflect.TypeOf((*MockDeliveryLog)(nil).IsDelivered), ctx, delivery)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockDeliveryLog) MarkDelivered(ctx context.Context, delivery domain.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockDeliveryLogMockRecorder) MarkDelivered(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockDeliveryLog)(nil).MarkDelivered), ctx, delivery)
+}
+
+// MockEmailSender is a mock of EmailSender interface.
+type MockEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSenderMockRecorder
+}
+
+// MockEmailSenderMockRecorder is the mock recorder for MockEmailSender.
+type MockEmailSenderMockRecorder struct {
+	mock *MockEmailSender
+}
+
+// NewMockEmailSender creates a new mock instance.
+func NewMockEmailSender(ctrl *gomock.Controller) *MockEmailSender {
+	mock := &MockEmailSender{ctrl: ctrl}
+	mock.recorder = &MockEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSender) EXPECT() *MockEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockEmailSender) Send(ctx context.Context, email *domain.Email) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockEmailSenderMockRecorder) Send(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockEmailSender)(nil).Send), ctx, email)
+}
+
+// MockChatSender is a mock of ChatSender interface.
+type MockChatSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatSenderMockRecorder
+}
+
+// MockChatSenderMockRecorder is the mock recorder for MockChatSender.
+type MockChatSenderMockRecorder struct {
+	mock *MockChatSender
+}
+
+// NewMockChatSender creates a new mock instance.
+func NewMockChatSender(ctrl *gomock.Controller) *MockChatSender {
+	mock := &MockChatSender{ctrl: ctrl}
+	mock.recorder = &MockChatSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatSender) EXPECT() *MockChatSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockChatSender) Send(ctx context.Context, message domain.ChatMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockChatSenderMockRecorder) Send(ctx, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockChatSender)(nil).Send), ctx, message)
+}
@@ -0,0 +1,375 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ooo/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/ooo/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain1 "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	dto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	domain2 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// MockPeriodProvider is a mock of PeriodProvider interface.
+type MockPeriodProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeriodProviderMockRecorder
+}
+
+// MockPeriodProviderMockRecorder is the mock recorder for MockPeriodProvider.
+type MockPeriodProviderMockRecorder struct {
+	mock *MockPeriodProvider
+}
+
+// NewMockPeriodProvider creates a new mock instance.
+func NewMockPeriodProvider(ctrl *gomock.Controller) *MockPeriodProvider {
+	mock := &MockPeriodProvider{ctrl: ctrl}
+	mock.recorder = &MockPeriodProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeriodProvider) EXPECT() *MockPeriodProviderMockRecorder {
+	return m.recorder
+}
+
+// CreatePeriod mocks base method.
+func (m *MockPeriodProvider) CreatePeriod(ctx context.Context, period *domain.Period) (*domain.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePeriod", ctx, period)
+	ret0, _ := ret[0].(*domain.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePeriod indicates an expected call of CreatePeriod.
+func (mr *MockPeriodProviderMockRecorder) CreatePeriod(ctx, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeriod", reflect.TypeOf((*MockPeriodProvider)(nil).CreatePeriod), ctx, period)
+}
+
+// DeletePeriod mocks base method.
+func (m *MockPeriodProvider) DeletePeriod(ctx context.Context, id int64) (*domain.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePeriod", ctx, id)
+	ret0, _ := ret[0].(*domain.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePeriod indicates an expected call of DeletePeriod.
+func (mr *MockPeriodProviderMockRecorder) DeletePeriod(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePeriod", reflect.TypeOf((*MockPeriodProvider)(nil).DeletePeriod), ctx, id)
+}
+
+// ListDueReassignments mocks base method.
+func (m *MockPeriodProvider) ListDueReassignments(ctx context.Context, now time.Time, limit int) ([]domain.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueReassignments", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueReassignments indicates an expected call of ListDueReassignments.
+func (mr *MockPeriodProviderMockRecorder) ListDueReassignments(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueReassignments", reflect.TypeOf((*MockPeriodProvider)(nil).ListDueReassignments), ctx, now, limit)
+}
+
+// ListEndedPeriods mocks base method.
+func (m *MockPeriodProvider) ListEndedPeriods(ctx context.Context, now time.Time, limit int) ([]domain.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndedPeriods", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndedPeriods indicates an expected call of ListEndedPeriods.
+func (mr *MockPeriodProviderMockRecorder) ListEndedPeriods(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndedPeriods", reflect.TypeOf((*MockPeriodProvider)(nil).ListEndedPeriods), ctx, now, limit)
+}
+
+// ListPeriods mocks base method.
+func (m *MockPeriodProvider) ListPeriods(ctx context.Context, userID string, now time.Time) ([]domain.Period, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPeriods", ctx, userID, now)
+	ret0, _ := ret[0].([]domain.Period)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPeriods indicates an expected call of ListPeriods.
+func (mr *MockPeriodProviderMockRecorder) ListPeriods(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPeriods", reflect.TypeOf((*MockPeriodProvider)(nil).ListPeriods), ctx, userID, now)
+}
+
+// MarkEndAnnounced mocks base method.
+func (m *MockPeriodProvider) MarkEndAnnounced(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEndAnnounced", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEndAnnounced indicates an expected call of MarkEndAnnounced.
+func (mr *MockPeriodProviderMockRecorder) MarkEndAnnounced(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEndAnnounced", reflect.TypeOf((*MockPeriodProvider)(nil).MarkEndAnnounced), ctx, id, at)
+}
+
+// MarkReassigned mocks base method.
+func (m *MockPeriodProvider) MarkReassigned(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReassigned", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReassigned indicates an expected call of MarkReassigned.
+func (mr *MockPeriodProviderMockRecorder) MarkReassigned(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReassigned", reflect.TypeOf((*MockPeriodProvider)(nil).MarkReassigned), ctx, id, at)
+}
+
+// MockOOOUserReader is a mock of UserReader interface.
+type MockOOOUserReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockOOOUserReaderMockRecorder
+}
+
+// MockOOOUserReaderMockRecorder is the mock recorder for MockOOOUserReader.
+type MockOOOUserReaderMockRecorder struct {
+	mock *MockOOOUserReader
+}
+
+// NewMockOOOUserReader creates a new mock instance.
+func NewMockOOOUserReader(ctrl *gomock.Controller) *MockOOOUserReader {
+	mock := &MockOOOUserReader{ctrl: ctrl}
+	mock.recorder = &MockOOOUserReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOOOUserReader) EXPECT() *MockOOOUserReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockOOOUserReader) GetUser(ctx context.Context, id string) (*domain2.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*domain2.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockOOOUserReaderMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockOOOUserReader)(nil).GetUser), ctx, id)
+}
+
+// MockReviewReader is a mock of ReviewReader interface.
+type MockReviewReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewReaderMockRecorder
+}
+
+// MockReviewReaderMockRecorder is the mock recorder for MockReviewReader.
+type MockReviewReaderMockRecorder struct {
+	mock *MockReviewReader
+}
+
+// NewMockReviewReader creates a new mock instance.
+func NewMockReviewReader(ctrl *gomock.Controller) *MockReviewReader {
+	mock := &MockReviewReader{ctrl: ctrl}
+	mock.recorder = &MockReviewReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewReader) EXPECT() *MockReviewReaderMockRecorder {
+	return m.recorder
+}
+
+// GetReview mocks base method.
+func (m *MockReviewReader) GetReview(ctx context.Context, userId string) (*[]domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, userId)
+	ret0, _ := ret[0].(*[]domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockReviewReaderMockRecorder) GetReview(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockReviewReader)(nil).GetReview), ctx, userId)
+}
+
+// MockOOOReassigner is a mock of Reassigner interface.
+type MockOOOReassigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockOOOReassignerMockRecorder
+}
+
+// MockOOOReassignerMockRecorder is the mock recorder for MockOOOReassigner.
+type MockOOOReassignerMockRecorder struct {
+	mock *MockOOOReassigner
+}
+
+// NewMockOOOReassigner creates a new mock instance.
+func NewMockOOOReassigner(ctrl *gomock.Controller) *MockOOOReassigner {
+	mock := &MockOOOReassigner{ctrl: ctrl}
+	mock.recorder = &MockOOOReassignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOOOReassigner) EXPECT() *MockOOOReassignerMockRecorder {
+	return m.recorder
+}
+
+// ReassignPR mocks base method.
+func (m *MockOOOReassigner) ReassignPR(ctx context.Context, request *dto.ReassignPRRequest) (*dto.ReassignPRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignPR", ctx, request)
+	ret0, _ := ret[0].(*dto.ReassignPRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignPR indicates an expected call of ReassignPR.
+func (mr *MockOOOReassignerMockRecorder) ReassignPR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignPR", reflect.TypeOf((*MockOOOReassigner)(nil).ReassignPR), ctx, request)
+}
+
+// MockOOOEventWriter is a mock of EventWriter interface.
+type MockOOOEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOOOEventWriterMockRecorder
+}
+
+// MockOOOEventWriterMockRecorder is the mock recorder for MockOOOEventWriter.
+type MockOOOEventWriterMockRecorder struct {
+	mock *MockOOOEventWriter
+}
+
+// NewMockOOOEventWriter creates a new mock instance.
+func NewMockOOOEventWriter(ctrl *gomock.Controller) *MockOOOEventWriter {
+	mock := &MockOOOEventWriter{ctrl: ctrl}
+	mock.recorder = &MockOOOEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOOOEventWriter) EXPECT() *MockOOOEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOOOEventWriter) Append(ctx context.Context, events ...domain0.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOOOEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOOOEventWriter)(nil).Append), varargs...)
+}
+
+// MockOOOTransactor is a mock of Transactor interface.
+type MockOOOTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockOOOTransactorMockRecorder
+}
+
+// MockOOOTransactorMockRecorder is the mock recorder for MockOOOTransactor.
+type MockOOOTransactorMockRecorder struct {
+	mock *MockOOOTransactor
+}
+
+// NewMockOOOTransactor creates a new mock instance.
+func NewMockOOOTransactor(ctrl *gomock.Controller) *MockOOOTransactor {
+	mock := &MockOOOTransactor{ctrl: ctrl}
+	mock.recorder = &MockOOOTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOOOTransactor) EXPECT() *MockOOOTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockOOOTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockOOOTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockOOOTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockOOOLocker is a mock of Locker interface.
+type MockOOOLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockOOOLockerMockRecorder
+}
+
+// MockOOOLockerMockRecorder is the mock recorder for MockOOOLocker.
+type MockOOOLockerMockRecorder struct {
+	mock *MockOOOLocker
+}
+
+// NewMockOOOLocker creates a new mock instance.
+func NewMockOOOLocker(ctrl *gomock.Controller) *MockOOOLocker {
+	mock := &MockOOOLocker{ctrl: ctrl}
+	mock.recorder = &MockOOOLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOOOLocker) EXPECT() *MockOOOLockerMockRecorder {
+	return m.recorder
+}
+
+// TryRun mocks base method.
+func (m *MockOOOLocker) TryRun(ctx context.Context, fn func(context.Context) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryRun", ctx, fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryRun indicates an expected call of TryRun.
+func (mr *MockOOOLockerMockRecorder) TryRun(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRun", reflect.TypeOf((*MockOOOLocker)(nil).TryRun), ctx, fn)
+}
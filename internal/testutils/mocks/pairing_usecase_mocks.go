@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pairing/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// MockRuleProvider is a mock of RuleProvider interface.
+type MockRuleProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleProviderMockRecorder
+}
+
+// MockRuleProviderMockRecorder is the mock recorder for MockRuleProvider.
+type MockRuleProviderMockRecorder struct {
+	mock *MockRuleProvider
+}
+
+// NewMockRuleProvider creates a new mock instance.
+func NewMockRuleProvider(ctrl *gomock.Controller) *MockRuleProvider {
+	mock := &MockRuleProvider{ctrl: ctrl}
+	mock.recorder = &MockRuleProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuleProvider) EXPECT() *MockRuleProviderMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockRuleProvider) DeleteRule(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRuleProviderMockRecorder) DeleteRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRuleProvider)(nil).DeleteRule), ctx, id)
+}
+
+// ListRules mocks base method.
+func (m *MockRuleProvider) ListRules(ctx context.Context, userID string, now time.Time) ([]domain.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx, userID, now)
+	ret0, _ := ret[0].([]domain.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRuleProviderMockRecorder) ListRules(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRuleProvider)(nil).ListRules), ctx, userID, now)
+}
+
+// SaveRule mocks base method.
+func (m *MockRuleProvider) SaveRule(ctx context.Context, rule *domain.Rule) (*domain.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRule", ctx, rule)
+	ret0, _ := ret[0].(*domain.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRule indicates an expected call of SaveRule.
+func (mr *MockRuleProviderMockRecorder) SaveRule(ctx, rule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRule", reflect.TypeOf((*MockRuleProvider)(nil).SaveRule), ctx, rule)
+}
+
+// MockPairingUserReader is a mock of UserReader interface.
+type MockPairingUserReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPairingUserReaderMockRecorder
+}
+
+// MockPairingUserReaderMockRecorder is the mock recorder for MockPairingUserReader.
+type MockPairingUserReaderMockRecorder struct {
+	mock *MockPairingUserReader
+}
+
+// NewMockPairingUserReader creates a new mock instance.
+func NewMockPairingUserReader(ctrl *gomock.Controller) *MockPairingUserReader {
+	mock := &MockPairingUserReader{ctrl: ctrl}
+	mock.recorder = &MockPairingUserReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPairingUserReader) EXPECT() *MockPairingUserReaderMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockPairingUserReader) GetUser(ctx context.Context, id string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockPairingUserReaderMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockPairingUserReader)(nil).GetUser), ctx, id)
+}
@@ -7,11 +7,14 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	domain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
-	domain0 "github.com/silentmol/avito-backend-trainee/internal/team/domain"
-	domain1 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/pairing/domain"
+	domain1 "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	domain2 "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	domain3 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
 // MockUserReader is a mock of UserReader interface.
@@ -38,10 +41,10 @@ func (m *MockUserReader) EXPECT() *MockUserReaderMockRecorder {
 }
 
 // GetUser mocks base method.
-func (m *MockUserReader) GetUser(ctx context.Context, id string) (*domain1.User, error) {
+func (m *MockUserReader) GetUser(ctx context.Context, id string) (*domain3.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUser", ctx, id)
-	ret0, _ := ret[0].(*domain1.User)
+	ret0, _ := ret[0].(*domain3.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -75,11 +78,41 @@ func (m *MockTeamReader) EXPECT() *MockTeamReaderMockRecorder {
 	return m.recorder
 }
 
+// GetCodeOwners mocks base method.
+func (m *MockTeamReader) GetCodeOwners(ctx context.Context, teamName string) (*domain2.CodeOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeOwners", ctx, teamName)
+	ret0, _ := ret[0].(*domain2.CodeOwners)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeOwners indicates an expected call of GetCodeOwners.
+func (mr *MockTeamReaderMockRecorder) GetCodeOwners(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwners", reflect.TypeOf((*MockTeamReader)(nil).GetCodeOwners), ctx, teamName)
+}
+
+// GetPool mocks base method.
+func (m *MockTeamReader) GetPool(ctx context.Context, name string) (*domain2.ReviewerPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPool", ctx, name)
+	ret0, _ := ret[0].(*domain2.ReviewerPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPool indicates an expected call of GetPool.
+func (mr *MockTeamReaderMockRecorder) GetPool(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockTeamReader)(nil).GetPool), ctx, name)
+}
+
 // GetTeam mocks base method.
-func (m *MockTeamReader) GetTeam(ctx context.Context, teamName string) (*domain0.Team, error) {
+func (m *MockTeamReader) GetTeam(ctx context.Context, teamName string) (*domain2.Team, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
-	ret0, _ := ret[0].(*domain0.Team)
+	ret0, _ := ret[0].(*domain2.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -90,6 +123,44 @@ func (mr *MockTeamReaderMockRecorder) GetTeam(ctx, teamName interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamReader)(nil).GetTeam), ctx, teamName)
 }
 
+// MockPairingReader is a mock of PairingReader interface.
+type MockPairingReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPairingReaderMockRecorder
+}
+
+// MockPairingReaderMockRecorder is the mock recorder for MockPairingReader.
+type MockPairingReaderMockRecorder struct {
+	mock *MockPairingReader
+}
+
+// NewMockPairingReader creates a new mock instance.
+func NewMockPairingReader(ctrl *gomock.Controller) *MockPairingReader {
+	mock := &MockPairingReader{ctrl: ctrl}
+	mock.recorder = &MockPairingReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPairingReader) EXPECT() *MockPairingReaderMockRecorder {
+	return m.recorder
+}
+
+// ListAuthorRules mocks base method.
+func (m *MockPairingReader) ListAuthorRules(ctx context.Context, authorID string, now time.Time) ([]domain0.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorRules", ctx, authorID, now)
+	ret0, _ := ret[0].([]domain0.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthorRules indicates an expected call of ListAuthorRules.
+func (mr *MockPairingReaderMockRecorder) ListAuthorRules(ctx, authorID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorRules", reflect.TypeOf((*MockPairingReader)(nil).ListAuthorRules), ctx, authorID, now)
+}
+
 // MockPRProvider is a mock of PRProvider interface.
 type MockPRProvider struct {
 	ctrl     *gomock.Controller
@@ -113,11 +184,26 @@ func (m *MockPRProvider) EXPECT() *MockPRProviderMockRecorder {
 	return m.recorder
 }
 
+// ClosePR mocks base method.
+func (m *MockPRProvider) ClosePR(ctx context.Context, id string, at time.Time) (*domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePR", ctx, id, at)
+	ret0, _ := ret[0].(*domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePR indicates an expected call of ClosePR.
+func (mr *MockPRProviderMockRecorder) ClosePR(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePR", reflect.TypeOf((*MockPRProvider)(nil).ClosePR), ctx, id, at)
+}
+
 // CreatePR mocks base method.
-func (m *MockPRProvider) CreatePR(ctx context.Context, pullRequest *domain.PullRequest) (*domain.PullRequest, error) {
+func (m *MockPRProvider) CreatePR(ctx context.Context, pullRequest *domain1.PullRequest) (*domain1.PullRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreatePR", ctx, pullRequest)
-	ret0, _ := ret[0].(*domain.PullRequest)
+	ret0, _ := ret[0].(*domain1.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -129,10 +215,10 @@ func (mr *MockPRProviderMockRecorder) CreatePR(ctx, pullRequest interface{}) *go
 }
 
 // GetPR mocks base method.
-func (m *MockPRProvider) GetPR(ctx context.Context, id string) (*domain.PullRequest, error) {
+func (m *MockPRProvider) GetPR(ctx context.Context, id string) (*domain1.PullRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPR", ctx, id)
-	ret0, _ := ret[0].(*domain.PullRequest)
+	ret0, _ := ret[0].(*domain1.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -144,10 +230,10 @@ func (mr *MockPRProviderMockRecorder) GetPR(ctx, id interface{}) *gomock.Call {
 }
 
 // GetReview mocks base method.
-func (m *MockPRProvider) GetReview(ctx context.Context, userId string) (*[]domain.PullRequest, error) {
+func (m *MockPRProvider) GetReview(ctx context.Context, userId string) (*[]domain1.PullRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetReview", ctx, userId)
-	ret0, _ := ret[0].(*[]domain.PullRequest)
+	ret0, _ := ret[0].(*[]domain1.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -158,11 +244,26 @@ func (mr *MockPRProviderMockRecorder) GetReview(ctx, userId interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockPRProvider)(nil).GetReview), ctx, userId)
 }
 
+// LockPR mocks base method.
+func (m *MockPRProvider) LockPR(ctx context.Context, id string) (*domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPR", ctx, id)
+	ret0, _ := ret[0].(*domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPR indicates an expected call of LockPR.
+func (mr *MockPRProviderMockRecorder) LockPR(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPR", reflect.TypeOf((*MockPRProvider)(nil).LockPR), ctx, id)
+}
+
 // MergePR mocks base method.
-func (m *MockPRProvider) MergePR(ctx context.Context, id string) (*domain.PullRequest, error) {
+func (m *MockPRProvider) MergePR(ctx context.Context, id string) (*domain1.PullRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "MergePR", ctx, id)
-	ret0, _ := ret[0].(*domain.PullRequest)
+	ret0, _ := ret[0].(*domain1.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -173,11 +274,41 @@ func (mr *MockPRProviderMockRecorder) MergePR(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePR", reflect.TypeOf((*MockPRProvider)(nil).MergePR), ctx, id)
 }
 
+// RecentReviewers mocks base method.
+func (m *MockPRProvider) RecentReviewers(ctx context.Context, authorID, excludeID string, limit int) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentReviewers", ctx, authorID, excludeID, limit)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentReviewers indicates an expected call of RecentReviewers.
+func (mr *MockPRProviderMockRecorder) RecentReviewers(ctx, authorID, excludeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentReviewers", reflect.TypeOf((*MockPRProvider)(nil).RecentReviewers), ctx, authorID, excludeID, limit)
+}
+
+// ReopenPR mocks base method.
+func (m *MockPRProvider) ReopenPR(ctx context.Context, id string) (*domain1.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPR", ctx, id)
+	ret0, _ := ret[0].(*domain1.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenPR indicates an expected call of ReopenPR.
+func (mr *MockPRProviderMockRecorder) ReopenPR(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPR", reflect.TypeOf((*MockPRProvider)(nil).ReopenPR), ctx, id)
+}
+
 // UpdatePR mocks base method.
-func (m *MockPRProvider) UpdatePR(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+func (m *MockPRProvider) UpdatePR(ctx context.Context, pr *domain1.PullRequest) (*domain1.PullRequest, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdatePR", ctx, pr)
-	ret0, _ := ret[0].(*domain.PullRequest)
+	ret0, _ := ret[0].(*domain1.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -187,3 +318,134 @@ func (mr *MockPRProviderMockRecorder) UpdatePR(ctx, pr interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePR", reflect.TypeOf((*MockPRProvider)(nil).UpdatePR), ctx, pr)
 }
+
+// MockDecisionStore is a mock of DecisionStore interface.
+type MockDecisionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDecisionStoreMockRecorder
+}
+
+// MockDecisionStoreMockRecorder is the mock recorder for MockDecisionStore.
+type MockDecisionStoreMockRecorder struct {
+	mock *MockDecisionStore
+}
+
+// NewMockDecisionStore creates a new mock instance.
+func NewMockDecisionStore(ctrl *gomock.Controller) *MockDecisionStore {
+	mock := &MockDecisionStore{ctrl: ctrl}
+	mock.recorder = &MockDecisionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDecisionStore) EXPECT() *MockDecisionStoreMockRecorder {
+	return m.recorder
+}
+
+// ListDecisions mocks base method.
+func (m *MockDecisionStore) ListDecisions(ctx context.Context, prID string) ([]domain1.Decision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDecisions", ctx, prID)
+	ret0, _ := ret[0].([]domain1.Decision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDecisions indicates an expected call of ListDecisions.
+func (mr *MockDecisionStoreMockRecorder) ListDecisions(ctx, prID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDecisions", reflect.TypeOf((*MockDecisionStore)(nil).ListDecisions), ctx, prID)
+}
+
+// SaveDecision mocks base method.
+func (m *MockDecisionStore) SaveDecision(ctx context.Context, decision *domain1.Decision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDecision", ctx, decision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDecision indicates an expected call of SaveDecision.
+func (mr *MockDecisionStoreMockRecorder) SaveDecision(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDecision", reflect.TypeOf((*MockDecisionStore)(nil).SaveDecision), ctx, decision)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockEventWriter is a mock of EventWriter interface.
+type MockEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventWriterMockRecorder
+}
+
+// MockEventWriterMockRecorder is the mock recorder for MockEventWriter.
+type MockEventWriterMockRecorder struct {
+	mock *MockEventWriter
+}
+
+// NewMockEventWriter creates a new mock instance.
+func NewMockEventWriter(ctrl *gomock.Controller) *MockEventWriter {
+	mock := &MockEventWriter{ctrl: ctrl}
+	mock.recorder = &MockEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventWriter) EXPECT() *MockEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockEventWriter)(nil).Append), varargs...)
+}
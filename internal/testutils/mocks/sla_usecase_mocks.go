@@ -0,0 +1,277 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/sla/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	dto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	domain1 "github.com/silentmol/avito-backend-trainee/internal/sla/domain"
+	domain2 "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+)
+
+// MockAssignmentProvider is a mock of AssignmentProvider interface.
+type MockAssignmentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssignmentProviderMockRecorder
+}
+
+// MockAssignmentProviderMockRecorder is the mock recorder for MockAssignmentProvider.
+type MockAssignmentProviderMockRecorder struct {
+	mock *MockAssignmentProvider
+}
+
+// NewMockAssignmentProvider creates a new mock instance.
+func NewMockAssignmentProvider(ctrl *gomock.Controller) *MockAssignmentProvider {
+	mock := &MockAssignmentProvider{ctrl: ctrl}
+	mock.recorder = &MockAssignmentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssignmentProvider) EXPECT() *MockAssignmentProviderMockRecorder {
+	return m.recorder
+}
+
+// ListDue mocks base method.
+func (m *MockAssignmentProvider) ListDue(ctx context.Context, defaults domain2.ReviewSLA, escalationRetry time.Duration, now time.Time, limit int) ([]domain1.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, defaults, escalationRetry, now, limit)
+	ret0, _ := ret[0].([]domain1.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockAssignmentProviderMockRecorder) ListDue(ctx, defaults, escalationRetry, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockAssignmentProvider)(nil).ListDue), ctx, defaults, escalationRetry, now, limit)
+}
+
+// MarkEscalationAttempted mocks base method.
+func (m *MockAssignmentProvider) MarkEscalationAttempted(ctx context.Context, prID, reviewerID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEscalationAttempted", ctx, prID, reviewerID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEscalationAttempted indicates an expected call of MarkEscalationAttempted.
+func (mr *MockAssignmentProviderMockRecorder) MarkEscalationAttempted(ctx, prID, reviewerID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEscalationAttempted", reflect.TypeOf((*MockAssignmentProvider)(nil).MarkEscalationAttempted), ctx, prID, reviewerID, at)
+}
+
+// MarkReminded mocks base method.
+func (m *MockAssignmentProvider) MarkReminded(ctx context.Context, prID, reviewerID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminded", ctx, prID, reviewerID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReminded indicates an expected call of MarkReminded.
+func (mr *MockAssignmentProviderMockRecorder) MarkReminded(ctx, prID, reviewerID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminded", reflect.TypeOf((*MockAssignmentProvider)(nil).MarkReminded), ctx, prID, reviewerID, at)
+}
+
+// MockPRReader is a mock of PRReader interface.
+type MockPRReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRReaderMockRecorder
+}
+
+// MockPRReaderMockRecorder is the mock recorder for MockPRReader.
+type MockPRReaderMockRecorder struct {
+	mock *MockPRReader
+}
+
+// NewMockPRReader creates a new mock instance.
+func NewMockPRReader(ctrl *gomock.Controller) *MockPRReader {
+	mock := &MockPRReader{ctrl: ctrl}
+	mock.recorder = &MockPRReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRReader) EXPECT() *MockPRReaderMockRecorder {
+	return m.recorder
+}
+
+// GetPR mocks base method.
+func (m *MockPRReader) GetPR(ctx context.Context, id string) (*domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPR", ctx, id)
+	ret0, _ := ret[0].(*domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPR indicates an expected call of GetPR.
+func (mr *MockPRReaderMockRecorder) GetPR(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPR", reflect.TypeOf((*MockPRReader)(nil).GetPR), ctx, id)
+}
+
+// MockReassigner is a mock of Reassigner interface.
+type MockReassigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockReassignerMockRecorder
+}
+
+// MockReassignerMockRecorder is the mock recorder for MockReassigner.
+type MockReassignerMockRecorder struct {
+	mock *MockReassigner
+}
+
+// NewMockReassigner creates a new mock instance.
+func NewMockReassigner(ctrl *gomock.Controller) *MockReassigner {
+	mock := &MockReassigner{ctrl: ctrl}
+	mock.recorder = &MockReassignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReassigner) EXPECT() *MockReassignerMockRecorder {
+	return m.recorder
+}
+
+// ReassignPR mocks base method.
+func (m *MockReassigner) ReassignPR(ctx context.Context, request *dto.ReassignPRRequest) (*dto.ReassignPRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignPR", ctx, request)
+	ret0, _ := ret[0].(*dto.ReassignPRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignPR indicates an expected call of ReassignPR.
+func (mr *MockReassignerMockRecorder) ReassignPR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignPR", reflect.TypeOf((*MockReassigner)(nil).ReassignPR), ctx, request)
+}
+
+// MockSLAEventWriter is a mock of EventWriter interface.
+type MockSLAEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSLAEventWriterMockRecorder
+}
+
+// MockSLAEventWriterMockRecorder is the mock recorder for MockSLAEventWriter.
+type MockSLAEventWriterMockRecorder struct {
+	mock *MockSLAEventWriter
+}
+
+// NewMockSLAEventWriter creates a new mock instance.
+func NewMockSLAEventWriter(ctrl *gomock.Controller) *MockSLAEventWriter {
+	mock := &MockSLAEventWriter{ctrl: ctrl}
+	mock.recorder = &MockSLAEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSLAEventWriter) EXPECT() *MockSLAEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockSLAEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockSLAEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockSLAEventWriter)(nil).Append), varargs...)
+}
+
+// MockSLATransactor is a mock of Transactor interface.
+type MockSLATransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockSLATransactorMockRecorder
+}
+
+// MockSLATransactorMockRecorder is the mock recorder for MockSLATransactor.
+type MockSLATransactorMockRecorder struct {
+	mock *MockSLATransactor
+}
+
+// NewMockSLATransactor creates a new mock instance.
+func NewMockSLATransactor(ctrl *gomock.Controller) *MockSLATransactor {
+	mock := &MockSLATransactor{ctrl: ctrl}
+	mock.recorder = &MockSLATransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSLATransactor) EXPECT() *MockSLATransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockSLATransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockSLATransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockSLATransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockLocker is a mock of Locker interface.
+type MockLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerMockRecorder
+}
+
+// MockLockerMockRecorder is the mock recorder for MockLocker.
+type MockLockerMockRecorder struct {
+	mock *MockLocker
+}
+
+// NewMockLocker creates a new mock instance.
+func NewMockLocker(ctrl *gomock.Controller) *MockLocker {
+	mock := &MockLocker{ctrl: ctrl}
+	mock.recorder = &MockLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocker) EXPECT() *MockLockerMockRecorder {
+	return m.recorder
+}
+
+// TryRun mocks base method.
+func (m *MockLocker) TryRun(ctx context.Context, fn func(context.Context) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryRun", ctx, fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryRun indicates an expected call of TryRun.
+func (mr *MockLockerMockRecorder) TryRun(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRun", reflect.TypeOf((*MockLocker)(nil).TryRun), ctx, fn)
+}
@@ -0,0 +1,268 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/stale/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	domain1 "github.com/silentmol/avito-backend-trainee/internal/stale/domain"
+)
+
+// MockStalePRProvider is a mock of PRProvider interface.
+type MockStalePRProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockStalePRProviderMockRecorder
+}
+
+// MockStalePRProviderMockRecorder is the mock recorder for MockStalePRProvider.
+type MockStalePRProviderMockRecorder struct {
+	mock *MockStalePRProvider
+}
+
+// NewMockStalePRProvider creates a new mock instance.
+func NewMockStalePRProvider(ctrl *gomock.Controller) *MockStalePRProvider {
+	mock := &MockStalePRProvider{ctrl: ctrl}
+	mock.recorder = &MockStalePRProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStalePRProvider) EXPECT() *MockStalePRProviderMockRecorder {
+	return m.recorder
+}
+
+// ClosePR mocks base method.
+func (m *MockStalePRProvider) ClosePR(ctx context.Context, id string, at time.Time) (*domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePR", ctx, id, at)
+	ret0, _ := ret[0].(*domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePR indicates an expected call of ClosePR.
+func (mr *MockStalePRProviderMockRecorder) ClosePR(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePR", reflect.TypeOf((*MockStalePRProvider)(nil).ClosePR), ctx, id, at)
+}
+
+// ListOpenCreatedBefore mocks base method.
+func (m *MockStalePRProvider) ListOpenCreatedBefore(ctx context.Context, before time.Time) ([]domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenCreatedBefore", ctx, before)
+	ret0, _ := ret[0].([]domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenCreatedBefore indicates an expected call of ListOpenCreatedBefore.
+func (mr *MockStalePRProviderMockRecorder) ListOpenCreatedBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenCreatedBefore", reflect.TypeOf((*MockStalePRProvider)(nil).ListOpenCreatedBefore), ctx, before)
+}
+
+// ListStaleCandidates mocks base method.
+func (m *MockStalePRProvider) ListStaleCandidates(ctx context.Context, staleBefore, closeBefore, flaggedBefore time.Time, limit int) ([]domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStaleCandidates", ctx, staleBefore, closeBefore, flaggedBefore, limit)
+	ret0, _ := ret[0].([]domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStaleCandidates indicates an expected call of ListStaleCandidates.
+func (mr *MockStalePRProviderMockRecorder) ListStaleCandidates(ctx, staleBefore, closeBefore, flaggedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStaleCandidates", reflect.TypeOf((*MockStalePRProvider)(nil).ListStaleCandidates), ctx, staleBefore, closeBefore, flaggedBefore, limit)
+}
+
+// LockPR mocks base method.
+func (m *MockStalePRProvider) LockPR(ctx context.Context, id string) (*domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockPR", ctx, id)
+	ret0, _ := ret[0].(*domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockPR indicates an expected call of LockPR.
+func (mr *MockStalePRProviderMockRecorder) LockPR(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockPR", reflect.TypeOf((*MockStalePRProvider)(nil).LockPR), ctx, id)
+}
+
+// MarkStale mocks base method.
+func (m *MockStalePRProvider) MarkStale(ctx context.Context, id string, at time.Time) (*domain0.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkStale", ctx, id, at)
+	ret0, _ := ret[0].(*domain0.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkStale indicates an expected call of MarkStale.
+func (mr *MockStalePRProviderMockRecorder) MarkStale(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStale", reflect.TypeOf((*MockStalePRProvider)(nil).MarkStale), ctx, id, at)
+}
+
+// MockActionRecorder is a mock of ActionRecorder interface.
+type MockActionRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionRecorderMockRecorder
+}
+
+// MockActionRecorderMockRecorder is the mock recorder for MockActionRecorder.
+type MockActionRecorderMockRecorder struct {
+	mock *MockActionRecorder
+}
+
+// NewMockActionRecorder creates a new mock instance.
+func NewMockActionRecorder(ctrl *gomock.Controller) *MockActionRecorder {
+	mock := &MockActionRecorder{ctrl: ctrl}
+	mock.recorder = &MockActionRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionRecorder) EXPECT() *MockActionRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordAction mocks base method.
+func (m *MockActionRecorder) RecordAction(ctx context.Context, record *domain1.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAction", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAction indicates an expected call of RecordAction.
+func (mr *MockActionRecorderMockRecorder) RecordAction(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAction", reflect.TypeOf((*MockActionRecorder)(nil).RecordAction), ctx, record)
+}
+
+// MockStaleEventWriter is a mock of EventWriter interface.
+type MockStaleEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaleEventWriterMockRecorder
+}
+
+// MockStaleEventWriterMockRecorder is the mock recorder for MockStaleEventWriter.
+type MockStaleEventWriterMockRecorder struct {
+	mock *MockStaleEventWriter
+}
+
+// NewMockStaleEventWriter creates a new mock instance.
+func NewMockStaleEventWriter(ctrl *gomock.Controller) *MockStaleEventWriter {
+	mock := &MockStaleEventWriter{ctrl: ctrl}
+	mock.recorder = &MockStaleEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaleEventWriter) EXPECT() *MockStaleEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockStaleEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockStaleEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockStaleEventWriter)(nil).Append), varargs...)
+}
+
+// MockStaleTransactor is a mock of Transactor interface.
+type MockStaleTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaleTransactorMockRecorder
+}
+
+// MockStaleTransactorMockRecorder is the mock recorder for MockStaleTransactor.
+type MockStaleTransactorMockRecorder struct {
+	mock *MockStaleTransactor
+}
+
+// NewMockStaleTransactor creates a new mock instance.
+func NewMockStaleTransactor(ctrl *gomock.Controller) *MockStaleTransactor {
+	mock := &MockStaleTransactor{ctrl: ctrl}
+	mock.recorder = &MockStaleTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaleTransactor) EXPECT() *MockStaleTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockStaleTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockStaleTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockStaleTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockStaleLocker is a mock of Locker interface.
+type MockStaleLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockStaleLockerMockRecorder
+}
+
+// MockStaleLockerMockRecorder is the mock recorder for MockStaleLocker.
+type MockStaleLockerMockRecorder struct {
+	mock *MockStaleLocker
+}
+
+// NewMockStaleLocker creates a new mock instance.
+func NewMockStaleLocker(ctrl *gomock.Controller) *MockStaleLocker {
+	mock := &MockStaleLocker{ctrl: ctrl}
+	mock.recorder = &MockStaleLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStaleLocker) EXPECT() *MockStaleLockerMockRecorder {
+	return m.recorder
+}
+
+// TryRun mocks base method.
+func (m *MockStaleLocker) TryRun(ctx context.Context, fn func(context.Context) error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryRun", ctx, fn)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryRun indicates an expected call of TryRun.
+func (mr *MockStaleLockerMockRecorder) TryRun(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRun", reflect.TypeOf((*MockStaleLocker)(nil).TryRun), ctx, fn)
+}
@@ -9,7 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	domain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/team/domain"
 )
 
 // MockTeamProvider is a mock of TeamProvider interface.
@@ -36,10 +37,10 @@ func (m *MockTeamProvider) EXPECT() *MockTeamProviderMockRecorder {
 }
 
 // CreateTeam mocks base method.
-func (m *MockTeamProvider) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+func (m *MockTeamProvider) CreateTeam(ctx context.Context, team *domain0.Team) (*domain0.Team, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTeam", ctx, team)
-	ret0, _ := ret[0].(*domain.Team)
+	ret0, _ := ret[0].(*domain0.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,11 +51,41 @@ func (mr *MockTeamProviderMockRecorder) CreateTeam(ctx, team interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamProvider)(nil).CreateTeam), ctx, team)
 }
 
+// GetCodeOwners mocks base method.
+func (m *MockTeamProvider) GetCodeOwners(ctx context.Context, teamName string) (*domain0.CodeOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeOwners", ctx, teamName)
+	ret0, _ := ret[0].(*domain0.CodeOwners)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeOwners indicates an expected call of GetCodeOwners.
+func (mr *MockTeamProviderMockRecorder) GetCodeOwners(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwners", reflect.TypeOf((*MockTeamProvider)(nil).GetCodeOwners), ctx, teamName)
+}
+
+// GetPool mocks base method.
+func (m *MockTeamProvider) GetPool(ctx context.Context, name string) (*domain0.ReviewerPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPool", ctx, name)
+	ret0, _ := ret[0].(*domain0.ReviewerPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPool indicates an expected call of GetPool.
+func (mr *MockTeamProviderMockRecorder) GetPool(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockTeamProvider)(nil).GetPool), ctx, name)
+}
+
 // GetTeam mocks base method.
-func (m *MockTeamProvider) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+func (m *MockTeamProvider) GetTeam(ctx context.Context, teamName string) (*domain0.Team, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTeam", ctx, teamName)
-	ret0, _ := ret[0].(*domain.Team)
+	ret0, _ := ret[0].(*domain0.Team)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -64,3 +95,142 @@ func (mr *MockTeamProviderMockRecorder) GetTeam(ctx, teamName interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamProvider)(nil).GetTeam), ctx, teamName)
 }
+
+// SetCodeOwners mocks base method.
+func (m *MockTeamProvider) SetCodeOwners(ctx context.Context, owners *domain0.CodeOwners) (*domain0.CodeOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCodeOwners", ctx, owners)
+	ret0, _ := ret[0].(*domain0.CodeOwners)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCodeOwners indicates an expected call of SetCodeOwners.
+func (mr *MockTeamProviderMockRecorder) SetCodeOwners(ctx, owners interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCodeOwners", reflect.TypeOf((*MockTeamProvider)(nil).SetCodeOwners), ctx, owners)
+}
+
+// SetPool mocks base method.
+func (m *MockTeamProvider) SetPool(ctx context.Context, name string, userIDs []string) (*domain0.ReviewerPool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPool", ctx, name, userIDs)
+	ret0, _ := ret[0].(*domain0.ReviewerPool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPool indicates an expected call of SetPool.
+func (mr *MockTeamProviderMockRecorder) SetPool(ctx, name, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPool", reflect.TypeOf((*MockTeamProvider)(nil).SetPool), ctx, name, userIDs)
+}
+
+// SetReviewSLA mocks base method.
+func (m *MockTeamProvider) SetReviewSLA(ctx context.Context, sla *domain0.ReviewSLA) (*domain0.ReviewSLA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewSLA", ctx, sla)
+	ret0, _ := ret[0].(*domain0.ReviewSLA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewSLA indicates an expected call of SetReviewSLA.
+func (mr *MockTeamProviderMockRecorder) SetReviewSLA(ctx, sla interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewSLA", reflect.TypeOf((*MockTeamProvider)(nil).SetReviewSLA), ctx, sla)
+}
+
+// SetReviewerPolicy mocks base method.
+func (m *MockTeamProvider) SetReviewerPolicy(ctx context.Context, teamName string, policy *domain0.ReviewerPolicy) (*domain0.ReviewerPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReviewerPolicy", ctx, teamName, policy)
+	ret0, _ := ret[0].(*domain0.ReviewerPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReviewerPolicy indicates an expected call of SetReviewerPolicy.
+func (mr *MockTeamProviderMockRecorder) SetReviewerPolicy(ctx, teamName, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReviewerPolicy", reflect.TypeOf((*MockTeamProvider)(nil).SetReviewerPolicy), ctx, teamName, policy)
+}
+
+// MockTeamTransactor is a mock of Transactor interface.
+type MockTeamTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamTransactorMockRecorder
+}
+
+// MockTeamTransactorMockRecorder is the mock recorder for MockTeamTransactor.
+type MockTeamTransactorMockRecorder struct {
+	mock *MockTeamTransactor
+}
+
+// NewMockTeamTransactor creates a new mock instance.
+func NewMockTeamTransactor(ctrl *gomock.Controller) *MockTeamTransactor {
+	mock := &MockTeamTransactor{ctrl: ctrl}
+	mock.recorder = &MockTeamTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamTransactor) EXPECT() *MockTeamTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockTeamTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockTeamTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockTeamTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockTeamEventWriter is a mock of EventWriter interface.
+type MockTeamEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamEventWriterMockRecorder
+}
+
+// MockTeamEventWriterMockRecorder is the mock recorder for MockTeamEventWriter.
+type MockTeamEventWriterMockRecorder struct {
+	mock *MockTeamEventWriter
+}
+
+// NewMockTeamEventWriter creates a new mock instance.
+func NewMockTeamEventWriter(ctrl *gomock.Controller) *MockTeamEventWriter {
+	mock := &MockTeamEventWriter{ctrl: ctrl}
+	mock.recorder = &MockTeamEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamEventWriter) EXPECT() *MockTeamEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockTeamEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockTeamEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockTeamEventWriter)(nil).Append), varargs...)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/topup/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	dto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+// MockPRLister is a mock of PRLister interface.
+type MockPRLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockPRListerMockRecorder
+}
+
+// MockPRListerMockRecorder is the mock recorder for MockPRLister.
+type MockPRListerMockRecorder struct {
+	mock *MockPRLister
+}
+
+// NewMockPRLister creates a new mock instance.
+func NewMockPRLister(ctrl *gomock.Controller) *MockPRLister {
+	mock := &MockPRLister{ctrl: ctrl}
+	mock.recorder = &MockPRListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPRLister) EXPECT() *MockPRListerMockRecorder {
+	return m.recorder
+}
+
+// ListOpenByAuthorTeam mocks base method.
+func (m *MockPRLister) ListOpenByAuthorTeam(ctx context.Context, teamName string) ([]domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByAuthorTeam", ctx, teamName)
+	ret0, _ := ret[0].([]domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByAuthorTeam indicates an expected call of ListOpenByAuthorTeam.
+func (mr *MockPRListerMockRecorder) ListOpenByAuthorTeam(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByAuthorTeam", reflect.TypeOf((*MockPRLister)(nil).ListOpenByAuthorTeam), ctx, teamName)
+}
+
+// MockPoolTeamLister is a mock of PoolTeamLister interface.
+type MockPoolTeamLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockPoolTeamListerMockRecorder
+}
+
+// MockPoolTeamListerMockRecorder is the mock recorder for MockPoolTeamLister.
+type MockPoolTeamListerMockRecorder struct {
+	mock *MockPoolTeamLister
+}
+
+// NewMockPoolTeamLister creates a new mock instance.
+func NewMockPoolTeamLister(ctrl *gomock.Controller) *MockPoolTeamLister {
+	mock := &MockPoolTeamLister{ctrl: ctrl}
+	mock.recorder = &MockPoolTeamListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPoolTeamLister) EXPECT() *MockPoolTeamListerMockRecorder {
+	return m.recorder
+}
+
+// ListPoolTeams mocks base method.
+func (m *MockPoolTeamLister) ListPoolTeams(ctx context.Context, poolName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPoolTeams", ctx, poolName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPoolTeams indicates an expected call of ListPoolTeams.
+func (mr *MockPoolTeamListerMockRecorder) ListPoolTeams(ctx, poolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPoolTeams", reflect.TypeOf((*MockPoolTeamLister)(nil).ListPoolTeams), ctx, poolName)
+}
+
+// MockFiller is a mock of Filler interface.
+type MockFiller struct {
+	ctrl     *gomock.Controller
+	recorder *MockFillerMockRecorder
+}
+
+// MockFillerMockRecorder is the mock recorder for MockFiller.
+type MockFillerMockRecorder struct {
+	mock *MockFiller
+}
+
+// NewMockFiller creates a new mock instance.
+func NewMockFiller(ctrl *gomock.Controller) *MockFiller {
+	mock := &MockFiller{ctrl: ctrl}
+	mock.recorder = &MockFillerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFiller) EXPECT() *MockFillerMockRecorder {
+	return m.recorder
+}
+
+// TopUpPR mocks base method.
+func (m *MockFiller) TopUpPR(ctx context.Context, request *dto.TopUpPRRequest) (*dto.TopUpPRResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopUpPR", ctx, request)
+	ret0, _ := ret[0].(*dto.TopUpPRResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopUpPR indicates an expected call of TopUpPR.
+func (mr *MockFillerMockRecorder) TopUpPR(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopUpPR", reflect.TypeOf((*MockFiller)(nil).TopUpPR), ctx, request)
+}
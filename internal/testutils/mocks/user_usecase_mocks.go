@@ -9,7 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	domain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	domain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	domain0 "github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
 // MockUserProvider is a mock of UserProvider interface.
@@ -36,10 +37,10 @@ func (m *MockUserProvider) EXPECT() *MockUserProviderMockRecorder {
 }
 
 // GetUser mocks base method.
-func (m *MockUserProvider) GetUser(ctx context.Context, id string) (*domain.User, error) {
+func (m *MockUserProvider) GetUser(ctx context.Context, id string) (*domain0.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUser", ctx, id)
-	ret0, _ := ret[0].(*domain.User)
+	ret0, _ := ret[0].(*domain0.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,11 +51,41 @@ func (mr *MockUserProviderMockRecorder) GetUser(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserProvider)(nil).GetUser), ctx, id)
 }
 
+// SetChatHandle mocks base method.
+func (m *MockUserProvider) SetChatHandle(ctx context.Context, id, chatHandle string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatHandle", ctx, id, chatHandle)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChatHandle indicates an expected call of SetChatHandle.
+func (mr *MockUserProviderMockRecorder) SetChatHandle(ctx, id, chatHandle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatHandle", reflect.TypeOf((*MockUserProvider)(nil).SetChatHandle), ctx, id, chatHandle)
+}
+
+// SetEmailSettings mocks base method.
+func (m *MockUserProvider) SetEmailSettings(ctx context.Context, id, email string, optOut, digest bool) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailSettings", ctx, id, email, optOut, digest)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEmailSettings indicates an expected call of SetEmailSettings.
+func (mr *MockUserProviderMockRecorder) SetEmailSettings(ctx, id, email, optOut, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailSettings", reflect.TypeOf((*MockUserProvider)(nil).SetEmailSettings), ctx, id, email, optOut, digest)
+}
+
 // SetIsActive mocks base method.
-func (m *MockUserProvider) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
+func (m *MockUserProvider) SetIsActive(ctx context.Context, id string, isActive bool) (*domain0.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetIsActive", ctx, id, isActive)
-	ret0, _ := ret[0].(*domain.User)
+	ret0, _ := ret[0].(*domain0.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -64,3 +95,127 @@ func (mr *MockUserProviderMockRecorder) SetIsActive(ctx, id, isActive interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsActive", reflect.TypeOf((*MockUserProvider)(nil).SetIsActive), ctx, id, isActive)
 }
+
+// SetLevel mocks base method.
+func (m *MockUserProvider) SetLevel(ctx context.Context, id string, level domain0.Level) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevel", ctx, id, level)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MockUserProviderMockRecorder) SetLevel(ctx, id, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MockUserProvider)(nil).SetLevel), ctx, id, level)
+}
+
+// SetTags mocks base method.
+func (m *MockUserProvider) SetTags(ctx context.Context, id string, tags []string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, id, tags)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockUserProviderMockRecorder) SetTags(ctx, id, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockUserProvider)(nil).SetTags), ctx, id, tags)
+}
+
+// SetWorkingHours mocks base method.
+func (m *MockUserProvider) SetWorkingHours(ctx context.Context, id string, hours *domain0.WorkingHours) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkingHours", ctx, id, hours)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWorkingHours indicates an expected call of SetWorkingHours.
+func (mr *MockUserProviderMockRecorder) SetWorkingHours(ctx, id, hours interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkingHours", reflect.TypeOf((*MockUserProvider)(nil).SetWorkingHours), ctx, id, hours)
+}
+
+// MockUserTransactor is a mock of Transactor interface.
+type MockUserTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTransactorMockRecorder
+}
+
+// MockUserTransactorMockRecorder is the mock recorder for MockUserTransactor.
+type MockUserTransactorMockRecorder struct {
+	mock *MockUserTransactor
+}
+
+// NewMockUserTransactor creates a new mock instance.
+func NewMockUserTransactor(ctrl *gomock.Controller) *MockUserTransactor {
+	mock := &MockUserTransactor{ctrl: ctrl}
+	mock.recorder = &MockUserTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserTransactor) EXPECT() *MockUserTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockUserTransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockUserTransactorMockRecorder) WithinTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockUserTransactor)(nil).WithinTx), ctx, fn)
+}
+
+// MockUserEventWriter is a mock of EventWriter interface.
+type MockUserEventWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserEventWriterMockRecorder
+}
+
+// MockUserEventWriterMockRecorder is the mock recorder for MockUserEventWriter.
+type MockUserEventWriterMockRecorder struct {
+	mock *MockUserEventWriter
+}
+
+// NewMockUserEventWriter creates a new mock instance.
+func NewMockUserEventWriter(ctrl *gomock.Controller) *MockUserEventWriter {
+	mock := &MockUserEventWriter{ctrl: ctrl}
+	mock.recorder = &MockUserEventWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserEventWriter) EXPECT() *MockUserEventWriterMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockUserEventWriter) Append(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockUserEventWriterMockRecorder) Append(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockUserEventWriter)(nil).Append), varargs...)
+}
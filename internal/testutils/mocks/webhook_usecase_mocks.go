@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/webhook/usecase/usecase.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+)
+
+// MockWebhookProvider is a mock of WebhookProvider interface.
+type MockWebhookProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookProviderMockRecorder
+}
+
+// MockWebhookProviderMockRecorder is the mock recorder for MockWebhookProvider.
+type MockWebhookProviderMockRecorder struct {
+	mock *MockWebhookProvider
+}
+
+// NewMockWebhookProvider creates a new mock instance.
+func NewMockWebhookProvider(ctrl *gomock.Controller) *MockWebhookProvider {
+	mock := &MockWebhookProvider{ctrl: ctrl}
+	mock.recorder = &MockWebhookProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookProvider) EXPECT() *MockWebhookProviderMockRecorder {
+	return m.recorder
+}
+
+// AppendDelivery mocks base method.
+func (m *MockWebhookProvider) AppendDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendDelivery indicates an expected call of AppendDelivery.
+func (mr *MockWebhookProviderMockRecorder) AppendDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendDelivery", reflect.TypeOf((*MockWebhookProvider)(nil).AppendDelivery), ctx, delivery)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookProvider) CreateSubscription(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(*domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookProviderMockRecorder) CreateSubscription(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookProvider)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookProvider) DeleteSubscription(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookProviderMockRecorder) DeleteSubscription(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookProvider)(nil).DeleteSubscription), ctx, id)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookProvider) GetSubscription(ctx context.Context, id int64) (*domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, id)
+	ret0, _ := ret[0].(*domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookProviderMockRecorder) GetSubscription(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookProvider)(nil).GetSubscription), ctx, id)
+}
+
+// HasSuccessfulDelivery mocks base method.
+func (m *MockWebhookProvider) HasSuccessfulDelivery(ctx context.Context, subscriptionID, eventID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSuccessfulDelivery", ctx, subscriptionID, eventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSuccessfulDelivery indicates an expected call of HasSuccessfulDelivery.
+func (mr *MockWebhookProviderMockRecorder) HasSuccessfulDelivery(ctx, subscriptionID, eventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSuccessfulDelivery", reflect.TypeOf((*MockWebhookProvider)(nil).HasSuccessfulDelivery), ctx, subscriptionID, eventID)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookProvider) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, subscriptionID, limit)
+	ret0, _ := ret[0].([]domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookProviderMockRecorder) ListDeliveries(ctx, subscriptionID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookProvider)(nil).ListDeliveries), ctx, subscriptionID, limit)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockWebhookProvider) ListSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx)
+	ret0, _ := ret[0].([]domain.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockWebhookProviderMockRecorder) ListSubscriptions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockWebhookProvider)(nil).ListSubscriptions), ctx)
+}
+
+// MockSender is a mock of Sender interface.
+type MockSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockSenderMockRecorder
+}
+
+// MockSenderMockRecorder is the mock recorder for MockSender.
+type MockSenderMockRecorder struct {
+	mock *MockSender
+}
+
+// NewMockSender creates a new mock instance.
+func NewMockSender(ctrl *gomock.Controller) *MockSender {
+	mock := &MockSender{ctrl: ctrl}
+	mock.recorder = &MockSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSender) EXPECT() *MockSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, url, headers, body)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockSenderMockRecorder) Send(ctx, url, headers, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSender)(nil).Send), ctx, url, headers, body)
+}
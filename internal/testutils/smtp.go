@@ -0,0 +1,141 @@
+package testutils
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// SMTPMessage - письмо, принятое тестовым SMTP-сервером.
+type SMTPMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// SMTPServer - минимальный SMTP-сервер для тестов: принимает любые письма
+// без шифрования и аутентификации и сохраняет их в памяти.
+type SMTPServer struct {
+	listener net.Listener
+
+	mu         sync.Mutex
+	messages   []SMTPMessage
+	rejectRcpt bool
+}
+
+func NewSMTPServer(t *testing.T) *SMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("smtp test server: %v", err)
+	}
+
+	s := &SMTPServer{listener: listener}
+	go s.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+func (s *SMTPServer) Host() string {
+	host, _, _ := net.SplitHostPort(s.listener.Addr().String())
+	return host
+}
+
+func (s *SMTPServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// RejectRecipients включает ответ 550 на RCPT TO.
+func (s *SMTPServer) RejectRecipients(reject bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejectRcpt = reject
+}
+
+func (s *SMTPServer) Messages() []SMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SMTPMessage(nil), s.messages...)
+}
+
+func (s *SMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *SMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	reply := func(format string, args ...any) bool {
+		return tp.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 localhost test smtp") {
+		return
+	}
+
+	var msg SMTPMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg = SMTPMessage{From: trimAddress(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.mu.Lock()
+			reject := s.rejectRcpt
+			s.mu.Unlock()
+			if reject {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			msg.To = append(msg.To, trimAddress(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case cmd == "RSET", cmd == "NOOP":
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func trimAddress(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	return strings.Trim(s, "<>")
+}
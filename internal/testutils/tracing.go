@@ -0,0 +1,62 @@
+package testutils
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	spanExporterOnce sync.Once
+	spanExporter     *tracetest.InMemoryExporter
+)
+
+// Spans - спаны, записанные в памяти в рамках одной трассы теста.
+type Spans struct {
+	traceID trace.TraceID
+}
+
+// StartTrace включает глобальный провайдер с in-memory экспортёром (один на процесс)
+// и открывает корневой спан теста. Спаны параллельных тестов различаются по трассе.
+func StartTrace(t *testing.T) (context.Context, *Spans) {
+	t.Helper()
+
+	spanExporterOnce.Do(func() {
+		spanExporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(tracing.NewProvider(tracing.Config{ServiceName: "test"},
+			sdktrace.WithSyncer(spanExporter)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	ctx, root := tracing.Start(context.Background(), t.Name())
+	t.Cleanup(func() { root.End() })
+
+	return ctx, &Spans{traceID: root.SpanContext().TraceID()}
+}
+
+// Ended возвращает завершённые спаны трассы теста в порядке завершения.
+func (s *Spans) Ended() tracetest.SpanStubs {
+	var spans tracetest.SpanStubs
+	for _, span := range spanExporter.GetSpans() {
+		if span.SpanContext.TraceID() == s.traceID {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Names - имена завершённых спанов трассы теста.
+func (s *Spans) Names() []string {
+	var names []string
+	for _, span := range s.Ended() {
+		names = append(names, span.Name)
+	}
+	return names
+}
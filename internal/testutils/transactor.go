@@ -0,0 +1,10 @@
+package testutils
+
+import "context"
+
+// InlineTx - реализация Transactor для unit-тестов: просто вызывает fn без транзакции.
+type InlineTx struct{}
+
+func (InlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
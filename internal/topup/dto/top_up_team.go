@@ -0,0 +1,19 @@
+package dto
+
+type TopUpTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required"`
+}
+
+type TopUpPullRequest struct {
+	ID    string   `json:"pull_request_id"`
+	Added []string `json:"added"`
+}
+
+// TopUpTeamResponse - Checked - сколько открытых PR команды просмотрено, NoCandidate - PR,
+// для свободных мест которых не нашлось кандидатов.
+type TopUpTeamResponse struct {
+	TeamName     string             `json:"team_name"`
+	Checked      int                `json:"checked"`
+	PullRequests []TopUpPullRequest `json:"pull_requests"`
+	NoCandidate  []string           `json:"no_candidate"`
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/topup/dto"
+)
+
+// Name и Handle реализуют синк outbox: когда в команде или пуле появляются доступные
+// ревьюверы (участник снова активен, вернулся из отпуска, изменился состав команды или
+// пула), свободные места открытых PR затронутых команд занимаются заново. Повтор после
+// ошибки безопасен - уже занятые места повторно не заполняются.
+func (u *TopUpUsecase) Name() string {
+	return "topup"
+}
+
+func (u *TopUpUsecase) Handle(ctx context.Context, event outboxdomain.Event) error {
+	teams, err := u.triggeredTeams(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, teamName := range teams {
+		if _, err := u.TopUpTeam(ctx, &dto.TopUpTeamRequest{TeamName: teamName}); err != nil {
+			errs = append(errs, fmt.Errorf("top up team %s: %w", teamName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// triggeredTeams возвращает команды, у которых могли появиться кандидаты в ревьюверы;
+// пустой список - событие на это не влияет.
+func (u *TopUpUsecase) triggeredTeams(ctx context.Context, event outboxdomain.Event) ([]string, error) {
+	switch event.Type {
+	case outboxdomain.EventUserActivityChanged:
+		var payload outboxdomain.UserActivityPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		if !payload.User.IsActive {
+			return nil, nil
+		}
+		return teamOf(payload.User.TeamName), nil
+
+	case outboxdomain.EventUserOOOEnded:
+		var payload outboxdomain.UserOOOEndedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return teamOf(payload.User.TeamName), nil
+
+	case outboxdomain.EventTeamMembersChanged:
+		var payload outboxdomain.TeamMembersPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		return teamOf(payload.Team.Name), nil
+
+	case outboxdomain.EventPoolMembersChanged:
+		var payload outboxdomain.PoolMembersPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", event.Type, err)
+		}
+		// команды читаются при доставке: политика могла измениться после записи события
+		teams, err := u.teamLister.ListPoolTeams(ctx, payload.Pool.Name)
+		if err != nil {
+			return nil, fmt.Errorf("list pool teams in provider: %w", err)
+		}
+		return teams, nil
+	}
+
+	return nil, nil
+}
+
+func teamOf(teamName string) []string {
+	if teamName == "" {
+		return nil
+	}
+	return []string{teamName}
+}
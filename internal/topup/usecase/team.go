@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	"github.com/silentmol/avito-backend-trainee/internal/topup/dto"
+)
+
+// TopUpTeam доназначает ревьюверов на свободные места всех открытых PR авторов команды.
+// Каждый PR обрабатывается отдельно; PR без кандидатов и PR, которые успели смержить
+// или закрыть, пропускаются, остальные ошибки собираются и возвращаются вместе с итогом.
+func (u *TopUpUsecase) TopUpTeam(ctx context.Context, request *dto.TopUpTeamRequest) (*dto.TopUpTeamResponse, error) {
+	prs, err := u.prLister.ListOpenByAuthorTeam(ctx, request.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("list open pull requests in provider: %w", err)
+	}
+
+	response := &dto.TopUpTeamResponse{
+		TeamName:     request.TeamName,
+		Checked:      len(prs),
+		PullRequests: make([]dto.TopUpPullRequest, 0),
+		NoCandidate:  make([]string, 0),
+	}
+
+	var errs []error
+	for _, pr := range prs {
+		result, err := u.filler.TopUpPR(ctx, &prdto.TopUpPRRequest{PrID: pr.ID})
+		if err != nil {
+			switch {
+			case errors.Is(err, apperr.ErrNoCandidate):
+				response.NoCandidate = append(response.NoCandidate, pr.ID)
+			case errors.Is(err, apperr.ErrPRMerged), errors.Is(err, apperr.ErrPRClosed),
+				errors.Is(err, apperr.ErrNotFound):
+				// PR успели смержить, закрыть или удалить после чтения списка
+			default:
+				errs = append(errs, fmt.Errorf("top up pull request %s: %w", pr.ID, err))
+			}
+			continue
+		}
+
+		if len(result.Added) > 0 {
+			response.PullRequests = append(response.PullRequests, dto.TopUpPullRequest{
+				ID:    pr.ID,
+				Added: result.Added,
+			})
+		}
+	}
+
+	slog.Info("TopUpUsecase.TopUpTeam: team pull requests topped up",
+		slog.String("team_name", request.TeamName),
+		slog.Int("checked", response.Checked),
+		slog.Int("topped_up", len(response.PullRequests)),
+		slog.Int("no_candidate", len(response.NoCandidate)),
+		slog.Int("failed", len(errs)),
+	)
+
+	return response, errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+)
+
+type PRLister interface {
+	// ListOpenByAuthorTeam возвращает открытые PR, авторы которых состоят в команде teamName.
+	ListOpenByAuthorTeam(ctx context.Context, teamName string) ([]prdomain.PullRequest, error)
+}
+
+type PoolTeamLister interface {
+	// ListPoolTeams возвращает команды, политика которых берёт ревьюверов из пула poolName.
+	ListPoolTeams(ctx context.Context, poolName string) ([]string, error)
+}
+
+type Filler interface {
+	TopUpPR(ctx context.Context, request *prdto.TopUpPRRequest) (*prdto.TopUpPRResponse, error)
+}
+
+// TopUpUsecase доназначает ревьюверов на свободные места открытых PR команды, когда в ней
+// появляются доступные участники, и по запросу администратора.
+type TopUpUsecase struct {
+	prLister   PRLister
+	teamLister PoolTeamLister
+	filler     Filler
+}
+
+func NewTopUpUsecase(prLister PRLister, teamLister PoolTeamLister, filler Filler) *TopUpUsecase {
+	return &TopUpUsecase{
+		prLister:   prLister,
+		teamLister: teamLister,
+		filler:     filler,
+	}
+}
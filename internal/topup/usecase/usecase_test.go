@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	prdomain "github.com/silentmol/avito-backend-trainee/internal/pr/domain"
+	prdto "github.com/silentmol/avito-backend-trainee/internal/pr/dto"
+	teamdomain "github.com/silentmol/avito-backend-trainee/internal/team/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/silentmol/avito-backend-trainee/internal/topup/dto"
+	userdomain "github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopUpUsecase_TopUpTeam(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	prLister := mocks.NewMockPRLister(ctrl)
+	filler := mocks.NewMockFiller(ctrl)
+
+	prLister.EXPECT().ListOpenByAuthorTeam(gomock.Any(), "backend").Return([]prdomain.PullRequest{
+		{ID: "pr-1"}, {ID: "pr-2"}, {ID: "pr-3"}, {ID: "pr-4"}, {ID: "pr-5"},
+	}, nil)
+
+	filler.EXPECT().TopUpPR(gomock.Any(), &prdto.TopUpPRRequest{PrID: "pr-1"}).
+		Return(&prdto.TopUpPRResponse{Added: []string{"u3"}}, nil)
+	filler.EXPECT().TopUpPR(gomock.Any(), &prdto.TopUpPRRequest{PrID: "pr-2"}).
+		Return(&prdto.TopUpPRResponse{Added: []string{}}, nil)
+	filler.EXPECT().TopUpPR(gomock.Any(), &prdto.TopUpPRRequest{PrID: "pr-3"}).
+		Return(nil, apperr.ErrNoCandidate)
+	filler.EXPECT().TopUpPR(gomock.Any(), &prdto.TopUpPRRequest{PrID: "pr-4"}).
+		Return(nil, apperr.ErrPRMerged)
+	filler.EXPECT().TopUpPR(gomock.Any(), &prdto.TopUpPRRequest{PrID: "pr-5"}).
+		Return(nil, errors.New("db down"))
+
+	uc := NewTopUpUsecase(prLister, nil, filler)
+
+	resp, err := uc.TopUpTeam(context.Background(), &dto.TopUpTeamRequest{TeamName: "backend"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pr-5")
+	assert.Equal(t, 5, resp.Checked)
+	assert.Equal(t, []dto.TopUpPullRequest{{ID: "pr-1", Added: []string{"u3"}}}, resp.PullRequests)
+	assert.Equal(t, []string{"pr-3"}, resp.NoCandidate)
+}
+
+func TestTopUpUsecase_Handle(t *testing.T) {
+	t.Parallel()
+
+	activated, err := outboxdomain.NewEvent(outboxdomain.EventUserActivityChanged, "u2",
+		outboxdomain.UserActivityPayload{User: userdomain.User{ID: "u2", TeamName: "backend", IsActive: true}})
+	require.NoError(t, err)
+
+	deactivated, err := outboxdomain.NewEvent(outboxdomain.EventUserActivityChanged, "u2",
+		outboxdomain.UserActivityPayload{User: userdomain.User{ID: "u2", TeamName: "backend", IsActive: false}})
+	require.NoError(t, err)
+
+	membersChanged, err := outboxdomain.NewEvent(outboxdomain.EventTeamMembersChanged, "payments",
+		outboxdomain.TeamMembersPayload{Team: teamdomain.Team{Name: "payments"}})
+	require.NoError(t, err)
+
+	poolChanged, err := outboxdomain.NewEvent(outboxdomain.EventPoolMembersChanged, "security",
+		outboxdomain.PoolMembersPayload{Pool: teamdomain.ReviewerPool{Name: "security"}})
+	require.NoError(t, err)
+
+	oooEnded, err := outboxdomain.NewEvent(outboxdomain.EventUserOOOEnded, "u2",
+		outboxdomain.UserOOOEndedPayload{User: userdomain.User{ID: "u2", TeamName: "backend", IsActive: true}, PeriodID: 1})
+	require.NoError(t, err)
+
+	merged, err := outboxdomain.NewEvent(outboxdomain.EventPRMerged, "pr-1",
+		outboxdomain.PRPayload{PullRequest: prdomain.PullRequest{ID: "pr-1"}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		event     outboxdomain.Event
+		poolTeams []string
+		wantTeams []string
+	}{
+		{name: "user activated", event: activated, wantTeams: []string{"backend"}},
+		{name: "user deactivated", event: deactivated},
+		{name: "team members changed", event: membersChanged, wantTeams: []string{"payments"}},
+		{name: "pool members changed", event: poolChanged, poolTeams: []string{"backend", "payments"},
+			wantTeams: []string{"backend", "payments"}},
+		{name: "pool not used by any policy", event: poolChanged, poolTeams: []string{}},
+		{name: "out-of-office ended", event: oooEnded, wantTeams: []string{"backend"}},
+		{name: "unrelated event", event: merged},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			prLister := mocks.NewMockPRLister(ctrl)
+			teamLister := mocks.NewMockPoolTeamLister(ctrl)
+			filler := mocks.NewMockFiller(ctrl)
+
+			if tt.poolTeams != nil {
+				teamLister.EXPECT().ListPoolTeams(gomock.Any(), "security").Return(tt.poolTeams, nil)
+			}
+			for _, team := range tt.wantTeams {
+				prLister.EXPECT().ListOpenByAuthorTeam(gomock.Any(), team).Return(nil, nil)
+			}
+
+			uc := NewTopUpUsecase(prLister, teamLister, filler)
+			assert.NoError(t, uc.Handle(context.Background(), tt.event))
+		})
+	}
+}
+
+func TestTopUpUsecase_Handle_PoolTeamFailureDoesNotStopOthers(t *testing.T) {
+	t.Parallel()
+
+	poolChanged, err := outboxdomain.NewEvent(outboxdomain.EventPoolMembersChanged, "security",
+		outboxdomain.PoolMembersPayload{Pool: teamdomain.ReviewerPool{Name: "security"}})
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	prLister := mocks.NewMockPRLister(ctrl)
+	teamLister := mocks.NewMockPoolTeamLister(ctrl)
+	filler := mocks.NewMockFiller(ctrl)
+
+	teamLister.EXPECT().ListPoolTeams(gomock.Any(), "security").Return([]string{"backend", "payments"}, nil)
+	prLister.EXPECT().ListOpenByAuthorTeam(gomock.Any(), "backend").Return(nil, errors.New("db down"))
+	prLister.EXPECT().ListOpenByAuthorTeam(gomock.Any(), "payments").Return(nil, nil)
+
+	uc := NewTopUpUsecase(prLister, teamLister, filler)
+	err = uc.Handle(context.Background(), poolChanged)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backend")
+}
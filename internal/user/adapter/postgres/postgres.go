@@ -8,9 +8,16 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
 	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
+// userColumns - колонки пользователя в порядке, который ожидает scanUser.
+const userColumns = `id, name, team_name, is_active, COALESCE(chat_handle, ''),
+	COALESCE(email, ''), email_opt_out, email_digest,
+	COALESCE(timezone, ''), COALESCE(to_char(work_start, 'HH24:MI'), ''), COALESCE(to_char(work_end, 'HH24:MI'), ''),
+	tags, COALESCE(level, '')`
+
 type UserRepository struct {
 	conn *pgxpool.Pool
 }
@@ -19,6 +26,35 @@ func NewUserRepository(conn *pgxpool.Pool) *UserRepository {
 	return &UserRepository{conn: conn}
 }
 
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var user domain.User
+	var hours domain.WorkingHours
+
+	if err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.TeamName,
+		&user.IsActive,
+		&user.ChatHandle,
+		&user.Email,
+		&user.EmailOptOut,
+		&user.EmailDigest,
+		&hours.Timezone,
+		&hours.Start,
+		&hours.End,
+		&user.Tags,
+		&user.Level,
+	); err != nil {
+		return nil, err
+	}
+
+	if hours.Timezone != "" {
+		user.WorkingHours = &hours
+	}
+
+	return &user, nil
+}
+
 func (u *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `
 	INSERT INTO users (id, name, team_name, is_active)
@@ -27,7 +63,7 @@ func (u *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*do
 	`
 
 	var createdUser domain.User
-	err := u.conn.QueryRow(ctx, query,
+	err := storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query,
 		user.ID,
 		user.Name,
 		user.TeamName,
@@ -46,19 +82,12 @@ func (u *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*do
 }
 
 func (u *UserRepository) GetUser(ctx context.Context, id string) (*domain.User, error) {
-	var user domain.User
-
 	query := `
-		SELECT id, name, team_name, is_active 
-		FROM users 
+		SELECT ` + userColumns + `
+		FROM users
 		WHERE id=$1
 	`
-	err := u.conn.QueryRow(ctx, query, id).Scan(
-		&user.ID,
-		&user.Name,
-		&user.TeamName,
-		&user.IsActive,
-	)
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, id))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -66,25 +95,17 @@ func (u *UserRepository) GetUser(ctx context.Context, id string) (*domain.User,
 		}
 		return nil, fmt.Errorf("db: failed to get user: %w", err)
 	}
-	return &user, nil
+	return user, nil
 }
 
 func (u *UserRepository) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
-	var user domain.User
-
 	query := `
 		UPDATE users
 		SET is_active = $1
 		WHERE id = $2
-		RETURNING id, name, team_name, is_active
-	`
+		RETURNING ` + userColumns
 
-	err := u.conn.QueryRow(ctx, query, isActive, id).Scan(
-		&user.ID,
-		&user.Name,
-		&user.TeamName,
-		&user.IsActive,
-	)
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, isActive, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrNotFound
@@ -92,5 +113,111 @@ func (u *UserRepository) SetIsActive(ctx context.Context, id string, isActive bo
 		return nil, fmt.Errorf("db: failed to update is_active: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
+}
+
+func (u *UserRepository) SetChatHandle(ctx context.Context, id, chatHandle string) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET chat_handle = NULLIF($1, '')
+		WHERE id = $2
+		RETURNING ` + userColumns
+
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, chatHandle, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to update chat_handle: %w", err)
+	}
+
+	return user, nil
+}
+
+func (u *UserRepository) SetEmailSettings(ctx context.Context, id, email string,
+	optOut, digest bool) (*domain.User, error) {
+
+	query := `
+		UPDATE users
+		SET email = NULLIF($1, ''),
+		    email_opt_out = $2,
+		    email_digest = $3
+		WHERE id = $4
+		RETURNING ` + userColumns
+
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, email, optOut, digest, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to update email settings: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetWorkingHours задаёт рабочее окно пользователя; nil очищает его.
+func (u *UserRepository) SetWorkingHours(ctx context.Context, id string,
+	hours *domain.WorkingHours) (*domain.User, error) {
+
+	var timezone, start, end *string
+	if hours != nil {
+		timezone, start, end = &hours.Timezone, &hours.Start, &hours.End
+	}
+
+	query := `
+		UPDATE users
+		SET timezone = $1,
+		    work_start = $2::time,
+		    work_end = $3::time
+		WHERE id = $4
+		RETURNING ` + userColumns
+
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, timezone, start, end, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to update working hours: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetTags заменяет теги пользователя; пустой список очищает их.
+func (u *UserRepository) SetTags(ctx context.Context, id string, tags []string) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET tags = $1
+		WHERE id = $2
+		RETURNING ` + userColumns
+
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, tags, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to update tags: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetLevel задаёт грейд пользователя; пустой уровень очищает его.
+func (u *UserRepository) SetLevel(ctx context.Context, id string, level domain.Level) (*domain.User, error) {
+	query := `
+		UPDATE users
+		SET level = NULLIF($1, '')
+		WHERE id = $2
+		RETURNING ` + userColumns
+
+	user, err := scanUser(storage.QuerierFrom(ctx, u.conn).QueryRow(ctx, query, string(level), id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to update level: %w", err)
+	}
+
+	return user, nil
 }
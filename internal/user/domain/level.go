@@ -0,0 +1,29 @@
+package domain
+
+// Level - грейд пользователя; по нему политика команды требует опытных ревьюверов.
+type Level string
+
+const (
+	LevelJunior Level = "junior"
+	LevelMiddle Level = "middle"
+	LevelSenior Level = "senior"
+	LevelLead   Level = "lead"
+)
+
+var levelRanks = map[Level]int{
+	LevelJunior: 1,
+	LevelMiddle: 2,
+	LevelSenior: 3,
+	LevelLead:   4,
+}
+
+func (l Level) Valid() bool {
+	_, ok := levelRanks[l]
+	return ok
+}
+
+// AtLeast - уровень не ниже min. Незаданный уровень не удовлетворяет ни одному требованию.
+func (l Level) AtLeast(min Level) bool {
+	rank, ok := levelRanks[l]
+	return ok && rank >= levelRanks[min]
+}
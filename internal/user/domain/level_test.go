@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevel_AtLeast(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		level Level
+		min   Level
+		want  bool
+	}{
+		{name: "same", level: LevelSenior, min: LevelSenior, want: true},
+		{name: "higher", level: LevelLead, min: LevelSenior, want: true},
+		{name: "lower", level: LevelMiddle, min: LevelSenior, want: false},
+		{name: "unset", level: "", min: LevelJunior, want: false},
+		{name: "unknown", level: "principal", min: LevelJunior, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.level.AtLeast(tt.min))
+		})
+	}
+}
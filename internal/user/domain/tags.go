@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+// MaxTags - сколько тегов можно задать пользователю или PR.
+const MaxTags = 32
+
+// tagPattern - тег в нижнем регистре: буквы, цифры и "+#._-", например "go", "c++", "k8s".
+var tagPattern = regexp.MustCompile(`^[\p{Ll}\p{Nd}][\p{Ll}\p{Nd}+#._-]{0,31}$`)
+
+// NormalizeTags приводит теги к нижнему регистру, убирает пробелы по краям и повторы
+// и сортирует их. Пустой список - допустимое значение: теги не заданы.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("%w: %q must be 1-32 letters, digits or \"+#._-\"", apperr.ErrInvalidTags, tag)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("%w: at most %d tags allowed", apperr.ErrInvalidTags, MaxTags)
+	}
+
+	return normalized, nil
+}
+
+// CountCommonTags - сколько тегов из want есть в have.
+func CountCommonTags(have, want []string) int {
+	n := 0
+	for _, tag := range want {
+		if slices.Contains(have, tag) {
+			n++
+		}
+	}
+	return n
+}
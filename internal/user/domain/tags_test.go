@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make([]string, 0, MaxTags+1)
+	for i := 0; i <= MaxTags; i++ {
+		tooMany = append(tooMany, "t"+strconv.Itoa(i))
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", tags: nil, want: []string{}},
+		{name: "normalized", tags: []string{" Postgres", "go", "GO", "c++"}, want: []string{"c++", "go", "postgres"}},
+		{name: "cyrillic", tags: []string{"Бэкенд"}, want: []string{"бэкенд"}},
+		{name: "blank", tags: []string{"go", " "}, wantErr: true},
+		{name: "space_inside", tags: []string{"front end"}, wantErr: true},
+		{name: "too_long", tags: []string{"abcdefghijklmnopqrstuvwxyz0123456"}, wantErr: true},
+		{name: "too_many", tags: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NormalizeTags(tt.tags)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, apperr.ErrInvalidTags))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCountCommonTags(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 2, CountCommonTags([]string{"go", "postgres", "k8s"}, []string{"go", "k8s", "frontend"}))
+	assert.Equal(t, 0, CountCommonTags(nil, []string{"go"}))
+	assert.Equal(t, 0, CountCommonTags([]string{"go"}, nil))
+}
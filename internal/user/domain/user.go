@@ -5,4 +5,17 @@ type User struct {
 	Name     string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// ChatHandle - логин в Slack/Mattermost для уведомлений, пустой - не уведомлять в чат.
+	ChatHandle string `json:"chat_handle,omitempty"`
+	// Email - адрес для уведомлений; EmailOptOut отключает письма,
+	// EmailDigest копит уведомления и отправляет их одним письмом.
+	Email       string `json:"email,omitempty"`
+	EmailOptOut bool   `json:"email_opt_out,omitempty"`
+	EmailDigest bool   `json:"email_digest,omitempty"`
+	// WorkingHours - рабочее окно для режима назначения working_hours, nil - не задано.
+	WorkingHours *WorkingHours `json:"working_hours,omitempty"`
+	// Tags - экспертиза пользователя ("go", "postgres"); по ней ревьюверы подбираются к PR с такими же тегами.
+	Tags []string `json:"tags,omitempty"`
+	// Level - грейд (junior/middle/senior/lead), пустой - не задан.
+	Level Level `json:"level,omitempty"`
 }
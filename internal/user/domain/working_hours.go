@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+)
+
+const clockLayout = "15:04"
+
+// WorkingHours - ежедневное рабочее окно пользователя в его часовом поясе.
+// Start и End задаются как "HH:MM"; окно, у которого End раньше Start, переходит через полночь.
+type WorkingHours struct {
+	Timezone string `json:"timezone"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+}
+
+func (w *WorkingHours) Validate() error {
+	if _, err := time.LoadLocation(w.Timezone); err != nil || w.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone %q", apperr.ErrInvalidWorkingHours, w.Timezone)
+	}
+
+	start, err := time.Parse(clockLayout, w.Start)
+	if err != nil {
+		return fmt.Errorf("%w: start must be HH:MM", apperr.ErrInvalidWorkingHours)
+	}
+
+	end, err := time.Parse(clockLayout, w.End)
+	if err != nil {
+		return fmt.Errorf("%w: end must be HH:MM", apperr.ErrInvalidWorkingHours)
+	}
+
+	if start.Equal(end) {
+		return fmt.Errorf("%w: start and end must differ", apperr.ErrInvalidWorkingHours)
+	}
+
+	return nil
+}
+
+// Contains - попадает ли момент t в рабочее окно. Для незаданных или некорректных
+// часов возвращает false: про такого пользователя неизвестно, работает ли он сейчас.
+func (w *WorkingHours) Contains(t time.Time) bool {
+	if w == nil {
+		return false
+	}
+
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+	start, err := time.Parse(clockLayout, w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(clockLayout, w.End)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from < to {
+		return minute >= from && minute < to
+	}
+	// ночная смена: окно переходит через полночь
+	return minute >= from || minute < to
+}
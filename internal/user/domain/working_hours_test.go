@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkingHours_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hours   WorkingHours
+		wantErr bool
+	}{
+		{name: "valid", hours: WorkingHours{Timezone: "Europe/Moscow", Start: "09:00", End: "18:00"}},
+		{name: "overnight", hours: WorkingHours{Timezone: "Asia/Yerevan", Start: "22:00", End: "06:00"}},
+		{name: "unknown_timezone", hours: WorkingHours{Timezone: "Mars/Olympus", Start: "09:00", End: "18:00"}, wantErr: true},
+		{name: "empty_timezone", hours: WorkingHours{Start: "09:00", End: "18:00"}, wantErr: true},
+		{name: "bad_clock", hours: WorkingHours{Timezone: "UTC", Start: "9am", End: "18:00"}, wantErr: true},
+		{name: "empty_window", hours: WorkingHours{Timezone: "UTC", Start: "09:00", End: "09:00"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.hours.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, apperr.ErrInvalidWorkingHours))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWorkingHours_Contains(t *testing.T) {
+	t.Parallel()
+
+	// 16:30 UTC = 19:30 в Москве, 20:30 в Ереване, 17:30 в Белграде (зимнее время)
+	now := time.Date(2025, 12, 5, 16, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		hours *WorkingHours
+		want  bool
+	}{
+		{name: "moscow_day_is_over", hours: &WorkingHours{Timezone: "Europe/Moscow", Start: "09:00", End: "18:00"}, want: false},
+		{name: "belgrade_still_works", hours: &WorkingHours{Timezone: "Europe/Belgrade", Start: "09:00", End: "18:00"}, want: true},
+		{name: "end_is_exclusive", hours: &WorkingHours{Timezone: "UTC", Start: "08:00", End: "16:30"}, want: false},
+		{name: "overnight_window", hours: &WorkingHours{Timezone: "Asia/Yerevan", Start: "20:00", End: "04:00"}, want: true},
+		{name: "not_set", hours: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.hours.Contains(now))
+		})
+	}
+}
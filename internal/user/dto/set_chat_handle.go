@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type SetChatHandleRequest struct {
+	UserID     string `json:"user_id" validate:"required"`
+	ChatHandle string `json:"chat_handle" validate:"max=64"`
+}
+
+type SetChatHandleResponse struct {
+	domain.User
+}
@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+type SetEmailSettingsRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Email  string `json:"email" validate:"omitempty,email"`
+	OptOut bool   `json:"email_opt_out"`
+	Digest bool   `json:"email_digest"`
+}
+
+type SetEmailSettingsResponse struct {
+	domain.User
+}
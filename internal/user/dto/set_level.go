@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// SetLevelRequest - пустой level очищает грейд.
+type SetLevelRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+	Level  string `json:"level" validate:"omitempty,oneof=junior middle senior lead"`
+}
+
+type SetLevelResponse struct {
+	domain.User
+}
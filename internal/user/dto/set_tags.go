@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// SetTagsRequest - теги заменяются целиком, пустой список очищает их.
+type SetTagsRequest struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Tags   []string `json:"tags" validate:"max=32"`
+}
+
+type SetTagsResponse struct {
+	domain.User
+}
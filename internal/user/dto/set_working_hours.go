@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+)
+
+// SetWorkingHoursRequest - пустые timezone, start и end очищают рабочее окно.
+type SetWorkingHoursRequest struct {
+	UserID   string `json:"user_id" validate:"required"`
+	Timezone string `json:"timezone" validate:"max=64"`
+	Start    string `json:"start" validate:"omitempty,len=5"`
+	End      string `json:"end" validate:"omitempty,len=5"`
+}
+
+type SetWorkingHoursResponse struct {
+	domain.User
+}
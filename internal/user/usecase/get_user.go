@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
 	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
 )
 
 func (u *UserUsecase) GetUser(ctx context.Context,
 	getUserRequest *dto.GetUserRequest) (*dto.GetUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.GetUser")
+	defer span.End()
+
 	userID := getUserRequest.UserID
 
 	user, err := u.userProvider.GetUser(ctx, userID)
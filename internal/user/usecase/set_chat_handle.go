@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
+)
+
+func (u *UserUsecase) SetChatHandle(ctx context.Context,
+	setChatHandleRequest *dto.SetChatHandleRequest) (*dto.SetChatHandleResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetChatHandle")
+	defer span.End()
+
+	userID := setChatHandleRequest.UserID
+	// "@alice" и "alice" - один и тот же логин
+	chatHandle := strings.TrimPrefix(strings.TrimSpace(setChatHandleRequest.ChatHandle), "@")
+
+	updatedUser, err := u.userProvider.SetChatHandle(ctx, userID, chatHandle)
+	if err != nil {
+		slog.Error("UserUsecase.SetChatHandle: provider error",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update chat_handle in provider: %w", err)
+	}
+
+	slog.Info("UserUsecase.SetChatHandle: user updated",
+		slog.String("user_id", updatedUser.ID),
+		slog.String("chat_handle", updatedUser.ChatHandle),
+	)
+
+	return &dto.SetChatHandleResponse{
+		User: *updatedUser,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
+)
+
+func (u *UserUsecase) SetEmailSettings(ctx context.Context,
+	request *dto.SetEmailSettingsRequest) (*dto.SetEmailSettingsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetEmailSettings")
+	defer span.End()
+
+	email := strings.TrimSpace(request.Email)
+
+	updatedUser, err := u.userProvider.SetEmailSettings(ctx, request.UserID, email, request.OptOut, request.Digest)
+	if err != nil {
+		slog.Error("UserUsecase.SetEmailSettings: provider error",
+			slog.String("user_id", request.UserID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update email settings in provider: %w", err)
+	}
+
+	slog.Info("UserUsecase.SetEmailSettings: user updated",
+		slog.String("user_id", updatedUser.ID),
+		slog.Bool("email_opt_out", updatedUser.EmailOptOut),
+		slog.Bool("email_digest", updatedUser.EmailDigest),
+	)
+
+	return &dto.SetEmailSettingsResponse{
+		User: *updatedUser,
+	}, nil
+}
@@ -5,16 +5,39 @@ import (
 	"fmt"
 	"log/slog"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
 )
 
 func (u *UserUsecase) SetIsActive(ctx context.Context,
 	setIsActiveRequest *dto.SetIsActiveRequest) (*dto.SetIsActiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetIsActive")
+	defer span.End()
 
 	userID := setIsActiveRequest.UserID
 	isActive := setIsActiveRequest.IsActive
 
-	updatedUser, err := u.userProvider.SetIsActive(ctx, userID, isActive)
+	var updatedUser *domain.User
+	err := u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedUser, err = u.userProvider.SetIsActive(ctx, userID, isActive)
+		if err != nil {
+			return fmt.Errorf("update isActive in postgres: %w", err)
+		}
+
+		event, err := outboxdomain.NewEvent(outboxdomain.EventUserActivityChanged, updatedUser.ID,
+			outboxdomain.UserActivityPayload{User: *updatedUser})
+		if err != nil {
+			return fmt.Errorf("build user activity event: %w", err)
+		}
+
+		if err := u.eventWriter.Append(ctx, event); err != nil {
+			return fmt.Errorf("append user activity event: %w", err)
+		}
+		return nil
+	})
 
 	if err != nil {
 		slog.Error("UserUsecase.SetIsActive: provider error",
@@ -22,7 +45,7 @@ func (u *UserUsecase) SetIsActive(ctx context.Context,
 			slog.Bool("is_active", isActive),
 			slog.Any("error", err),
 		)
-		return nil, fmt.Errorf("update isActive in postgres: %w", err)
+		return nil, err
 	}
 
 	slog.Info("UserUsecase.SetIsActive: user updated",
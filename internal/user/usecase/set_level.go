@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
+)
+
+func (u *UserUsecase) SetLevel(ctx context.Context, setLevelRequest *dto.SetLevelRequest) (*dto.SetLevelResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetLevel")
+	defer span.End()
+
+	userID := setLevelRequest.UserID
+
+	updatedUser, err := u.userProvider.SetLevel(ctx, userID, domain.Level(setLevelRequest.Level))
+	if err != nil {
+		slog.Error("UserUsecase.SetLevel: provider error",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update level in provider: %w", err)
+	}
+
+	slog.Info("UserUsecase.SetLevel: user updated",
+		slog.String("user_id", updatedUser.ID),
+		slog.String("level", string(updatedUser.Level)),
+	)
+
+	return &dto.SetLevelResponse{
+		User: *updatedUser,
+	}, nil
+}
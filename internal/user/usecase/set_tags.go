@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
+)
+
+func (u *UserUsecase) SetTags(ctx context.Context, setTagsRequest *dto.SetTagsRequest) (*dto.SetTagsResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetTags")
+	defer span.End()
+
+	userID := setTagsRequest.UserID
+
+	tags, err := domain.NormalizeTags(setTagsRequest.Tags)
+	if err != nil {
+		slog.Info("UserUsecase.SetTags: invalid tags",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	updatedUser, err := u.userProvider.SetTags(ctx, userID, tags)
+	if err != nil {
+		slog.Error("UserUsecase.SetTags: provider error",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update tags in provider: %w", err)
+	}
+
+	slog.Info("UserUsecase.SetTags: user updated",
+		slog.String("user_id", updatedUser.ID),
+		slog.Any("tags", updatedUser.Tags),
+	)
+
+	return &dto.SetTagsResponse{
+		User: *updatedUser,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/tracing"
+	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
+)
+
+func (u *UserUsecase) SetWorkingHours(ctx context.Context,
+	setWorkingHoursRequest *dto.SetWorkingHoursRequest) (*dto.SetWorkingHoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.SetWorkingHours")
+	defer span.End()
+
+	userID := setWorkingHoursRequest.UserID
+
+	var hours *domain.WorkingHours
+	if setWorkingHoursRequest.Timezone != "" || setWorkingHoursRequest.Start != "" || setWorkingHoursRequest.End != "" {
+		hours = &domain.WorkingHours{
+			Timezone: setWorkingHoursRequest.Timezone,
+			Start:    setWorkingHoursRequest.Start,
+			End:      setWorkingHoursRequest.End,
+		}
+		if err := hours.Validate(); err != nil {
+			slog.Info("UserUsecase.SetWorkingHours: invalid working hours",
+				slog.String("user_id", userID),
+				slog.Any("error", err),
+			)
+			return nil, err
+		}
+	}
+
+	updatedUser, err := u.userProvider.SetWorkingHours(ctx, userID, hours)
+	if err != nil {
+		slog.Error("UserUsecase.SetWorkingHours: provider error",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("update working hours in provider: %w", err)
+	}
+
+	slog.Info("UserUsecase.SetWorkingHours: user updated",
+		slog.String("user_id", updatedUser.ID),
+		slog.Any("working_hours", updatedUser.WorkingHours),
+	)
+
+	return &dto.SetWorkingHoursResponse{
+		User: *updatedUser,
+	}, nil
+}
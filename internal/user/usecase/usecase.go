@@ -3,20 +3,38 @@ package usecase
 import (
 	"context"
 
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
 )
 
 type UserProvider interface {
 	GetUser(ctx context.Context, id string) (*domain.User, error)
 	SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error)
+	SetChatHandle(ctx context.Context, id, chatHandle string) (*domain.User, error)
+	SetEmailSettings(ctx context.Context, id, email string, optOut, digest bool) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, id string, hours *domain.WorkingHours) (*domain.User, error)
+	SetTags(ctx context.Context, id string, tags []string) (*domain.User, error)
+	SetLevel(ctx context.Context, id string, level domain.Level) (*domain.User, error)
+}
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type EventWriter interface {
+	Append(ctx context.Context, events ...outboxdomain.Event) error
 }
 
 type UserUsecase struct {
 	userProvider UserProvider
+	txManager    Transactor
+	eventWriter  EventWriter
 }
 
-func NewUserUsecase(repo UserProvider) *UserUsecase {
+func NewUserUsecase(repo UserProvider, txManager Transactor, eventWriter EventWriter) *UserUsecase {
 	return &UserUsecase{
 		userProvider: repo,
+		txManager:    txManager,
+		eventWriter:  eventWriter,
 	}
 }
@@ -6,6 +6,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils"
 	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
 	"github.com/silentmol/avito-backend-trainee/internal/user/domain"
 	"github.com/silentmol/avito-backend-trainee/internal/user/dto"
@@ -134,7 +137,23 @@ func TestUserUsecase_SetIsActive(t *testing.T) {
 				SetIsActive(gomock.Any(), tt.req.UserID, tt.req.IsActive).
 				Return(tt.stubUser, tt.stubErr)
 
-			uc := &UserUsecase{userProvider: userProvider}
+			eventWriter := mocks.NewMockUserEventWriter(ctrl)
+			if !tt.wantErr {
+				eventWriter.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...outboxdomain.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, outboxdomain.EventUserActivityChanged, events[0].Type)
+						assert.Equal(t, tt.req.UserID, events[0].AggregateID)
+						return nil
+					})
+			}
+
+			uc := &UserUsecase{
+				userProvider: userProvider,
+				txManager:    testutils.InlineTx{},
+				eventWriter:  eventWriter,
+			}
 
 			resp, err := uc.SetIsActive(context.Background(), tt.req)
 			if tt.wantErr {
@@ -150,3 +169,201 @@ func TestUserUsecase_SetIsActive(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_SetChatHandle(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name       string
+		req        *dto.SetChatHandleRequest
+		wantHandle string
+		stubErr    error
+		wantErr    bool
+	}
+
+	tests := []tc{
+		{
+			name:       "success",
+			req:        &dto.SetChatHandleRequest{UserID: "u1", ChatHandle: "alice"},
+			wantHandle: "alice",
+		},
+		{
+			name:       "leading_at_is_trimmed",
+			req:        &dto.SetChatHandleRequest{UserID: "u1", ChatHandle: " @alice "},
+			wantHandle: "alice",
+		},
+		{
+			name:       "empty_clears_handle",
+			req:        &dto.SetChatHandleRequest{UserID: "u1", ChatHandle: ""},
+			wantHandle: "",
+		},
+		{
+			name:       "not_found",
+			req:        &dto.SetChatHandleRequest{UserID: "u404", ChatHandle: "bob"},
+			wantHandle: "bob",
+			stubErr:    apperr.ErrNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userProvider := mocks.NewMockUserProvider(ctrl)
+			userProvider.EXPECT().
+				SetChatHandle(gomock.Any(), tt.req.UserID, tt.wantHandle).
+				DoAndReturn(func(_ context.Context, id, handle string) (*domain.User, error) {
+					if tt.stubErr != nil {
+						return nil, tt.stubErr
+					}
+					return &domain.User{ID: id, ChatHandle: handle}, nil
+				})
+
+			uc := &UserUsecase{userProvider: userProvider}
+
+			resp, err := uc.SetChatHandle(context.Background(), tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.stubErr)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHandle, resp.User.ChatHandle)
+		})
+	}
+}
+
+func TestUserUsecase_SetEmailSettings(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userProvider := mocks.NewMockUserProvider(ctrl)
+	userProvider.EXPECT().
+		SetEmailSettings(gomock.Any(), "u1", "bob@example.com", false, true).
+		Return(&domain.User{ID: "u1", Email: "bob@example.com", EmailDigest: true}, nil)
+	userProvider.EXPECT().
+		SetEmailSettings(gomock.Any(), "u404", "", true, false).
+		Return(nil, apperr.ErrNotFound)
+
+	uc := &UserUsecase{userProvider: userProvider}
+
+	resp, err := uc.SetEmailSettings(context.Background(), &dto.SetEmailSettingsRequest{
+		UserID: "u1",
+		Email:  " bob@example.com ",
+		Digest: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "bob@example.com", resp.User.Email)
+	assert.True(t, resp.User.EmailDigest)
+
+	resp, err = uc.SetEmailSettings(context.Background(), &dto.SetEmailSettingsRequest{
+		UserID: "u404",
+		OptOut: true,
+	})
+	require.ErrorIs(t, err, apperr.ErrNotFound)
+	require.Nil(t, resp)
+}
+
+func TestUserUsecase_SetWorkingHours(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hours := &domain.WorkingHours{Timezone: "Europe/Belgrade", Start: "09:00", End: "18:00"}
+
+	userProvider := mocks.NewMockUserProvider(ctrl)
+	userProvider.EXPECT().
+		SetWorkingHours(gomock.Any(), "u1", hours).
+		Return(&domain.User{ID: "u1", WorkingHours: hours}, nil)
+	userProvider.EXPECT().
+		SetWorkingHours(gomock.Any(), "u1", nil).
+		Return(&domain.User{ID: "u1"}, nil)
+
+	uc := &UserUsecase{userProvider: userProvider}
+
+	resp, err := uc.SetWorkingHours(context.Background(), &dto.SetWorkingHoursRequest{
+		UserID:   "u1",
+		Timezone: "Europe/Belgrade",
+		Start:    "09:00",
+		End:      "18:00",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hours, resp.User.WorkingHours)
+
+	resp, err = uc.SetWorkingHours(context.Background(), &dto.SetWorkingHoursRequest{UserID: "u1"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.User.WorkingHours)
+
+	// некорректное окно не доходит до хранилища
+	resp, err = uc.SetWorkingHours(context.Background(), &dto.SetWorkingHoursRequest{
+		UserID: "u1",
+		Start:  "09:00",
+		End:    "18:00",
+	})
+	require.ErrorIs(t, err, apperr.ErrInvalidWorkingHours)
+	require.Nil(t, resp)
+}
+
+func TestUserUsecase_SetTags(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userProvider := mocks.NewMockUserProvider(ctrl)
+	userProvider.EXPECT().
+		SetTags(gomock.Any(), "u1", []string{"go", "postgres"}).
+		Return(&domain.User{ID: "u1", Tags: []string{"go", "postgres"}}, nil)
+
+	uc := &UserUsecase{userProvider: userProvider}
+
+	resp, err := uc.SetTags(context.Background(), &dto.SetTagsRequest{
+		UserID: "u1",
+		Tags:   []string{"Postgres", "go", "go"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "postgres"}, resp.User.Tags)
+
+	// некорректный тег не доходит до хранилища
+	resp, err = uc.SetTags(context.Background(), &dto.SetTagsRequest{
+		UserID: "u1",
+		Tags:   []string{"front end"},
+	})
+	require.ErrorIs(t, err, apperr.ErrInvalidTags)
+	require.Nil(t, resp)
+}
+
+func TestUserUsecase_SetLevel(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userProvider := mocks.NewMockUserProvider(ctrl)
+	userProvider.EXPECT().
+		SetLevel(gomock.Any(), "u1", domain.LevelSenior).
+		Return(&domain.User{ID: "u1", Level: domain.LevelSenior}, nil)
+	userProvider.EXPECT().
+		SetLevel(gomock.Any(), "u404", domain.LevelLead).
+		Return(nil, apperr.ErrNotFound)
+
+	uc := &UserUsecase{userProvider: userProvider}
+
+	resp, err := uc.SetLevel(context.Background(), &dto.SetLevelRequest{UserID: "u1", Level: "senior"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.LevelSenior, resp.User.Level)
+
+	resp, err = uc.SetLevel(context.Background(), &dto.SetLevelRequest{UserID: "u404", Level: "lead"})
+	require.ErrorIs(t, err, apperr.ErrNotFound)
+	require.Nil(t, resp)
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	"github.com/silentmol/avito-backend-trainee/internal/storage"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+)
+
+type WebhookRepository struct {
+	conn *pgxpool.Pool
+}
+
+func NewWebhookRepository(conn *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{conn: conn}
+}
+
+func (w *WebhookRepository) CreateSubscription(ctx context.Context,
+	subscription *domain.Subscription) (*domain.Subscription, error) {
+
+	query := `
+		INSERT INTO webhook_subscriptions (url, secret, event_types)
+		VALUES ($1, $2, $3)
+		RETURNING id, url, secret, event_types, created_at
+	`
+
+	var created domain.Subscription
+	if err := storage.QuerierFrom(ctx, w.conn).QueryRow(ctx, query,
+		subscription.URL,
+		subscription.Secret,
+		subscription.EventTypes,
+	).Scan(
+		&created.ID,
+		&created.URL,
+		&created.Secret,
+		&created.EventTypes,
+		&created.CreatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("db: failed to create webhook subscription: %w", err)
+	}
+
+	return &created, nil
+}
+
+func (w *WebhookRepository) GetSubscription(ctx context.Context, id int64) (*domain.Subscription, error) {
+	query := `
+		SELECT id, url, secret, event_types, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1
+	`
+
+	var subscription domain.Subscription
+	if err := storage.QuerierFrom(ctx, w.conn).QueryRow(ctx, query, id).Scan(
+		&subscription.ID,
+		&subscription.URL,
+		&subscription.Secret,
+		&subscription.EventTypes,
+		&subscription.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, fmt.Errorf("db: failed to get webhook subscription: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+func (w *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	query := `
+		SELECT id, url, secret, event_types, created_at
+		FROM webhook_subscriptions
+		ORDER BY id
+	`
+
+	rows, err := storage.QuerierFrom(ctx, w.conn).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]domain.Subscription, 0)
+
+	for rows.Next() {
+		var subscription domain.Subscription
+		if err := rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.Secret,
+			&subscription.EventTypes,
+			&subscription.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (w *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	query := `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1
+	`
+
+	tag, err := storage.QuerierFrom(ctx, w.conn).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("db: failed to delete webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (w *WebhookRepository) AppendDelivery(ctx context.Context, delivery *domain.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries
+			(subscription_id, event_id, event_type, status_code, success, error, duration_ms)
+		VALUES ($1, $2, $3, NULLIF($4, 0), $5, NULLIF($6, ''), $7)
+	`
+
+	if _, err := storage.QuerierFrom(ctx, w.conn).Exec(ctx, query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.StatusCode,
+		delivery.Success,
+		delivery.Error,
+		delivery.DurationMs,
+	); err != nil {
+		return fmt.Errorf("db: failed to append webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (w *WebhookRepository) ListDeliveries(ctx context.Context,
+	subscriptionID int64, limit int) ([]domain.Delivery, error) {
+
+	query := `
+		SELECT id, subscription_id, event_id, event_type, COALESCE(status_code, 0),
+		       success, COALESCE(error, ''), duration_ms, created_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	rows, err := storage.QuerierFrom(ctx, w.conn).Query(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db: failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.Delivery, 0)
+
+	for rows.Next() {
+		var delivery domain.Delivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.StatusCode,
+			&delivery.Success,
+			&delivery.Error,
+			&delivery.DurationMs,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("db: failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("db: rows error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookRepository) HasSuccessfulDelivery(ctx context.Context, subscriptionID, eventID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM webhook_deliveries
+			WHERE subscription_id = $1 AND event_id = $2 AND success
+		)
+	`
+
+	var exists bool
+	if err := storage.QuerierFrom(ctx, w.conn).QueryRow(ctx, query, subscriptionID, eventID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("db: failed to check webhook delivery: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+// Delivery - запись журнала доставки: одна попытка отправить событие в одну подписку.
+type Delivery struct {
+	ID             int64     `json:"delivery_id"`
+	SubscriptionID int64     `json:"subscription_id"`
+	EventID        int64     `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	StatusCode     int       `json:"status_code,omitempty"`
+	Success        bool      `json:"success"`
+	Error          string    `json:"error,omitempty"`
+	DurationMs     int64     `json:"duration_ms"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Payload - тело, которое получает подписчик.
+type Payload struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewPayload(event outboxdomain.Event) Payload {
+	return Payload{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	}
+}
+
+func IsSuccessStatus(code int) bool {
+	return code >= 200 && code < 300
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature-256"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	// AllEvents - подписка на все типы событий.
+	AllEvents = "*"
+)
+
+type Subscription struct {
+	ID         int64     `json:"subscription_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Validate проверяет URL и типы событий подписки.
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be absolute http(s) URL", apperr.ErrInvalidWebhook)
+	}
+
+	if s.Secret == "" {
+		return fmt.Errorf("%w: secret is required", apperr.ErrInvalidWebhook)
+	}
+
+	if len(s.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", apperr.ErrInvalidWebhook)
+	}
+
+	for _, t := range s.EventTypes {
+		if t == AllEvents {
+			continue
+		}
+		if !outboxdomain.EventType(t).Valid() {
+			return fmt.Errorf("%w: unknown event type %q", apperr.ErrInvalidWebhook, t)
+		}
+	}
+
+	return nil
+}
+
+func (s *Subscription) Matches(eventType outboxdomain.EventType) bool {
+	return slices.Contains(s.EventTypes, AllEvents) || slices.Contains(s.EventTypes, string(eventType))
+}
+
+// Sign подписывает тело запроса HMAC-SHA256 секретом подписки в формате "sha256=<hex>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		subscription Subscription
+		wantErr      bool
+	}{
+		{
+			name: "valid",
+			subscription: Subscription{
+				URL:        "https://bot.example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.merged", "pr.reviewer_assigned"},
+			},
+		},
+		{
+			name: "wildcard",
+			subscription: Subscription{
+				URL:        "http://localhost:9000/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{AllEvents},
+			},
+		},
+		{
+			name: "relative_url",
+			subscription: Subscription{
+				URL:        "/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.merged"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported_scheme",
+			subscription: Subscription{
+				URL:        "ftp://example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.merged"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown_event_type",
+			subscription: Subscription{
+				URL:        "https://example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.exploded"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no_event_types",
+			subscription: Subscription{
+				URL:    "https://example.com/hook",
+				Secret: "0123456789abcdef",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty_secret",
+			subscription: Subscription{
+				URL:        "https://example.com/hook",
+				EventTypes: []string{"pr.merged"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.subscription.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, apperr.ErrInvalidWebhook)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	t.Parallel()
+
+	merged := Subscription{EventTypes: []string{"pr.merged"}}
+	assert.True(t, merged.Matches(outboxdomain.EventPRMerged))
+	assert.False(t, merged.Matches(outboxdomain.EventPRCreated))
+
+	all := Subscription{EventTypes: []string{AllEvents}}
+	assert.True(t, all.Matches(outboxdomain.EventUserActivityChanged))
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"type":"pr.merged"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, want, Sign("secret", body))
+	assert.NotEqual(t, want, Sign("other", body))
+}
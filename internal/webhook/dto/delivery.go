@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+
+type GetDeliveriesRequest struct {
+	SubscriptionID int64 `query:"subscription_id" validate:"required"`
+	Limit          int   `query:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+type GetDeliveriesResponse struct {
+	SubscriptionID int64             `json:"subscription_id"`
+	Deliveries     []domain.Delivery `json:"deliveries"`
+}
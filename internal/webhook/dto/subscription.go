@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+
+type AddSubscriptionRequest struct {
+	URL        string   `json:"url" validate:"required"`
+	Secret     string   `json:"secret" validate:"required,min=16"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+}
+
+type AddSubscriptionResponse struct {
+	Subscription domain.Subscription `json:"subscription"`
+}
+
+type ListSubscriptionsResponse struct {
+	Subscriptions []domain.Subscription `json:"subscriptions"`
+}
+
+type DeleteSubscriptionRequest struct {
+	SubscriptionID int64 `json:"subscription_id" validate:"required"`
+}
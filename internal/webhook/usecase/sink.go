@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+)
+
+// Name и Handle реализуют синк outbox: каждое событие рассылается подписчикам.
+// Повторы с экспоненциальной задержкой выполняет диспетчер outbox; подписки,
+// уже получившие событие, при повторе пропускаются по журналу доставок.
+func (u *WebhookUsecase) Name() string {
+	return "webhook"
+}
+
+func (u *WebhookUsecase) Handle(ctx context.Context, event outboxdomain.Event) error {
+	subscriptions, err := u.webhookProvider.ListSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions in provider: %w", err)
+	}
+
+	body, err := json.Marshal(domain.NewPayload(event))
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.Type) {
+			continue
+		}
+
+		delivered, err := u.webhookProvider.HasSuccessfulDelivery(ctx, subscription.ID, event.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("check delivery for subscription %d: %w", subscription.ID, err))
+			continue
+		}
+		if delivered {
+			continue
+		}
+
+		if err := u.deliver(ctx, subscription, event, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (u *WebhookUsecase) deliver(ctx context.Context, subscription domain.Subscription,
+	event outboxdomain.Event, body []byte) error {
+
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		domain.EventHeader:     string(event.Type),
+		domain.DeliveryHeader:  strconv.FormatInt(event.ID, 10),
+		domain.SignatureHeader: domain.Sign(subscription.Secret, body),
+	}
+
+	started := time.Now()
+	statusCode, sendErr := u.sender.Send(ctx, subscription.URL, headers, body)
+
+	delivery := &domain.Delivery{
+		SubscriptionID: subscription.ID,
+		EventID:        event.ID,
+		EventType:      string(event.Type),
+		StatusCode:     statusCode,
+		Success:        sendErr == nil && domain.IsSuccessStatus(statusCode),
+		DurationMs:     time.Since(started).Milliseconds(),
+	}
+
+	var deliveryErr error
+	switch {
+	case sendErr != nil:
+		deliveryErr = fmt.Errorf("subscription %d: %w", subscription.ID, sendErr)
+	case !delivery.Success:
+		deliveryErr = fmt.Errorf("subscription %d: unexpected status %d", subscription.ID, statusCode)
+	}
+	if deliveryErr != nil {
+		delivery.Error = deliveryErr.Error()
+	}
+
+	if err := u.webhookProvider.AppendDelivery(ctx, delivery); err != nil {
+		slog.Error("WebhookUsecase.deliver: failed to record delivery",
+			slog.Int64("subscription_id", subscription.ID),
+			slog.Int64("event_id", event.ID),
+			slog.Any("error", err),
+		)
+		// без записи в журнале повтор отправит событие ещё раз, это допустимо
+	}
+
+	if deliveryErr != nil {
+		slog.Warn("WebhookUsecase.deliver: delivery failed",
+			slog.Int64("subscription_id", subscription.ID),
+			slog.Int64("event_id", event.ID),
+			slog.Int("status_code", statusCode),
+			slog.Any("error", deliveryErr),
+		)
+		return deliveryErr
+	}
+
+	slog.Info("WebhookUsecase.deliver: event delivered",
+		slog.Int64("subscription_id", subscription.ID),
+		slog.Int64("event_id", event.ID),
+		slog.String("event_type", string(event.Type)),
+	)
+
+	return nil
+}
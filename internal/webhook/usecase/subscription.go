@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/dto"
+)
+
+func (u *WebhookUsecase) AddSubscription(ctx context.Context,
+	request *dto.AddSubscriptionRequest) (*dto.AddSubscriptionResponse, error) {
+
+	subscription := &domain.Subscription{
+		URL:        request.URL,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+	}
+
+	if err := subscription.Validate(); err != nil {
+		slog.Info("WebhookUsecase.AddSubscription: invalid subscription",
+			slog.String("url", request.URL),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	created, err := u.webhookProvider.CreateSubscription(ctx, subscription)
+	if err != nil {
+		slog.Error("WebhookUsecase.AddSubscription: provider error",
+			slog.String("url", request.URL),
+			slog.Any("error", err),
+		)
+		return nil, fmt.Errorf("create webhook subscription in provider: %w", err)
+	}
+
+	slog.Info("WebhookUsecase.AddSubscription: subscription created",
+		slog.Int64("subscription_id", created.ID),
+		slog.String("url", created.URL),
+		slog.Any("event_types", created.EventTypes),
+	)
+
+	return &dto.AddSubscriptionResponse{
+		Subscription: *created,
+	}, nil
+}
+
+func (u *WebhookUsecase) ListSubscriptions(ctx context.Context) (*dto.ListSubscriptionsResponse, error) {
+	subscriptions, err := u.webhookProvider.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions in provider: %w", err)
+	}
+
+	return &dto.ListSubscriptionsResponse{
+		Subscriptions: subscriptions,
+	}, nil
+}
+
+func (u *WebhookUsecase) DeleteSubscription(ctx context.Context, request *dto.DeleteSubscriptionRequest) error {
+	if err := u.webhookProvider.DeleteSubscription(ctx, request.SubscriptionID); err != nil {
+		return fmt.Errorf("delete webhook subscription in provider: %w", err)
+	}
+
+	slog.Info("WebhookUsecase.DeleteSubscription: subscription deleted",
+		slog.Int64("subscription_id", request.SubscriptionID),
+	)
+
+	return nil
+}
+
+const defaultDeliveriesLimit = 50
+
+func (u *WebhookUsecase) GetDeliveries(ctx context.Context,
+	request *dto.GetDeliveriesRequest) (*dto.GetDeliveriesResponse, error) {
+
+	if _, err := u.webhookProvider.GetSubscription(ctx, request.SubscriptionID); err != nil {
+		return nil, fmt.Errorf("get webhook subscription from provider: %w", err)
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	deliveries, err := u.webhookProvider.ListDeliveries(ctx, request.SubscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries in provider: %w", err)
+	}
+
+	return &dto.GetDeliveriesResponse{
+		SubscriptionID: request.SubscriptionID,
+		Deliveries:     deliveries,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+)
+
+type WebhookProvider interface {
+	CreateSubscription(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*domain.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	AppendDelivery(ctx context.Context, delivery *domain.Delivery) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.Delivery, error)
+	HasSuccessfulDelivery(ctx context.Context, subscriptionID, eventID int64) (bool, error)
+}
+
+type Sender interface {
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+type WebhookUsecase struct {
+	webhookProvider WebhookProvider
+	sender          Sender
+}
+
+func NewWebhookUsecase(repo WebhookProvider, sender Sender) *WebhookUsecase {
+	return &WebhookUsecase{
+		webhookProvider: repo,
+		sender:          sender,
+	}
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/silentmol/avito-backend-trainee/internal/apperr"
+	outboxdomain "github.com/silentmol/avito-backend-trainee/internal/outbox/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/testutils/mocks"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/adapter/sender"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/domain"
+	"github.com/silentmol/avito-backend-trainee/internal/webhook/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookUsecase_AddSubscription(t *testing.T) {
+	t.Parallel()
+
+	type tc struct {
+		name       string
+		req        *dto.AddSubscriptionRequest
+		stubErr    error
+		wantCreate bool
+		wantErr    error
+		wantAnyErr bool
+	}
+
+	tests := []tc{
+		{
+			name: "success",
+			req: &dto.AddSubscriptionRequest{
+				URL:        "https://bot.example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.merged"},
+			},
+			wantCreate: true,
+		},
+		{
+			name: "invalid_event_type",
+			req: &dto.AddSubscriptionRequest{
+				URL:        "https://bot.example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.unknown"},
+			},
+			wantErr: apperr.ErrInvalidWebhook,
+		},
+		{
+			name: "provider_error",
+			req: &dto.AddSubscriptionRequest{
+				URL:        "https://bot.example.com/hook",
+				Secret:     "0123456789abcdef",
+				EventTypes: []string{"pr.merged"},
+			},
+			wantCreate: true,
+			stubErr:    errors.New("db error"),
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookProvider := mocks.NewMockWebhookProvider(ctrl)
+
+			if tt.wantCreate {
+				webhookProvider.EXPECT().
+					CreateSubscription(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, s *domain.Subscription) (*domain.Subscription, error) {
+						if tt.stubErr != nil {
+							return nil, tt.stubErr
+						}
+						assert.Equal(t, tt.req.URL, s.URL)
+						assert.Equal(t, tt.req.Secret, s.Secret)
+						created := *s
+						created.ID = 1
+						return &created, nil
+					})
+			}
+
+			uc := &WebhookUsecase{webhookProvider: webhookProvider}
+
+			resp, err := uc.AddSubscription(context.Background(), tt.req)
+			if tt.wantErr != nil || tt.wantAnyErr {
+				require.Error(t, err)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), resp.Subscription.ID)
+		})
+	}
+}
+
+func TestWebhookUsecase_Handle(t *testing.T) {
+	t.Parallel()
+
+	event := outboxdomain.Event{
+		ID:      42,
+		Type:    outboxdomain.EventPRMerged,
+		Payload: json.RawMessage(`{"pull_request":{"pull_request_id":"pr-1"}}`),
+	}
+
+	subscriptions := []domain.Subscription{
+		{ID: 1, URL: "https://a.example.com", Secret: "s1", EventTypes: []string{"pr.merged"}},
+		{ID: 2, URL: "https://b.example.com", Secret: "s2", EventTypes: []string{"pr.created"}},
+		{ID: 3, URL: "https://c.example.com", Secret: "s3", EventTypes: []string{domain.AllEvents}},
+		{ID: 4, URL: "https://d.example.com", Secret: "s4", EventTypes: []string{"pr.merged"}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookProvider := mocks.NewMockWebhookProvider(ctrl)
+	webhookSender := mocks.NewMockSender(ctrl)
+
+	webhookProvider.EXPECT().ListSubscriptions(gomock.Any()).Return(subscriptions, nil)
+
+	// подписка 2 не подписана на pr.merged, подписка 4 уже получила событие
+	webhookProvider.EXPECT().HasSuccessfulDelivery(gomock.Any(), int64(1), int64(42)).Return(false, nil)
+	webhookProvider.EXPECT().HasSuccessfulDelivery(gomock.Any(), int64(3), int64(42)).Return(false, nil)
+	webhookProvider.EXPECT().HasSuccessfulDelivery(gomock.Any(), int64(4), int64(42)).Return(true, nil)
+
+	webhookSender.EXPECT().
+		Send(gomock.Any(), "https://a.example.com", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, headers map[string]string, body []byte) (int, error) {
+			assert.Equal(t, domain.Sign("s1", body), headers[domain.SignatureHeader])
+			assert.Equal(t, "pr.merged", headers[domain.EventHeader])
+			assert.Equal(t, "42", headers[domain.DeliveryHeader])
+			return http.StatusOK, nil
+		})
+	webhookSender.EXPECT().
+		Send(gomock.Any(), "https://c.example.com", gomock.Any(), gomock.Any()).
+		Return(http.StatusServiceUnavailable, nil)
+
+	var recorded []domain.Delivery
+	webhookProvider.EXPECT().
+		AppendDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *domain.Delivery) error {
+			recorded = append(recorded, *d)
+			return nil
+		}).
+		Times(2)
+
+	uc := NewWebhookUsecase(webhookProvider, webhookSender)
+
+	err := uc.Handle(context.Background(), event)
+	require.Error(t, err, "non-2xx must be reported so the outbox retries")
+
+	require.Len(t, recorded, 2)
+	assert.Equal(t, int64(1), recorded[0].SubscriptionID)
+	assert.True(t, recorded[0].Success)
+	assert.Equal(t, int64(3), recorded[1].SubscriptionID)
+	assert.False(t, recorded[1].Success)
+	assert.Equal(t, http.StatusServiceUnavailable, recorded[1].StatusCode)
+	assert.NotEmpty(t, recorded[1].Error)
+}
+
+func TestWebhookUsecase_Handle_HTTPServer(t *testing.T) {
+	t.Parallel()
+
+	const secret = "0123456789abcdef"
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, domain.Sign(secret, body), r.Header.Get(domain.SignatureHeader))
+
+		var payload domain.Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, int64(7), payload.ID)
+		assert.Equal(t, "pr.reviewer_assigned", payload.Type)
+		assert.JSONEq(t, `{"reviewer_id":"u2"}`, string(payload.Data))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookProvider := mocks.NewMockWebhookProvider(ctrl)
+	webhookProvider.EXPECT().ListSubscriptions(gomock.Any()).Return([]domain.Subscription{
+		{ID: 1, URL: server.URL, Secret: secret, EventTypes: []string{"pr.reviewer_assigned"}},
+	}, nil)
+	webhookProvider.EXPECT().HasSuccessfulDelivery(gomock.Any(), int64(1), int64(7)).Return(false, nil)
+	webhookProvider.EXPECT().
+		AppendDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *domain.Delivery) error {
+			assert.True(t, d.Success)
+			assert.Equal(t, http.StatusNoContent, d.StatusCode)
+			return nil
+		})
+
+	uc := NewWebhookUsecase(webhookProvider, sender.NewSender(time.Second))
+
+	err := uc.Handle(context.Background(), outboxdomain.Event{
+		ID:      7,
+		Type:    outboxdomain.EventPRReviewerAssigned,
+		Payload: json.RawMessage(`{"reviewer_id":"u2"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE external_identities ADD COLUMN IF NOT EXISTS external_id TEXT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_external_identities_external_id
+    ON external_identities(provider, external_id)
+    WHERE external_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_external_identities_external_id;
+ALTER TABLE external_identities DROP COLUMN IF EXISTS external_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email TEXT NULL,
+    ADD COLUMN IF NOT EXISTS email_opt_out BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS email_digest BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS email_digest_items (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_digest_items_pending
+    ON email_digest_items(user_id, id)
+    WHERE sent_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS email_digest_items;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_digest,
+    DROP COLUMN IF EXISTS email_opt_out,
+    DROP COLUMN IF EXISTS email;
+
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ревьюверы переезжают в отдельную таблицу: для SLA нужно время назначения каждого из них
+CREATE TABLE IF NOT EXISTS pull_request_reviewers (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    position SMALLINT NOT NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reminded_at TIMESTAMPTZ NULL,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviewers_reviewer_id ON pull_request_reviewers(reviewer_id);
+
+INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, position, assigned_at)
+SELECT id, reviewer1_id, 0, created_at FROM pull_requests WHERE reviewer1_id IS NOT NULL
+UNION ALL
+SELECT id, reviewer2_id, 1, created_at FROM pull_requests WHERE reviewer2_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_pull_requests_reviewer1_id;
+DROP INDEX IF EXISTS idx_pull_requests_reviewer2_id;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS reviewer1_id,
+    DROP COLUMN IF EXISTS reviewer2_id;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
+
+CREATE TABLE IF NOT EXISTS team_review_sla (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    remind_after INTERVAL NOT NULL,
+    escalate_after INTERVAL NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS team_review_sla;
+
+DROP INDEX IF EXISTS idx_pull_requests_status;
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS reviewer1_id TEXT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS reviewer2_id TEXT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT;
+
+UPDATE pull_requests p
+SET reviewer1_id = (
+        SELECT r.reviewer_id FROM pull_request_reviewers r
+        WHERE r.pull_request_id = p.id ORDER BY r.position LIMIT 1
+    ),
+    reviewer2_id = (
+        SELECT r.reviewer_id FROM pull_request_reviewers r
+        WHERE r.pull_request_id = p.id ORDER BY r.position OFFSET 1 LIMIT 1
+    );
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_reviewer1_id ON pull_requests(reviewer1_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_reviewer2_id ON pull_requests(reviewer2_id);
+
+DROP TABLE IF EXISTS pull_request_reviewers;
+
+-- +goose StatementEnd
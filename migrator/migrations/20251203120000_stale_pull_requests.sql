@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS stale_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_at ON pull_requests(status, created_at);
+
+-- журнал автоматических действий над PR (пометка заброшенным, автозакрытие)
+CREATE TABLE IF NOT EXISTS pull_request_actions (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_actions_pull_request_id ON pull_request_actions(pull_request_id, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS pull_request_actions;
+
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at;
+
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS stale_at;
+
+-- +goose StatementEnd
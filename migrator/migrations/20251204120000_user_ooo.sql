@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- периоды отсутствия пользователей: в окне [starts_at, ends_at) пользователь не получает ревью
+CREATE TABLE IF NOT EXISTS user_ooo_periods (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    reassign_reviews BOOLEAN NOT NULL DEFAULT FALSE,
+    reassigned_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_ooo_periods_user_id_ends_at ON user_ooo_periods(user_id, ends_at);
+
+-- периоды, у которых ещё не переназначены ревью
+CREATE INDEX IF NOT EXISTS idx_user_ooo_periods_pending_reassign ON user_ooo_periods(starts_at)
+    WHERE reassign_reviews AND reassigned_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS user_ooo_periods;
+
+-- +goose StatementEnd
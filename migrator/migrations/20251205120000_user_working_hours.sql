@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- рабочее окно пользователя: часовой пояс IANA и время начала/конца в нём
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone TEXT NULL,
+    ADD COLUMN IF NOT EXISTS work_start TIME NULL,
+    ADD COLUMN IF NOT EXISTS work_end TIME NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_working_hours_complete
+    CHECK ((timezone IS NULL) = (work_start IS NULL) AND (timezone IS NULL) = (work_end IS NULL));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_working_hours_complete,
+    DROP COLUMN IF EXISTS work_end,
+    DROP COLUMN IF EXISTS work_start,
+    DROP COLUMN IF EXISTS timezone;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- правила владения кодом команды (шаблон пути -> пользователи/команды), порядок правил важен
+CREATE TABLE IF NOT EXISTS team_code_owners (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS team_code_owners;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- экспертиза пользователя и метки PR, по пересечению которых подбираются ревьюверы
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS tags;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS tags;
+
+-- +goose StatementEnd
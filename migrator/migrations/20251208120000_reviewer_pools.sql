@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- именованные пулы ревьюверов, участники которых могут быть из разных команд
+CREATE TABLE IF NOT EXISTS reviewer_pools (
+    name TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS reviewer_pool_members (
+    pool_name TEXT NOT NULL REFERENCES reviewer_pools(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (pool_name, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_pool_members_user_id ON reviewer_pool_members(user_id);
+
+-- политика команды: сколько ревьюверов из своей команды и сколько из каждого пула
+CREATE TABLE IF NOT EXISTS team_reviewer_policies (
+    team_name TEXT PRIMARY KEY REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    team_reviewers SMALLINT NOT NULL CHECK (team_reviewers >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS team_reviewer_pools (
+    team_name TEXT NOT NULL REFERENCES team_reviewer_policies(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    pool_name TEXT NOT NULL REFERENCES reviewer_pools(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    reviewers SMALLINT NOT NULL CHECK (reviewers > 0),
+    position SMALLINT NOT NULL,
+    PRIMARY KEY (team_name, pool_name)
+);
+
+-- из какого пула назначен ревьювер: при переназначении замена берётся из того же пула
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS pool_name TEXT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS pool_name;
+
+DROP TABLE IF EXISTS team_reviewer_pools;
+DROP TABLE IF EXISTS team_reviewer_policies;
+DROP INDEX IF EXISTS idx_reviewer_pool_members_user_id;
+DROP TABLE IF EXISTS reviewer_pool_members;
+DROP TABLE IF EXISTS reviewer_pools;
+
+-- +goose StatementEnd
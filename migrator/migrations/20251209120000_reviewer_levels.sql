@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- грейд пользователя и требование политики команды к грейду ревьюверов
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS level TEXT NULL
+    CHECK (level IN ('junior', 'middle', 'senior', 'lead'));
+
+ALTER TABLE team_reviewer_policies
+    ADD COLUMN IF NOT EXISTS min_level TEXT NULL
+        CHECK (min_level IN ('junior', 'middle', 'senior', 'lead')),
+    ADD COLUMN IF NOT EXISTS min_level_reviewers SMALLINT NOT NULL DEFAULT 0
+        CHECK (min_level_reviewers >= 0);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE team_reviewer_policies
+    DROP COLUMN IF EXISTS min_level_reviewers,
+    DROP COLUMN IF EXISTS min_level;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS level;
+
+-- +goose StatementEnd
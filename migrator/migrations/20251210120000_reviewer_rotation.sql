@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- настройки режима rotation и индекс для чтения последних PR автора
+ALTER TABLE team_reviewer_policies
+    ADD COLUMN IF NOT EXISTS rotation_window SMALLINT NOT NULL DEFAULT 0
+        CHECK (rotation_window BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS rotation_decay DOUBLE PRECISION NOT NULL DEFAULT 0
+        CHECK (rotation_decay >= 0 AND rotation_decay <= 1);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_id_created_at
+    ON pull_requests(author_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_pull_requests_author_id_created_at;
+
+ALTER TABLE team_reviewer_policies
+    DROP COLUMN IF EXISTS rotation_decay,
+    DROP COLUMN IF EXISTS rotation_window;
+
+-- +goose StatementEnd
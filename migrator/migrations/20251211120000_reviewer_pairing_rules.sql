@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- жёсткие правила пар автор-ревьювер: запрет (конфликт интересов) и обязательный ревьювер (наставник)
+CREATE TABLE IF NOT EXISTS reviewer_pairing_rules (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('exclude', 'require')),
+    author_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (author_id <> reviewer_id),
+    UNIQUE (author_id, reviewer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_pairing_rules_reviewer_id ON reviewer_pairing_rules(reviewer_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS reviewer_pairing_rules;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- записи о выборе ревьюверов: зерно генератора и входные данные, по которым выбор можно повторить
+CREATE TABLE IF NOT EXISTS assignment_decisions (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('create', 'reassign')),
+    seed BIGINT NOT NULL,
+    input JSONB NOT NULL,
+    reviewers TEXT[] NOT NULL DEFAULT '{}',
+    warnings TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_decisions_pull_request_id ON assignment_decisions(pull_request_id, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS assignment_decisions;
+
+-- +goose StatementEnd
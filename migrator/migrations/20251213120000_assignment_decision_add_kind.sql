@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- выбор дополнительных ревьюверов (/pullRequest/addReviewer) тоже записывается
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_kind_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_kind_check CHECK (kind IN ('create', 'reassign', 'add'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM assignment_decisions WHERE kind = 'add';
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_kind_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_kind_check CHECK (kind IN ('create', 'reassign'));
+
+-- +goose StatementEnd
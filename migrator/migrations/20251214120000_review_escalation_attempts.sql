@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- неудачная эскалация (заменить некем) откладывает следующую попытку, чтобы такие
+-- назначения не занимали всю выборку планировщика SLA
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS escalation_attempted_at TIMESTAMPTZ NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS escalation_attempted_at;
+
+-- +goose StatementEnd
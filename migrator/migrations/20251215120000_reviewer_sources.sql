@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- почему назначен ревьювер: владелец кода или указан явно; такие назначения не переносятся
+-- при выравнивании нагрузки и не снимаются при пересмотре состава
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS source TEXT NULL CHECK (source IN ('owner', 'requested'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS source;
+
+-- +goose StatementEnd
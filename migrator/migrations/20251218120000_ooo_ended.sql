@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- когда об окончании периода отсутствия отправлено событие user.ooo_ended
+ALTER TABLE user_ooo_periods ADD COLUMN IF NOT EXISTS ended_announced_at TIMESTAMPTZ NULL;
+
+-- периоды, закончившиеся до миграции, задним числом не объявляются
+UPDATE user_ooo_periods
+SET ended_announced_at = ends_at
+WHERE ends_at <= NOW() AND ended_announced_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_user_ooo_periods_pending_end ON user_ooo_periods(ends_at)
+    WHERE ended_announced_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_user_ooo_periods_pending_end;
+ALTER TABLE user_ooo_periods DROP COLUMN IF EXISTS ended_announced_at;
+
+-- +goose StatementEnd